@@ -10,6 +10,10 @@ def handler(event, _):
     try:
         record = event["Records"][0]
         key = record["s3"]["object"]["key"]
+        # Syllabi may be stored under a prefix of a shared bucket, the object key is the syllabus ID
+        _, _, prefix = (os.getenv("AWS_S3_SYLLABI_BUCKET") or "").strip("/").partition("/")
+        if prefix:
+            key = key.removeprefix(prefix + "/")
 
         # Algo must match Go server decode algo
         token = jwt.encode({}, jwt_secret, algorithm="HS256")
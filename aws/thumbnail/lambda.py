@@ -28,25 +28,58 @@ def generate_pdf_thumbnail(pdf_stream, max_size=(200, 260), dpi=72):
     return output
 
 
+def generate_pdf_pages(pdf_stream, max_pages=10, dpi=100):
+    """Converts the first pages of a PDF to preview JPEGs in memory."""
+    doc = fitz.open(stream=pdf_stream, filetype="pdf")
+    pages = []
+    for number in range(min(doc.page_count, max_pages)):
+        pix = doc.load_page(number).get_pixmap(dpi=dpi)
+        image = Image.frombytes("RGB", [pix.width, pix.height], pix.samples)
+
+        output = io.BytesIO()
+        image.save(output, format="JPEG", quality=80)
+        output.seek(0)
+        pages.append(output)
+
+    return pages
+
+
+def parse_location(value):
+    """Splits a "bucket" or "bucket/prefix" location, matching the Go server's bucket.ParseLocation."""
+    bucket, _, prefix = (value or "").strip("/").partition("/")
+    return bucket, prefix + "/" if prefix else ""
+
+
 def handler(event, _):
-    dest_bucket = os.getenv("AWS_S3_THUMBNAIL_BUCKET")
+    dest_bucket, dest_prefix = parse_location(os.getenv("AWS_S3_THUMBNAIL_BUCKET"))
+    _, src_prefix = parse_location(os.getenv("AWS_S3_SYLLABI_BUCKET"))
 
     try:
         record = event["Records"][0]
         src_bucket = record["s3"]["bucket"]["name"]
         key = record["s3"]["object"]["key"]
+        # Thumbnails are keyed by the syllabus object key, without the bucket prefix
+        object_key = key.removeprefix(src_prefix)
 
         # Retrieve syllabus from bucket
         pdf_stream = io.BytesIO()
         s3.download_fileobj(src_bucket, key, pdf_stream)
-        pdf_stream.seek(0)
 
+        pdf_stream.seek(0)
         thumb_buffer = generate_pdf_thumbnail(pdf_stream)
 
-        dest_key = key + ".jpg"
+        dest_key = dest_prefix + object_key + ".jpg"
         s3.upload_fileobj(
             thumb_buffer, dest_bucket, dest_key, ExtraArgs={"ContentType": "image/jpeg"}
         )
         print(f"syllabus thumbnail created: {key}")
+
+        pdf_stream.seek(0)
+        for number, page_buffer in enumerate(generate_pdf_pages(pdf_stream), start=1):
+            page_key = f"{dest_prefix}{object_key}/pages/{number}.jpg"
+            s3.upload_fileobj(
+                page_buffer, dest_bucket, page_key, ExtraArgs={"ContentType": "image/jpeg"}
+            )
+        print(f"syllabus previews created: {key}")
     except Exception as e:
         print(f"error processing record: {e}\n with event: {event}")
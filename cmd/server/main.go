@@ -12,6 +12,7 @@ import (
 	"github.com/JackieLi565/syllabye/internal/service/bucket"
 	"github.com/JackieLi565/syllabye/internal/service/database"
 	"github.com/JackieLi565/syllabye/internal/service/emailer"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
 	"github.com/JackieLi565/syllabye/internal/service/logger"
 	"github.com/JackieLi565/syllabye/internal/service/openid"
 	"github.com/JackieLi565/syllabye/internal/service/queue"
@@ -51,9 +52,15 @@ func main() {
 
 	googleOpenId := openid.NewGoogleOpenIdProvider(log)
 	s3Presigner := bucket.NewS3Presigner(log, s3Client, os.Getenv(config.AWS_S3_SYLLABI_BUCKET))
+	s3Object := bucket.NewS3Object(log, s3Client, os.Getenv(config.AWS_S3_SYLLABI_BUCKET))
+	s3ThumbnailPresigner := bucket.NewS3Presigner(log, s3Client, os.Getenv(config.AWS_S3_THUMBNAIL_BUCKET))
+	s3ThumbnailObject := bucket.NewS3Object(log, s3Client, os.Getenv(config.AWS_S3_THUMBNAIL_BUCKET))
+	s3AvatarPresigner := bucket.NewS3Presigner(log, s3Client, os.Getenv(config.AWS_S3_AVATAR_BUCKET))
+	s3AvatarObject := bucket.NewS3Object(log, s3Client, os.Getenv(config.AWS_S3_AVATAR_BUCKET))
 	jwt := authorizer.NewJwtAuthorizer(os.Getenv(config.JwtSecret)) // TODO: add logger
 	webhookQueue := queue.NewSqsWebhook(log, sqsClient)
 	sesEmailer := emailer.NewSesNoReply(log, sesClient)
+	documentExtractor := extractor.NewDocumentExtractor(log)
 
 	// Repositories
 	pgProgramRepo := repository.NewPgProgramRepository(db, log)
@@ -63,16 +70,42 @@ func main() {
 	pgCourseCategoryRepo := repository.NewPgCourseCategoryRepository(db, log)
 	pgCourseRepo := repository.NewPgCourseRepository(db, log)
 	pgSyllabusRepo := repository.NewPgSyllabusRepository(db, log)
+	pgSuspensionRepo := repository.NewPgSuspensionRepository(db, log)
+	pgUploadRepo := repository.NewPgUploadRepository(db, log)
+	pgSearchRepo := repository.NewPgSearchRepository(db, log)
+	pgDetailsRepo := repository.NewPgDetailsRepository(db, log)
+	pgCalendarRepo := repository.NewPgCalendarRepository(db, log)
+	pgCommentRepo := repository.NewPgCommentRepository(db, log)
+	pgReviewRepo := repository.NewPgReviewRepository(db, log)
+	pgCollectionRepo := repository.NewPgCollectionRepository(db, log)
+	pgRequestRepo := repository.NewPgSyllabusRequestRepository(db, log)
+	pgInstructorRepo := repository.NewPgInstructorRepository(db, log)
+	pgClaimRepo := repository.NewPgClaimRepository(db, log)
+	pgRemovalRepo := repository.NewPgRemovalRepository(db, log)
+	pgTermRepo := repository.NewPgTermRepository(db, log)
+	pgRequisiteRepo := repository.NewPgRequisiteRepository(db, log)
+	pgRequirementRepo := repository.NewPgRequirementRepository(db, log)
 
 	// Handlers
 	utilHandler := handler.NewUtilHandler()
-	authHandler := handler.NewAuthHandler(log, pgUserRepo, pgSessionRepo, googleOpenId, jwt, sesEmailer)
-	programHandler := handler.NewProgramHandler(log, pgProgramRepo)
+	authHandler := handler.NewAuthHandler(log, pgUserRepo, pgSessionRepo, pgSuspensionRepo, googleOpenId, jwt, sesEmailer)
+	programHandler := handler.NewProgramHandler(log, pgProgramRepo, pgRequirementRepo)
 	facultyHandler := handler.NewFacultyHandler(log, pgFacultyRepo)
 	courseCategoryHandler := handler.NewCourseCategoryHandler(log, pgCourseCategoryRepo)
-	courseHandler := handler.NewCourseHandler(log, pgCourseRepo)
-	userHandler := handler.NewUserHandler(log, pgUserRepo)
-	syllabusHandler := handler.NewSyllabusHandler(log, pgSyllabusRepo, s3Presigner, jwt, webhookQueue, sesEmailer)
+	courseHandler := handler.NewCourseHandler(log, pgCourseRepo, pgRequisiteRepo)
+	reviewHandler := handler.NewReviewHandler(log, pgReviewRepo)
+	userHandler := handler.NewUserHandler(log, pgUserRepo, pgSyllabusRepo, pgRequirementRepo, s3AvatarPresigner, s3AvatarObject, s3ThumbnailPresigner)
+	syllabusHandler := handler.NewSyllabusHandler(log, pgSyllabusRepo, pgUploadRepo, pgRequestRepo, s3Presigner, s3Object, s3ThumbnailPresigner, s3ThumbnailObject, jwt, webhookQueue, sesEmailer)
+	searchHandler := handler.NewSearchHandler(log, pgSearchRepo, pgSyllabusRepo, pgDetailsRepo, s3Object, s3ThumbnailPresigner, documentExtractor)
+	detailsHandler := handler.NewDetailsHandler(log, pgDetailsRepo)
+	calendarHandler := handler.NewCalendarHandler(log, pgCalendarRepo, pgTermRepo)
+	commentHandler := handler.NewCommentHandler(log, pgCommentRepo, sesEmailer)
+	requestHandler := handler.NewRequestHandler(log, pgRequestRepo)
+	instructorHandler := handler.NewInstructorHandler(log, pgInstructorRepo, pgClaimRepo, pgRemovalRepo, pgSyllabusRepo, s3ThumbnailPresigner)
+	termHandler := handler.NewTermHandler(log, pgTermRepo)
+	collectionHandler := handler.NewCollectionHandler(log, pgCollectionRepo, pgSyllabusRepo, s3ThumbnailPresigner)
+	uploadHandler := handler.NewUploadHandler(log, pgUploadRepo, pgSyllabusRepo, s3Object)
+	adminHandler := handler.NewAdminHandler(log, pgUserRepo, pgSuspensionRepo, pgSyllabusRepo, pgCommentRepo, pgClaimRepo, pgRemovalRepo, pgTermRepo, pgRequisiteRepo, pgRequirementRepo, sesEmailer)
 
 	r := chi.NewRouter()
 	r.Use(utilHandler.RequestIdMiddleware)
@@ -106,6 +139,7 @@ func main() {
 
 	r.Route(basePath, func(r chi.Router) {
 		r.Get("/logout", authHandler.Logout)
+		r.Get("/icons/{icon}", syllabusHandler.GetSyllabusIcon)
 
 		r.Route("/providers/google", func(r chi.Router) {
 			if env == "development" {
@@ -120,6 +154,32 @@ func main() {
 			r.Use(utilHandler.JsonMiddleware)
 
 			r.Get("/", authHandler.SessionCheck)
+
+			r.Route("/collections", func(r chi.Router) {
+				r.Use(authHandler.AuthMiddleware)
+
+				r.Get("/", collectionHandler.ListCollections)
+				r.Post("/", collectionHandler.CreateCollection)
+
+				r.Route("/{collectionId}", func(r chi.Router) {
+					r.Get("/", collectionHandler.GetCollection)
+					r.Patch("/", collectionHandler.UpdateCollection)
+					r.Delete("/", collectionHandler.DeleteCollection)
+
+					r.Route("/syllabi", func(r chi.Router) {
+						r.Post("/", collectionHandler.AddCollectionSyllabus)
+						r.Put("/", collectionHandler.ReorderCollection)
+						r.Delete("/{syllabusId}", collectionHandler.RemoveCollectionSyllabus)
+					})
+				})
+			})
+		})
+
+		r.Route("/collections", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(utilHandler.JsonMiddleware)
+
+			r.Get("/shared/{shareToken}", collectionHandler.GetSharedCollection)
 		})
 
 		r.Route("/programs", func(r chi.Router) {
@@ -128,6 +188,7 @@ func main() {
 
 			r.Get("/", programHandler.ListPrograms)
 			r.Get("/{programId}", programHandler.GetProgram)
+			r.Get("/{programId}/requirements", programHandler.ListProgramRequirements)
 		})
 
 		r.Route("/faculties", func(r chi.Router) {
@@ -138,14 +199,37 @@ func main() {
 			r.Get("/{facultyId}", facultyHandler.GetFaculty)
 		})
 
+		// Calendar apps fetch the feed without a session
+		r.Get("/users/{userId}/calendar.ics", calendarHandler.GetUserCalendarFeed)
+
 		r.Route("/users", func(r chi.Router) {
 			r.Use(authHandler.AuthMiddleware)
 			r.Use(utilHandler.JsonMiddleware)
 
 			r.Get("/exists", userHandler.SearchUserNickname)
+			r.Get("/@{nickname}", userHandler.GetUserByNickname)
 			r.Route("/{userId}", func(r chi.Router) {
 				r.Get("/", userHandler.GetUser)
 				r.Patch("/", userHandler.UpdateUser)
+				r.Post("/calendar", calendarHandler.CreateCalendarSubscription)
+				r.Delete("/calendar", calendarHandler.DeleteCalendarSubscription)
+				r.Get("/progress", userHandler.GetUserProgress)
+
+				r.Route("/avatar", func(r chi.Router) {
+					r.Post("/", userHandler.UploadAvatar)
+					r.Put("/", userHandler.ConfirmAvatar)
+					r.Delete("/", userHandler.DeleteAvatar)
+				})
+
+				r.Route("/links", func(r chi.Router) {
+					r.Get("/", userHandler.ListUserLinks)
+					r.Post("/", userHandler.AddUserLink)
+
+					r.Route("/{linkId}", func(r chi.Router) {
+						r.Put("/", userHandler.UpdateUserLink)
+						r.Delete("/", userHandler.DeleteUserLink)
+					})
+				})
 
 				r.Route("/courses", func(r chi.Router) {
 					r.Post("/", userHandler.AddUserCourse)
@@ -164,7 +248,24 @@ func main() {
 			r.Use(utilHandler.JsonMiddleware)
 
 			r.Get("/", courseHandler.ListCourses)
-			r.Get("/{courseId}", courseHandler.GetCourse)
+			r.Route("/{courseId}", func(r chi.Router) {
+				r.Get("/", courseHandler.GetCourse)
+				r.Get("/requisites", courseHandler.GetCourseRequisites)
+				r.Get("/unlocks", courseHandler.ListUnlockedCourses)
+				r.Get("/eligibility", courseHandler.GetCourseEligibility)
+
+				r.Route("/reviews", func(r chi.Router) {
+					r.Get("/", reviewHandler.ListCourseReviews)
+					r.Post("/", reviewHandler.CreateCourseReview)
+
+					r.Route("/{reviewId}", func(r chi.Router) {
+						r.Put("/", reviewHandler.UpdateCourseReview)
+						r.Delete("/", reviewHandler.DeleteCourseReview)
+					})
+				})
+
+				r.Post("/requests", requestHandler.CreateSyllabusRequest)
+			})
 
 			r.Route("/categories", func(r chi.Router) {
 				r.Get("/", courseCategoryHandler.ListCourseCategories)
@@ -172,12 +273,48 @@ func main() {
 			})
 		})
 
+		r.Route("/instructors", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(utilHandler.JsonMiddleware)
+
+			r.Get("/", instructorHandler.ListInstructors)
+			r.Post("/", instructorHandler.CreateInstructor)
+			r.Route("/{instructorId}", func(r chi.Router) {
+				r.Get("/", instructorHandler.GetInstructor)
+				r.Get("/syllabi", instructorHandler.ListInstructorSyllabi)
+				r.Post("/claims", instructorHandler.CreateInstructorClaim)
+			})
+		})
+
+		r.Route("/terms", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(utilHandler.JsonMiddleware)
+
+			r.Get("/", termHandler.ListTerms)
+			r.Get("/{termId}", termHandler.GetTerm)
+		})
+
+		r.Route("/requests", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(utilHandler.JsonMiddleware)
+
+			r.Get("/", requestHandler.ListSyllabusRequests)
+			r.Route("/{requestId}/vote", func(r chi.Router) {
+				r.Put("/", requestHandler.VoteSyllabusRequest)
+				r.Delete("/", requestHandler.RemoveSyllabusRequestVote)
+			})
+		})
+
 		r.Route("/syllabi", func(r chi.Router) {
 			r.Use(authHandler.AuthMiddleware)
 			r.Use(utilHandler.JsonMiddleware)
 
 			r.Post("/", syllabusHandler.CreateSyllabus)
 			r.Get("/", syllabusHandler.ListSyllabi)
+			r.Head("/", syllabusHandler.CheckSyllabusChecksum)
+			r.Post("/batch", syllabusHandler.CreateSyllabusBatch)
+			r.Get("/search", searchHandler.SearchSyllabi)
+			r.Get("/batches/{batchId}/verify", syllabusHandler.VerifySyllabusBatch)
 
 			r.Route("/{syllabusId}", func(r chi.Router) {
 				r.Get("/", syllabusHandler.GetSyllabus)
@@ -185,6 +322,37 @@ func main() {
 				r.Delete("/", syllabusHandler.DeleteSyllabus)
 				r.Get("/sync", syllabusHandler.SyncSyllabus)
 				r.Get("/verify", syllabusHandler.VerifySyllabus)
+				r.Get("/extract", searchHandler.ExtractSyllabusText)
+				r.Get("/details", detailsHandler.GetSyllabusDetails)
+				r.Put("/details", detailsHandler.UpdateSyllabusDetails)
+				r.Get("/calendar.ics", calendarHandler.GetSyllabusCalendar)
+				r.Get("/preview", syllabusHandler.GetSyllabusPreview)
+				r.Get("/download", syllabusHandler.DownloadSyllabus)
+				r.Put("/bookmark", collectionHandler.BookmarkSyllabus)
+				r.Delete("/bookmark", collectionHandler.RemoveBookmark)
+				r.Post("/removal-requests", instructorHandler.CreateRemovalRequest)
+
+				r.Route("/comments", func(r chi.Router) {
+					r.Get("/", commentHandler.ListComments)
+					r.Post("/", commentHandler.CreateComment)
+
+					r.Route("/{commentId}", func(r chi.Router) {
+						r.Patch("/", commentHandler.UpdateComment)
+						r.Delete("/", commentHandler.DeleteComment)
+						r.Get("/replies", commentHandler.ListCommentReplies)
+					})
+				})
+
+				r.Route("/revisions", func(r chi.Router) {
+					r.Get("/", syllabusHandler.ListSyllabusRevisions)
+					r.Post("/", syllabusHandler.CreateSyllabusRevision)
+
+					r.Route("/{revision}", func(r chi.Router) {
+						r.Get("/", syllabusHandler.GetSyllabusRevision)
+						r.Get("/sync", syllabusHandler.SyncSyllabusRevision)
+						r.Get("/verify", syllabusHandler.VerifySyllabusRevision)
+					})
+				})
 
 				r.Route("/reactions", func(r chi.Router) {
 					r.Get("/", syllabusHandler.ListSyllabusLikes)
@@ -194,6 +362,44 @@ func main() {
 				})
 			})
 		})
+
+		r.Route("/uploads", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(uploadHandler.TusMiddleware)
+
+			r.Options("/", uploadHandler.GetUploadOptions)
+			r.Post("/", uploadHandler.CreateUpload)
+
+			r.Route("/{uploadId}", func(r chi.Router) {
+				r.Head("/", uploadHandler.GetUploadOffset)
+				r.Patch("/", uploadHandler.UploadChunk)
+				r.Delete("/", uploadHandler.TerminateUpload)
+			})
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(authHandler.AuthMiddleware)
+			r.Use(authHandler.AdminMiddleware)
+			r.Use(utilHandler.JsonMiddleware)
+
+			r.Route("/users/{userId}/suspensions", func(r chi.Router) {
+				r.Get("/", adminHandler.ListUserSuspensions)
+				r.Post("/", adminHandler.SuspendUser)
+				r.Delete("/", adminHandler.LiftUserSuspension)
+			})
+
+			r.Put("/syllabi/{syllabusId}/status", adminHandler.UpdateSyllabusStatus)
+			r.Post("/syllabi/extract", syllabusHandler.QueueSyllabusExtractions)
+			r.Put("/comments/{commentId}/removal", adminHandler.RemoveComment)
+
+			r.Get("/instructor-claims", adminHandler.ListInstructorClaims)
+			r.Put("/instructor-claims/{claimId}", adminHandler.ReviewInstructorClaim)
+			r.Get("/removal-requests", adminHandler.ListRemovalRequests)
+			r.Put("/removal-requests/{requestId}", adminHandler.ReviewRemovalRequest)
+			r.Post("/terms", adminHandler.CreateTerm)
+			r.Put("/courses/{courseId}/requisites", adminHandler.SetCourseRequisites)
+			r.Put("/programs/{programId}/requirements", adminHandler.SetProgramRequirements)
+		})
 	})
 
 	http.ListenAndServe(":" + os.Getenv("PORT"), r)
@@ -17,51 +17,67 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/courses": {
-            "get": {
+        "/admin/comments/{commentId}/removal": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Course"
+                    "Admin"
                 ],
-                "summary": "List courses",
+                "summary": "Remove a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by course name or code",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by category ID",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size (default: 25)",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Removal reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RemoveCommentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/CourseResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Comment is already removed",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -73,33 +89,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/categories": {
-            "get": {
+        "/admin/courses/{courseId}/requisites": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Groups require all or any of their courses and nested groups, nested at most 5 levels deep.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Course Category"
+                    "Admin"
                 ],
-                "summary": "List course categories",
+                "summary": "Set course requisites",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search keyword",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Requisite type and group",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetCourseRequisitesRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/CourseCategoryResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or required course not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -111,7 +156,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/categories/{categoryId}": {
+        "/admin/instructor-claims": {
             "get": {
                 "security": [
                     {
@@ -119,23 +164,49 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "Course Category"
+                    "Admin"
                 ],
-                "summary": "Retrieves a course category.",
+                "summary": "List instructor claims",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category ID",
-                        "name": "categoryId",
-                        "in": "path",
-                        "required": true
+                        "description": "Claim status (default: Pending)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CourseCategoryResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/InstructorClaimResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -147,31 +218,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{courseId}": {
-            "get": {
+        "/admin/instructor-claims/{claimId}": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Approving verifies the user as the instructor and rejects the instructor's other pending claims.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Course"
+                    "Admin"
                 ],
-                "summary": "Get a course",
+                "summary": "Review an instructor claim",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "courseId",
+                        "description": "Claim ID",
+                        "name": "claimId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Approved or Rejected, a reason is required for rejections",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ApprovalReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/CourseResponse"
+                            "$ref": "#/definitions/InstructorClaimResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Claim is already reviewed, or the instructor or user is already verified",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -183,33 +291,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/faculties": {
-            "get": {
+        "/admin/programs/{programId}/requirements": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Requirements are evaluated and listed in the given order, an empty list removes the program's requirements.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Faculty"
+                    "Admin"
                 ],
-                "summary": "List faculties",
+                "summary": "Set program requirements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by faculty name",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Program ID",
+                        "name": "programId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Program requirements",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetProgramRequirementsRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/FacultyResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Program, course or category not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -221,7 +358,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/faculties/{facultyId}": {
+        "/admin/removal-requests": {
             "get": {
                 "security": [
                     {
@@ -229,44 +366,53 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "Faculty"
+                    "Admin"
                 ],
-                "summary": "Get a faculty",
+                "summary": "List removal requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Faculty ID",
-                        "name": "facultyId",
-                        "in": "path",
-                        "required": true
+                        "description": "Request status (default: Pending)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/FacultyResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RemovalRequestResponse"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/logout": {
-            "get": {
-                "description": "Removes the users session cookie if exists.",
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Logout user session",
-                "responses": {
-                    "302": {
-                        "description": "Redirects to root page",
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -274,27 +420,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
-            "get": {
+        "/admin/removal-requests/{requestId}": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
-                "description": "Validates the session cookie and returns session payload if authenticated.",
+                "description": "Approving removes the syllabus if it is still published and emails its uploader.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
+                ],
+                "summary": "Review a removal request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Removal request ID",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approved or Rejected, a reason is required for rejections",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ApprovalReviewRequest"
+                        }
+                    }
                 ],
-                "summary": "Check user session",
                 "responses": {
                     "200": {
-                        "description": "Valid session",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/SessionResponse"
+                            "$ref": "#/definitions/RemovalRequestResponse"
                         }
                     },
-                    "401": {
-                        "description": "Missing or invalid session cookie",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Request is already reviewed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -302,28 +493,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/programs": {
-            "get": {
+        "/admin/syllabi/extract": {
+            "post": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
                 "tags": [
-                    "Program"
+                    "Admin"
                 ],
-                "summary": "List programs",
+                "summary": "Queue missing syllabus text extractions",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by faculty ID",
-                        "name": "faculty",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by program name or code",
-                        "name": "search",
+                        "type": "integer",
+                        "description": "Maximum number of syllabi to queue (default: 25)",
+                        "name": "size",
                         "in": "query"
                     }
                 ],
@@ -331,10 +516,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/ProgramResponse"
-                            }
+                            "$ref": "#/definitions/QueueSyllabusExtractionsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -346,31 +534,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/programs/{programId}": {
-            "get": {
+        "/admin/syllabi/{syllabusId}/status": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Verifying syllabi may be Rejected, Published syllabi may be Removed and Removed syllabi may be restored to Published. The uploader is emailed of rejections and removals.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Program"
+                    "Admin"
                 ],
-                "summary": "Get a program",
+                "summary": "Moderate a syllabus",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Program ID",
-                        "name": "programId",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New status (Published, Rejected, Removed), a reason is required for rejections and removals",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSyllabusStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/ProgramResponse"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed from the current status",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -382,30 +607,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/providers/google": {
-            "get": {
-                "description": "Validates an optional redirect query param and redirects the user to the OpenID login flow.",
+        "/admin/terms": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Syllabi can only reference existing terms, whether by id or by year and semester.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Redirect to OpenID consent screen",
+                "summary": "Create a term",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Optional redirect URL after login",
-                        "name": "redirect",
-                        "in": "query"
+                        "description": "Term data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTermRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Redirects to OpenID consent screen",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/TermResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The institution already has the term",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Unable to continue to OpenID provider",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -413,7 +667,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/syllabi": {
+        "/admin/users/{userId}/suspensions": {
             "get": {
                 "security": [
                     {
@@ -421,45 +675,16 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Admin"
                 ],
-                "summary": "List syllabi",
+                "summary": "List user suspensions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by user ID",
+                        "description": "User ID",
                         "name": "userId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by course ID",
-                        "name": "courseId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by year",
-                        "name": "year",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by semester",
-                        "name": "semester",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size (default: 10)",
-                        "name": "size",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -468,7 +693,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/SyllabusResponse"
+                                "$ref": "#/definitions/UserSuspensionResponse"
                             }
                         }
                     },
@@ -478,6 +703,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -492,21 +723,29 @@ const docTemplate = `{
                         "Session": []
                     }
                 ],
+                "description": "Suspended users are denied on every authenticated request and cannot start new sessions.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Admin"
                 ],
-                "summary": "Create a syllabus",
+                "summary": "Suspend a user",
                 "parameters": [
                     {
-                        "description": "Syllabus data",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension reason and optional duration in hours, omit the duration for a permanent suspension",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateSyllabusRequest"
+                            "$ref": "#/definitions/SuspendUserRequest"
                         }
                     }
                 ],
@@ -515,16 +754,6 @@ const docTemplate = `{
                         "description": "Created",
                         "schema": {
                             "type": "string"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "URL to access the created syllabus"
-                            },
-                            "X-Presigned-Url": {
-                                "type": "string",
-                                "description": "Presigned URL to upload the syllabus file"
-                            }
                         }
                     },
                     "400": {
@@ -533,56 +762,20 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/syllabi/{syllabusId}": {
-            "get": {
-                "security": [
-                    {
-                        "Session": []
-                    }
-                ],
-                "tags": [
-                    "Syllabus"
-                ],
-                "summary": "Get a syllabus",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/SyllabusResponse"
-                        },
-                        "headers": {
-                            "X-Presigned-Url": {
-                                "type": "string",
-                                "description": "Presigned URL to access the syllabus file"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "User is already suspended",
                         "schema": {
                             "type": "string"
                         }
@@ -602,14 +795,14 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Admin"
                 ],
-                "summary": "Delete a syllabus",
+                "summary": "Lift a user suspension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     }
@@ -621,6 +814,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -628,7 +827,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "User is not suspended",
                         "schema": {
                             "type": "string"
                         }
@@ -640,52 +839,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/collections/shared/{shareToken}": {
+            "get": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Collection"
                 ],
-                "summary": "Update a syllabus",
+                "summary": "Get a shared collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
+                        "description": "Share token of the collection link",
+                        "name": "shareToken",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated syllabus data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateSyllabusRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "URL to access the updated syllabus"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/CollectionResponse"
                         }
                     },
                     "404": {
@@ -703,40 +883,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/syllabi/{syllabusId}/reaction": {
-            "post": {
+        "/courses": {
+            "get": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Courses are ordered by code unless sorted by a rating, courses without reviews are listed last.",
                 "tags": [
-                    "Syllabus"
+                    "Course"
                 ],
-                "summary": "React to a syllabus",
+                "summary": "List courses",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
-                        "in": "path",
-                        "required": true
+                        "description": "Search by course name or code",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
-                        "description": "Reaction action",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/SyllabusReactionRequest"
-                        }
+                        "type": "string",
+                        "description": "Filter by category ID",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by difficulty, workload, usefulness or reviews",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CourseResponse"
+                            }
                         }
                     },
                     "400": {
@@ -745,12 +949,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -758,37 +956,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/courses/categories": {
+            "get": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Course Category"
                 ],
-                "summary": "Remove syllabus reaction",
+                "summary": "List course categories",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
-                        "in": "path",
-                        "required": true
+                        "description": "Search keyword",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CourseCategoryResponse"
+                            }
                         }
                     },
                     "500": {
@@ -800,7 +996,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/syllabi/{syllabusId}/reactions": {
+        "/courses/categories/{categoryId}": {
             "get": {
                 "security": [
                     {
@@ -808,14 +1004,14 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "Syllabus"
+                    "Course Category"
                 ],
-                "summary": "List syllabus reactions",
+                "summary": "Retrieves a course category.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Syllabus ID",
-                        "name": "syllabusId",
+                        "description": "Category ID",
+                        "name": "categoryId",
                         "in": "path",
                         "required": true
                     }
@@ -824,16 +1020,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/SyllabusReactionResponse"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/CourseCategoryResponse"
                         }
                     },
                     "500": {
@@ -845,7 +1032,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/exists": {
+        "/courses/{courseId}": {
             "get": {
                 "security": [
                     {
@@ -853,22 +1040,23 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "Check existing nickname",
+                "summary": "Get a course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search user nickname",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/NicknameExistsResponse"
+                            "$ref": "#/definitions/CourseResponse"
                         }
                     },
                     "500": {
@@ -880,22 +1068,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{userId}": {
+        "/courses/{courseId}/eligibility": {
             "get": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Evaluates the course's requisites against the user's courses. Courses count as completed once their term has ended. Prerequisites met only by courses without a term are reported as unknown rather than met. Antirequisites include courses in progress.",
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "Get a user",
+                "summary": "Check course eligibility",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
                     }
@@ -904,7 +1093,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/UserResponse"
+                            "$ref": "#/definitions/CourseEligibilityResponse"
                         }
                     },
                     "400": {
@@ -926,46 +1115,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/courses/{courseId}/requests": {
+            "post": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Requests a syllabus for a course, optionally for a term. Requesting a syllabus which is already requested votes for the existing request instead.\nUsers who voted for a request are emailed once a matching syllabus is published.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Request"
                 ],
-                "summary": "Update a user",
+                "summary": "Request a syllabus",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated user data",
+                        "description": "Year and semester, both omitted to request any term",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateUserRequest"
+                            "$ref": "#/definitions/SyllabusRequestRequest"
                         }
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Existing request voted for",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusRequestResponse"
+                        }
+                    },
                     "201": {
-                        "description": "Created",
+                        "description": "Request created",
                         "schema": {
-                            "type": "string"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "URL to access the updated user"
-                            }
+                            "$ref": "#/definitions/SyllabusRequestResponse"
                         }
                     },
                     "400": {
@@ -974,14 +1169,14 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Syllabus is already available",
                         "schema": {
                             "type": "string"
                         }
@@ -995,45 +1190,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{userId}/courses": {
+        "/courses/{courseId}/requisites": {
             "get": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "description": "Requisites are trees of groups, each requiring all or any of its courses and nested groups. Types without requisites are null.",
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "List user courses",
+                "summary": "Get course requisites",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CourseRequisitesResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Search by name or course code",
-                        "name": "search",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{courseId}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Most recent first, aggregate ratings are included in the course.",
+                "tags": [
+                    "Course"
+                ],
+                "summary": "List course reviews",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by category ID",
-                        "name": "category",
-                        "in": "query"
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Page number (default: 1)",
                         "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Page size (default: 25)",
                         "name": "size",
                         "in": "query"
@@ -1045,10 +1278,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/UserCourseResponse"
+                                "$ref": "#/definitions/CourseReviewResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1063,25 +1302,29 @@ const docTemplate = `{
                         "Session": []
                     }
                 ],
+                "description": "Users may review each course in their course history once.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "Add a user course",
+                "summary": "Review a course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User course data",
+                        "description": "Ratings from 1 to 5, the term the course was taken and optional text of at most 5000 characters",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateUserCourseRequest"
+                            "$ref": "#/definitions/CourseReviewRequest"
                         }
                     }
                 ],
@@ -1089,7 +1332,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/CourseReviewResponse"
                         }
                     },
                     "400": {
@@ -1099,13 +1342,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Course is not in the user's course history",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Course is already reviewed",
                         "schema": {
                             "type": "string"
                         }
@@ -1119,38 +1362,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{userId}/courses/{courseId}": {
-            "delete": {
+        "/courses/{courseId}/reviews/{reviewId}": {
+            "put": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "Delete a user course",
+                "summary": "Update a course review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "courseId",
+                        "description": "Review ID",
+                        "name": "reviewId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Ratings from 1 to 5, the term the course was taken and optional text of at most 5000 characters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CourseReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/CourseReviewResponse"
                         }
                     },
                     "400": {
@@ -1159,12 +1414,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1179,39 +1428,30 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "Session": []
                     }
                 ],
                 "tags": [
-                    "User"
+                    "Course"
                 ],
-                "summary": "Update a user course",
+                "summary": "Delete a course review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Course ID",
+                        "name": "courseId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "courseId",
+                        "description": "Review ID",
+                        "name": "reviewId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated course data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateUserCourseRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -1227,20 +1467,66 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/courses/{courseId}/unlocks": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Courses listing the course anywhere in their prerequisites or corequisites.",
+                "tags": [
+                    "Course"
+                ],
+                "summary": "List courses unlocked by a course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
                     },
-                    "409": {
-                        "description": "Conflict",
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UnlockedCourseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
@@ -1253,64 +1539,5564 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "CourseCategoryResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+        },
+        "/faculties": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Faculty"
+                ],
+                "summary": "List faculties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by faculty name",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/FacultyResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "CourseResponse": {
+        "/faculties/{facultyId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Faculty"
+                ],
+                "summary": "Get a faculty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Faculty ID",
+                        "name": "facultyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/FacultyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/instructors": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Clients should search by name before adding an instructor, similar names are listed most similar first to avoid duplicates.",
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "List instructors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fuzzy match on the instructor's name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instructors with published syllabi for the course",
+                        "name": "courseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/InstructorResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "Add an instructor",
+                "parameters": [
+                    {
+                        "description": "Name of at most 100 characters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InstructorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/InstructorResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the created instructor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "An instructor with the same name exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/instructors/{instructorId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "Get an instructor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID",
+                        "name": "instructorId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/InstructorDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/instructors/{instructorId}/claims": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Claims are reviewed by an admin, once approved the user's uploads for the instructor are marked official.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "Claim an instructor profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID",
+                        "name": "instructorId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional message of at most 1000 characters for the reviewing admin",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InstructorClaimRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/InstructorClaimResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Instructor or user is already verified, or a claim is pending",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/instructors/{instructorId}/syllabi": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "List an instructor's syllabi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID",
+                        "name": "instructorId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by course ID",
+                        "name": "courseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "get": {
+                "description": "Removes the users session cookie if exists.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout user session",
+                "responses": {
+                    "302": {
+                        "description": "Redirects to root page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Validates the session cookie and returns session payload if authenticated.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Check user session",
+                "responses": {
+                    "200": {
+                        "description": "Valid session",
+                        "schema": {
+                            "$ref": "#/definitions/SessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid session cookie",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/collections": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "List collections",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CollectionResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Create a collection",
+                "parameters": [
+                    {
+                        "description": "Name of at most 100 characters, optional description and visibility",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/collections/{collectionId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Get a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The default collection cannot be deleted.",
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Delete a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The default collection cannot be renamed. Making a collection private replaces its share link, so links shared before stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Update a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/collections/{collectionId}/syllabi": {
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Syllabi which were removed, rejected or expired are not listed, they keep their order after the listed syllabi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Reorder a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Every syllabus listed in the collection, in the new order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReorderCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Add a syllabus to a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Syllabus to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddCollectionSyllabusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Syllabus is already in the collection",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/collections/{collectionId}/syllabi/{syllabusId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Remove a syllabus from a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Program"
+                ],
+                "summary": "List programs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by faculty ID",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by program name or code",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProgramResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/{programId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Program"
+                ],
+                "summary": "Get a program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program ID",
+                        "name": "programId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProgramResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/programs/{programId}/requirements": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Required requirements need every listed course. Elective requirements need credits from the listed courses and categories,\nCredits requirements count credits from any course when no course or category is listed.",
+                "tags": [
+                    "Program"
+                ],
+                "summary": "List program requirements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program ID",
+                        "name": "programId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ProgramRequirementResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/providers/google": {
+            "get": {
+                "description": "Validates an optional redirect query param and redirects the user to the OpenID login flow.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Redirect to OpenID consent screen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Optional redirect URL after login",
+                        "name": "redirect",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirects to OpenID consent screen",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unable to continue to OpenID provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Requests with the most votes first. Only open requests are listed unless another status is given.",
+                "tags": [
+                    "Request"
+                ],
+                "summary": "List syllabus requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request status (default: Open)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusRequestResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests/{requestId}/vote": {
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Request"
+                ],
+                "summary": "Vote for a syllabus request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Request is already fulfilled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Request"
+                ],
+                "summary": "Remove a syllabus request vote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "List syllabi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by course ID",
+                        "name": "courseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by semester",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by term ID, or current for the current term",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status, syllabi other than Published are only listed for their owner",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by instructor ID",
+                        "name": "instructorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Create a syllabus",
+                "parameters": [
+                    {
+                        "description": "Syllabus data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSyllabusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the created syllabus"
+                            },
+                            "X-Presigned-Url": {
+                                "type": "string",
+                                "description": "Presigned URL to upload the syllabus file"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or instructor not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "An identical syllabus exists for the course and term, linked by the Location header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Matches published syllabi and the user's own pending uploads by the CRC32 checksum of the file.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Check for a duplicate syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64 encoded CRC32 checksum of the file",
+                        "name": "checksum",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restrict the check to a course",
+                        "name": "courseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Restrict the check to a year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restrict the check to a semester",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A matching syllabus exists",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the matching syllabus"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No matching syllabus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/batch": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Valid syllabi are created together and invalid ones are reported without failing the batch.\nA single summary email is sent once the batch's upload window has passed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Create a batch of syllabi",
+                "parameters": [
+                    {
+                        "description": "Up to 25 syllabi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSyllabusBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/search": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Supports web search syntax, such as \"quoted phrases\", or and -excluded words.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Search syllabi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusSearchResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Get a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Delete a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Update a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated syllabus data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSyllabusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the updated syllabus"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/bookmark": {
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Bookmarks are kept in the default \"Saved\" collection, which is created by the first bookmark.",
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Bookmark a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Collection"
+                ],
+                "summary": "Remove a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Events keep the same UID across exports, so re-importing updates rather than duplicates them.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Export syllabus assessments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Newest first. Deleted and removed comments are listed as placeholders while they have replies.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "List syllabus comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CommentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The syllabus uploader is notified of new comments and authors are notified of replies by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Create a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment of at most 2000 characters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Syllabus is not published or the parent comment was deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/comments/{commentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment of at most 2000 characters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Comment was deleted or removed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/comments/{commentId}/replies": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Oldest first, including placeholders of deleted and removed replies. Nest replies by their parent ID.",
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "List comment replies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/CommentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/details": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Details are parsed from the syllabus text once it is published and may be corrected by the uploader.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Get syllabus details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Only the uploader may edit details. Corrections are kept until a new revision of the syllabus is published.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Correct syllabus details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrected syllabus details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSyllabusDetailsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/download": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Downloads are rate limited per user, a 429 response has a Retry-After header in seconds.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Download a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Short-lived presigned URL of the syllabus file"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Pages are only rendered for PDFs, other syllabi have no pages and should show the icon.",
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Get a syllabus preview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/reaction": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "React to a syllabus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction action",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusReactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Remove syllabus reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/reactions": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "List syllabus reactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusReactionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/removal-requests": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Only the verified instructor of a published syllabus may request its removal, requests are reviewed by an admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructor"
+                ],
+                "summary": "Request a syllabus removal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason of at most 1000 characters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RemovalRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/RemovalRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the verified instructor of the syllabus",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Syllabus is not published, is the user's own upload or already has a pending request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "List syllabus revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SyllabusRevisionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The revision replaces the syllabus' current file once the upload has been verified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Create a syllabus revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Revision file data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSyllabusRevisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusRevisionResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the created revision"
+                            },
+                            "X-Presigned-Url": {
+                                "type": "string",
+                                "description": "Presigned URL to upload the revision file"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Syllabus is not published, already has a pending revision or the file is a duplicate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/syllabi/{syllabusId}/revisions/{revision}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Syllabus"
+                ],
+                "summary": "Get a syllabus revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Syllabus ID",
+                        "name": "syllabusId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SyllabusRevisionResponse"
+                        },
+                        "headers": {
+                            "X-Presigned-Url": {
+                                "type": "string",
+                                "description": "Presigned URL to access the revision file, only set for published revisions"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Term"
+                ],
+                "summary": "List terms",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by semester",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by institution",
+                        "name": "institution",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TermResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{termId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The current term is the shortest term in progress, or the next term to start between terms.",
+                "tags": [
+                    "Term"
+                ],
+                "summary": "Get a term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Term ID, or current for the current term",
+                        "name": "termId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TermResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Follows the tus 1.0 creation extension. Upload-Metadata must contain the base64 encoded syllabusId and may contain a revision, defaulting to the first upload.",
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Create a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tus protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "File size in bytes, matching the size submitted with the syllabus",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tus metadata with syllabusId and optionally revision",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created upload"
+                            },
+                            "Upload-Expires": {
+                                "type": "string",
+                                "description": "Time after which the upload can no longer be resumed"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "File was already uploaded or is being uploaded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Get resumable upload options",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Tus-Extension": {
+                                "type": "string",
+                                "description": "Supported tus protocol extensions"
+                            },
+                            "Tus-Max-Size": {
+                                "type": "integer",
+                                "description": "Maximum upload size in bytes"
+                            },
+                            "Tus-Version": {
+                                "type": "string",
+                                "description": "Supported tus protocol versions"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{uploadId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Terminate a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tus protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload already completed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Get a resumable upload offset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tus protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Upload-Expires": {
+                                "type": "string",
+                                "description": "Time after which the upload can no longer be resumed"
+                            },
+                            "Upload-Length": {
+                                "type": "integer",
+                                "description": "Total bytes"
+                            },
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Upload expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Chunks are written to storage in parts, bytes received before a dropped connection are kept and reflected in the offset.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload a chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tus protocol version (1.0.0)",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset the chunk starts at, matching the current offset",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload-Offset does not match the current offset",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Upload expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/exists": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Check existing nickname",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search user nickname",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/NicknameExistsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL to access the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Confirm an avatar upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserAvatarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Upload an avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Avatar file data (jpeg, png or webp up to 5 MB)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UploadAvatarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "X-Presigned-Url": {
+                                "type": "string",
+                                "description": "Presigned URL to upload the avatar file"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete an avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/calendar": {
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "The feed merges the dated assessments of the user's courses for the current term.",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create a calendar subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CalendarSubscriptionResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete a calendar subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/calendar.ics": {
+            "get": {
+                "description": "Merges the dated assessments of the user's courses for the current term.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get a user's calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calendar subscription token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/courses": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List user courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or course code",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category ID",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size (default: 25)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UserCourseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Add a user course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User course data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateUserCourseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/courses/{courseId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete a user course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update a user course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated course data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateUserCourseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/links": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List user links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UserLinkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Add a user link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link data (type: GitHub, LinkedIn, Website, Instagram)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/UserLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/links/{linkId}": {
+            "put": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update a user link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link ID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link data (type: GitHub, LinkedIn, Website, Instagram)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete a user link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link ID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "Session": []
+                    }
+                ],
+                "description": "Courses taken in a term which has not ended are in progress, courses without a term are reported as unknown until their term is set.\nA course counts towards at most one Required or Elective requirement, Credits requirements count every matching course.",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get degree progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProgramProgressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The user's courses are not visible",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The user has not set a program",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AddCollectionSyllabusRequest": {
+            "type": "object",
+            "properties": {
+                "syllabusId": {
+                    "type": "string"
+                }
+            }
+        },
+        "ApprovalReviewRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "Assessment": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Percentage of the final grade",
+                    "type": "number"
+                }
+            }
+        },
+        "CalendarSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "description": "Secret feed URL to subscribe to from a calendar app",
+                    "type": "string"
+                }
+            }
+        },
+        "CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateModified": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "description": "The default collection holds bookmarks",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shareUrl": {
+                    "description": "Read only link, null unless shared",
+                    "type": "string"
+                },
+                "syllabi": {
+                    "description": "Only included when getting a single collection",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SyllabusResponse"
+                    }
+                },
+                "syllabusCount": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "Private or Shared",
+                    "type": "string"
+                }
+            }
+        },
+        "CommentResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Null for placeholders",
+                    "type": "string"
+                },
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateEdited": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string"
+                },
+                "replyCount": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Visible, Deleted or Removed",
+                    "type": "string"
+                },
+                "syllabusId": {
+                    "type": "string"
+                },
+                "threadId": {
+                    "description": "Top level comment of the thread, null for top level comments",
+                    "type": "string"
+                },
+                "userId": {
+                    "description": "Null for placeholders and comments of deleted users",
+                    "type": "string"
+                }
+            }
+        },
+        "CourseCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "CourseEligibilityResponse": {
+            "type": "object",
+            "properties": {
+                "antirequisites": {
+                    "description": "Antirequisites the user has completed or is taking",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RequisiteCourseResponse"
+                    }
+                },
+                "corequisitesMet": {
+                    "description": "Corequisites may be in progress or taken alongside the course and do not affect eligibility",
+                    "type": "boolean"
+                },
+                "eligible": {
+                    "description": "Prerequisites are met and the user has no antirequisite",
+                    "type": "boolean"
+                },
+                "prerequisitesMet": {
+                    "description": "Prerequisites must be completed",
+                    "type": "boolean"
+                },
+                "prerequisitesUnknown": {
+                    "description": "Prerequisites are only met counting courses without a term, whose completion is unknown",
+                    "type": "boolean"
+                },
+                "requisites": {
+                    "$ref": "#/definitions/CourseRequisitesResponse"
+                },
+                "taken": {
+                    "description": "The user already has the course",
+                    "type": "boolean"
+                }
+            }
+        },
+        "CourseRatingsResponse": {
+            "type": "object",
+            "properties": {
+                "difficulty": {
+                    "description": "Averages from 1 to 5, null without reviews",
+                    "type": "number"
+                },
+                "reviewCount": {
+                    "type": "integer"
+                },
+                "usefulness": {
+                    "type": "number"
+                },
+                "workload": {
+                    "type": "number"
+                }
+            }
+        },
+        "CourseRequisitesResponse": {
+            "type": "object",
+            "properties": {
+                "antirequisites": {
+                    "description": "Taking any of the courses excludes the course",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RequisiteGroupResponse"
+                        }
+                    ]
+                },
+                "corequisites": {
+                    "$ref": "#/definitions/RequisiteGroupResponse"
+                },
+                "prerequisites": {
+                    "$ref": "#/definitions/RequisiteGroupResponse"
+                }
+            }
+        },
+        "CourseResponse": {
+            "type": "object",
+            "properties": {
+                "antirequisites": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "corequisites": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "course": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "prerequisites": {
+                    "description": "Requisites as published in the academic calendar, see GET /courses/{courseId}/requisites for linked courses",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "ratings": {
+                    "$ref": "#/definitions/CourseRatingsResponse"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "CourseReviewRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "usefulness": {
+                    "type": "integer"
+                },
+                "workload": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CourseReviewResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateModified": {
+                    "type": "integer"
+                },
+                "difficulty": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isAuthor": {
+                    "description": "Reviews are anonymous, authors can only identify their own",
+                    "type": "boolean"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "usefulness": {
+                    "type": "integer"
+                },
+                "workload": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateCollectionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "Private (default) or Shared",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "description": "Comment being replied to, omit for a top level comment",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateSyllabusBatchRequest": {
+            "type": "object",
+            "properties": {
+                "syllabi": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CreateSyllabusRequest"
+                    }
+                }
+            }
+        },
+        "CreateSyllabusRequest": {
+            "type": "object",
+            "required": [
+                "checksum",
+                "contentType",
+                "courseId",
+                "fileName",
+                "fileSize"
+            ],
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                },
+                "instructorId": {
+                    "description": "Optional, see GET /instructors for existing instructors",
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "termId": {
+                    "description": "Takes precedence over year and semester, see GET /terms for existing terms",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "Year and semester are required unless a term is given, they must match an existing term",
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateSyllabusRevisionRequest": {
+            "type": "object",
+            "required": [
+                "checksum",
+                "contentType",
+                "fileName",
+                "fileSize"
+            ],
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateTermRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "dateEnd",
+                "dateStart",
+                "name",
+                "semester",
+                "year"
+            ],
+            "properties": {
+                "code": {
+                    "description": "e.g. 2024-fall",
+                    "type": "string"
+                },
+                "dateEnd": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "dateStart": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "institution": {
+                    "description": "Defaults to Toronto Metropolitan University",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "e.g. Fall 2024",
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateUserCourseRequest": {
+            "type": "object",
+            "required": [
+                "courseId"
+            ],
+            "properties": {
+                "courseId": {
+                    "type": "string"
+                },
+                "semesterTaken": {
+                    "description": "Without a term ID, the term is the latest of the semester starting before the course is added",
+                    "type": "string"
+                },
+                "termId": {
+                    "description": "Term ID or \"current\", sets the semester taken",
+                    "type": "string"
+                },
+                "yearTaken": {
+                    "description": "Year of study",
+                    "type": "integer"
+                }
+            }
+        },
+        "FacultyResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "GradeWeight": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Percentage of the final grade",
+                    "type": "number"
+                }
+            }
+        },
+        "InstructorClaimRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "InstructorClaimResponse": {
+            "type": "object",
+            "properties": {
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateReviewed": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructor": {
+                    "type": "string"
+                },
+                "instructorId": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reviewReason": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Pending, Approved or Rejected",
+                    "type": "string"
+                },
+                "userEmail": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "InstructorContact": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "office": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "InstructorCourseResponse": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "syllabusCount": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "InstructorDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "description": "Courses the instructor has published syllabi for",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/InstructorCourseResponse"
+                    }
+                },
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "syllabusCount": {
+                    "description": "Published syllabi of the instructor",
+                    "type": "integer"
+                },
+                "verified": {
+                    "description": "Claimed by a verified faculty account",
+                    "type": "boolean"
+                }
+            }
+        },
+        "InstructorRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "InstructorResponse": {
+            "type": "object",
+            "properties": {
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "syllabusCount": {
+                    "description": "Published syllabi of the instructor",
+                    "type": "integer"
+                },
+                "verified": {
+                    "description": "Claimed by a verified faculty account",
+                    "type": "boolean"
+                }
+            }
+        },
+        "NicknameExistsResponse": {
+            "type": "object",
+            "properties": {
+                "exists": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "ProgramProgressResponse": {
+            "type": "object",
+            "properties": {
+                "creditsCompleted": {
+                    "type": "number"
+                },
+                "creditsInProgress": {
+                    "type": "number"
+                },
+                "creditsUnknown": {
+                    "type": "number"
+                },
+                "programId": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RequirementProgressResponse"
+                    }
+                },
+                "status": {
+                    "description": "Completed once every requirement is completed, NoRequirements if the program has none",
+                    "type": "string"
+                }
+            }
+        },
+        "ProgramRequirementRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "categoryIds": {
+                    "description": "Only counted by Elective and Credits requirements",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "courseIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "credits": {
+                    "description": "Required for Elective and Credits requirements, omitted for Required requirements",
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Required, Elective or Credits",
+                    "type": "string"
+                }
+            }
+        },
+        "ProgramRequirementResponse": {
+            "type": "object",
+            "properties": {
+                "categoryIds": {
+                    "description": "Elective and Credits requirements count courses of the categories",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RequirementCourseResponse"
+                    }
+                },
+                "credits": {
+                    "description": "Credits needed, null for Required requirements which need every course",
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Required, Elective or Credits",
+                    "type": "string"
+                }
+            }
+        },
+        "ProgramResponse": {
+            "type": "object",
+            "properties": {
+                "faculty": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "ProgressCourseResponse": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "PublicProfileResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserCourseResponse"
+                    }
+                },
+                "totalLikes": {
+                    "type": "integer"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SyllabusResponse"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/UserResponse"
+                }
+            }
+        },
+        "QueueSyllabusExtractionsResponse": {
+            "type": "object",
+            "properties": {
+                "queued": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RemovalRequestRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "RemovalRequestResponse": {
+            "type": "object",
+            "properties": {
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateReviewed": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewReason": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Pending, Approved or Rejected",
+                    "type": "string"
+                },
+                "syllabusId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "description": "Null once the requesting instructor's account is deleted",
+                    "type": "string"
+                }
+            }
+        },
+        "RemoveCommentRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "ReorderCollectionRequest": {
+            "type": "object",
+            "properties": {
+                "syllabusIds": {
+                    "description": "Every syllabus listed in the collection, in the new order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "RequirementCourseResponse": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "RequirementProgressResponse": {
             "type": "object",
             "properties": {
-                "categoryId": {
+                "completed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProgressCourseResponse"
+                    }
+                },
+                "creditsCompleted": {
+                    "type": "number"
+                },
+                "creditsInProgress": {
+                    "type": "number"
+                },
+                "creditsUnknown": {
+                    "type": "number"
+                },
+                "inProgress": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProgressCourseResponse"
+                    }
+                },
+                "missing": {
+                    "description": "Courses of Required requirements which were not taken",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RequirementCourseResponse"
+                    }
+                },
+                "requirement": {
+                    "$ref": "#/definitions/ProgramRequirementResponse"
+                },
+                "status": {
+                    "description": "Completed, InProgress, Unknown if only met counting courses without a term, or Missing",
                     "type": "string"
                 },
+                "unknown": {
+                    "description": "Courses without a term, which may or may not be completed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProgressCourseResponse"
+                    }
+                }
+            }
+        },
+        "RequisiteCourseResponse": {
+            "type": "object",
+            "properties": {
                 "course": {
                     "type": "string"
                 },
-                "currentYear": {
-                    "type": "string",
-                    "x-nullable": true
-                },
-                "id": {
+                "courseId": {
                     "type": "string"
                 },
+                "taken": {
+                    "description": "Whether the user completed prerequisites, or has the course at all otherwise, only set on eligibility checks",
+                    "type": "boolean"
+                },
                 "title": {
                     "type": "string"
                 },
-                "uri": {
+                "unknown": {
+                    "description": "Whether the user has a prerequisite without a term, so its completion is unknown, only set on eligibility checks",
+                    "type": "boolean"
+                }
+            }
+        },
+        "SessionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "CreateSyllabusRequest": {
+        "SetCourseRequisitesRequest": {
             "type": "object",
             "required": [
-                "checksum",
-                "contentType",
-                "courseId",
-                "fileName",
-                "fileSize",
-                "semester",
-                "year"
+                "type"
             ],
             "properties": {
-                "checksum": {
+                "requisites": {
+                    "description": "Null removes the course's requisites of the type",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RequisiteGroupRequest"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "Prerequisite, Corequisite or Antirequisite",
+                    "type": "string"
+                }
+            }
+        },
+        "SetProgramRequirementsRequest": {
+            "type": "object",
+            "properties": {
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ProgramRequirementRequest"
+                    }
+                }
+            }
+        },
+        "SuspendUserRequest": {
+            "type": "object",
+            "properties": {
+                "durationHours": {
+                    "description": "Omit for a permanent suspension",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "SyllabusBatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "location": {
+                    "description": "URL of the created syllabus, or of the syllabus it duplicates",
+                    "type": "string"
+                },
+                "presignedUrl": {
+                    "description": "URL to upload the syllabus file",
+                    "type": "string"
+                },
+                "syllabusId": {
+                    "description": "Null when the syllabus was not created",
+                    "type": "string"
+                }
+            }
+        },
+        "SyllabusBatchResponse": {
+            "type": "object",
+            "properties": {
+                "batchId": {
+                    "description": "Null when no syllabi were created",
+                    "type": "string"
+                },
+                "syllabi": {
+                    "description": "Results in the order of the request",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SyllabusBatchItemResponse"
+                    }
+                }
+            }
+        },
+        "SyllabusDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "assessments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Assessment"
+                    }
+                },
+                "confidence": {
+                    "description": "From 0 to 1, how complete the parsed details are likely to be",
+                    "type": "number"
+                },
+                "dateModified": {
+                    "type": "integer"
+                },
+                "edited": {
+                    "description": "Whether the uploader corrected the details",
+                    "type": "boolean"
+                },
+                "gradingScheme": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradeWeight"
+                    }
+                },
+                "instructor": {
+                    "$ref": "#/definitions/InstructorContact"
+                },
+                "revision": {
+                    "description": "Revision the details were read from",
+                    "type": "integer"
+                },
+                "syllabusId": {
+                    "type": "string"
+                },
+                "textbooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Textbook"
+                    }
+                }
+            }
+        },
+        "SyllabusPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "iconUrl": {
+                    "type": "string"
+                },
+                "pages": {
+                    "description": "Presigned URLs of the first pages' images, in page order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnailUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "SyllabusReactionRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                }
+            }
+        },
+        "SyllabusReactionResponse": {
+            "type": "object",
+            "properties": {
+                "dateReacted": {
+                    "type": "integer"
+                },
+                "dislike": {
+                    "type": "boolean"
+                },
+                "syllabusId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "SyllabusRequestRequest": {
+            "type": "object",
+            "properties": {
+                "semester": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SyllabusRequestResponse": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateFulfilled": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "syllabusId": {
+                    "type": "string"
+                },
+                "voted": {
+                    "type": "boolean"
+                },
+                "votes": {
+                    "type": "integer"
+                },
+                "year": {
+                    "description": "Null for requests of any term",
+                    "type": "integer"
+                }
+            }
+        },
+        "SyllabusResponse": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "courseId": {
+                    "type": "string"
+                },
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateStatusChanged": {
+                    "type": "integer"
+                },
+                "dislikes": {
+                    "type": "integer"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                },
+                "iconUrl": {
+                    "description": "Generic icon for the content type, shown without a thumbnail",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructorId": {
+                    "type": "string"
+                },
+                "likes": {
+                    "type": "integer"
+                },
+                "official": {
+                    "description": "Uploaded by the syllabus' verified instructor",
+                    "type": "boolean"
+                },
+                "reaction": {
+                    "description": "The caller's reaction, Like or Dislike",
+                    "type": "string"
+                },
+                "received": {
+                    "type": "boolean"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Uploading, Verifying, Published, Rejected, Expired or Removed",
+                    "type": "string"
+                },
+                "statusReason": {
+                    "type": "string"
+                },
+                "termId": {
+                    "type": "string"
+                },
+                "thumbnailUrl": {
+                    "description": "Presigned URL of the first page's image, only PDFs have thumbnails",
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 },
+                "views": {
+                    "description": "Unique users who viewed the syllabus",
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SyllabusRevisionResponse": {
+            "type": "object",
+            "properties": {
                 "contentType": {
                     "type": "string"
                 },
-                "courseId": {
-                    "type": "string"
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateStatusChanged": {
+                    "type": "integer"
                 },
                 "fileName": {
                     "type": "string"
@@ -1318,148 +7104,177 @@ const docTemplate = `{
                 "fileSize": {
                     "type": "integer"
                 },
-                "semester": {
+                "revision": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Uploading, Verifying, Published, Rejected or Expired",
                     "type": "string"
                 },
-                "year": {
-                    "type": "integer"
+                "statusReason": {
+                    "type": "string"
+                },
+                "syllabusId": {
+                    "type": "string"
                 }
             }
         },
-        "CreateUserCourseRequest": {
+        "SyllabusSearchResponse": {
             "type": "object",
-            "required": [
-                "courseId"
-            ],
             "properties": {
-                "courseId": {
-                    "type": "string"
-                },
-                "semesterTaken": {
+                "snippet": {
+                    "description": "HTML escaped matching excerpts, matches are wrapped in \u003cmark\u003e tags",
                     "type": "string"
                 },
-                "yearTaken": {
-                    "type": "integer"
+                "syllabus": {
+                    "$ref": "#/definitions/SyllabusResponse"
                 }
             }
         },
-        "FacultyResponse": {
+        "TermResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "description": "e.g. 2024-fall",
+                    "type": "string"
+                },
+                "dateEnd": {
+                    "type": "integer"
+                },
+                "dateStart": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
+                "institution": {
+                    "type": "string"
+                },
                 "name": {
+                    "description": "e.g. Fall 2024",
+                    "type": "string"
+                },
+                "semester": {
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "NicknameExistsResponse": {
+        "Textbook": {
             "type": "object",
             "properties": {
-                "exists": {
+                "isbn": {
+                    "type": "string"
+                },
+                "required": {
                     "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "ProgramResponse": {
+        "UnlockedCourseResponse": {
             "type": "object",
             "properties": {
-                "faculty": {
+                "course": {
                     "type": "string"
                 },
-                "id": {
+                "courseId": {
                     "type": "string"
                 },
-                "name": {
+                "title": {
                     "type": "string"
                 },
-                "uri": {
+                "type": {
+                    "description": "Prerequisite or Corequisite",
                     "type": "string"
                 }
             }
         },
-        "SessionResponse": {
+        "UpdateCollectionRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "x-nullable": true
                 },
-                "userId": {
+                "name": {
                     "type": "string"
+                },
+                "visibility": {
+                    "description": "Visibility is Private or Shared, a null value resets to Private.",
+                    "type": "string",
+                    "x-nullable": true
                 }
             }
         },
-        "SyllabusReactionRequest": {
+        "UpdateCommentRequest": {
             "type": "object",
             "properties": {
-                "action": {
+                "content": {
                     "type": "string"
                 }
             }
         },
-        "SyllabusReactionResponse": {
+        "UpdateSyllabusDetailsRequest": {
             "type": "object",
             "properties": {
-                "dateReacted": {
-                    "type": "integer"
+                "assessments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Assessment"
+                    }
                 },
-                "dislike": {
-                    "type": "boolean"
+                "gradingScheme": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradeWeight"
+                    }
                 },
-                "syllabusId": {
-                    "type": "string"
+                "instructor": {
+                    "$ref": "#/definitions/InstructorContact"
                 },
-                "userId": {
-                    "type": "string"
+                "textbooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Textbook"
+                    }
                 }
             }
         },
-        "SyllabusResponse": {
+        "UpdateSyllabusRequest": {
             "type": "object",
             "properties": {
-                "contentType": {
-                    "type": "string"
-                },
-                "courseId": {
-                    "type": "string"
-                },
-                "dateAdded": {
-                    "type": "integer"
-                },
-                "fileName": {
-                    "type": "string"
-                },
-                "fileSize": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "received": {
-                    "type": "boolean"
+                "instructorId": {
+                    "description": "Null removes the syllabus' instructor",
+                    "type": "string",
+                    "x-nullable": true
                 },
                 "semester": {
-                    "type": "string"
+                    "type": "string",
+                    "x-nullable": true
                 },
-                "userId": {
+                "termId": {
+                    "description": "Sets the year and semester from the term",
                     "type": "string"
                 },
                 "year": {
-                    "type": "integer"
+                    "type": "integer",
+                    "x-nullable": true
                 }
             }
         },
-        "UpdateSyllabusRequest": {
+        "UpdateSyllabusStatusRequest": {
             "type": "object",
             "properties": {
-                "semester": {
-                    "type": "string",
-                    "x-nullable": true
+                "reason": {
+                    "type": "string"
                 },
-                "year": {
-                    "type": "integer",
-                    "x-nullable": true
+                "status": {
+                    "type": "string"
                 }
             }
         },
@@ -1467,6 +7282,11 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "semesterTaken": {
+                    "description": "Without a term ID, the term is derived from the semester or cleared with it",
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "termId": {
                     "type": "string",
                     "x-nullable": true
                 },
@@ -1483,15 +7303,32 @@ const docTemplate = `{
                     "type": "string",
                     "x-nullable": true
                 },
+                "coursesVisibility": {
+                    "type": "string",
+                    "x-nullable": true
+                },
                 "currentYear": {
                     "type": "integer",
                     "x-nullable": true
                 },
+                "currentYearVisibility": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "emailVisibility": {
+                    "description": "Visibility values are Public, Program or Private. A null value resets to the default.",
+                    "type": "string",
+                    "x-nullable": true
+                },
                 "gender": {
                     "type": "string",
                     "x-nullable": true
                 },
-                "instagram": {
+                "genderVisibility": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "linksVisibility": {
                     "type": "string",
                     "x-nullable": true
                 },
@@ -1505,6 +7342,39 @@ const docTemplate = `{
                 }
             }
         },
+        "UploadAvatarRequest": {
+            "type": "object",
+            "required": [
+                "checksum",
+                "contentType",
+                "fileSize"
+            ],
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "UserAvatarResponse": {
+            "type": "object",
+            "properties": {
+                "large": {
+                    "type": "string"
+                },
+                "medium": {
+                    "type": "string"
+                },
+                "small": {
+                    "type": "string"
+                }
+            }
+        },
         "UserCourseResponse": {
             "type": "object",
             "properties": {
@@ -1518,6 +7388,10 @@ const docTemplate = `{
                     "type": "integer",
                     "x-nullable": true
                 },
+                "termId": {
+                    "type": "string",
+                    "x-nullable": true
+                },
                 "title": {
                     "type": "string"
                 },
@@ -1527,9 +7401,41 @@ const docTemplate = `{
                 }
             }
         },
+        "UserLinkRequest": {
+            "type": "object",
+            "required": [
+                "link",
+                "type"
+            ],
+            "properties": {
+                "link": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "UserLinkResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "UserResponse": {
             "type": "object",
             "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/UserAvatarResponse"
+                },
                 "bio": {
                     "type": "string",
                     "x-nullable": true
@@ -1551,10 +7457,6 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "instagram": {
-                    "type": "string",
-                    "x-nullable": true
-                },
                 "nickname": {
                     "type": "string",
                     "x-nullable": true
@@ -1566,6 +7468,108 @@ const docTemplate = `{
                 "programId": {
                     "type": "string",
                     "x-nullable": true
+                },
+                "visibility": {
+                    "$ref": "#/definitions/UserVisibilityResponse"
+                }
+            }
+        },
+        "UserSuspensionResponse": {
+            "type": "object",
+            "properties": {
+                "dateAdded": {
+                    "type": "integer"
+                },
+                "dateExpires": {
+                    "description": "Null for permanent suspensions",
+                    "type": "integer"
+                },
+                "dateLifted": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "liftedBy": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "suspendedBy": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "UserVisibilityResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "string"
+                },
+                "currentYear": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "links": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RequisiteGroupReq": {
+            "type": "object",
+            "properties": {
+                "courseIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RequisiteGroupReq"
+                    }
+                },
+                "operator": {
+                    "description": "All (default), or Any for one of",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RequisiteGroupRes": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RequisiteCourseResponse"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RequisiteGroupRes"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "All, or Any for one of",
+                    "type": "string"
+                },
+                "satisfied": {
+                    "description": "Only set on eligibility checks",
+                    "type": "boolean"
                 }
             }
         }
@@ -10,6 +10,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.2
 	github.com/oapi-codegen/nullable v1.1.0
+	golang.org/x/image v0.25.0
 	golang.org/x/oauth2 v0.28.0
 	google.golang.org/api v0.226.0
 )
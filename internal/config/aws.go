@@ -5,14 +5,23 @@ var (
 	AWS_ACCESS_KEY        = "AWS_ACCESS_KEY_ID"
 	AWS_SECRET_ACCESS_KEY = "AWS_SECRET_ACCESS_KEY"
 
-	AWS_S3_ENDPOINT       = "AWS_S3_ENDPOINT"
-	AWS_S3_SYLLABI_BUCKET = "AWS_S3_SYLLABI_BUCKET"
+	AWS_S3_ENDPOINT         = "AWS_S3_ENDPOINT"
+	AWS_S3_SYLLABI_BUCKET   = "AWS_S3_SYLLABI_BUCKET"
+	AWS_S3_THUMBNAIL_BUCKET = "AWS_S3_THUMBNAIL_BUCKET"
+	AWS_S3_AVATAR_BUCKET    = "AWS_S3_AVATAR_BUCKET"
+	AWS_S3_AVATAR_CDN_URL   = "AWS_S3_AVATAR_CDN_URL"
 
 	AWS_SQS_ENDPOINT    = "AWS_SQS_ENDPOINT"
 	AWS_SQS_WEBHOOK_URL = "AWS_SQS_WEBHOOK_URL"
 
-	AWS_SES_ENDPOINT                = "AWS_SES_ENDPOINT"
-	AWS_SES_WELCOME_TEMPLATE        = "AWS_SES_TEMPLATE_WELCOME"
-	AWS_SES_UPLOAD_SUCCESS_TEMPLATE = "AWS_SES_TEMPLATE_UPLOAD_SUCCESS"
-	AWS_SES_UPLOAD_ERROR_TEMPLATE   = "AWS_SES_TEMPLATE_UPLOAD_ERROR"
+	AWS_SES_ENDPOINT                   = "AWS_SES_ENDPOINT"
+	AWS_SES_WELCOME_TEMPLATE           = "AWS_SES_TEMPLATE_WELCOME"
+	AWS_SES_UPLOAD_SUCCESS_TEMPLATE    = "AWS_SES_TEMPLATE_UPLOAD_SUCCESS"
+	AWS_SES_UPLOAD_ERROR_TEMPLATE      = "AWS_SES_TEMPLATE_UPLOAD_ERROR"
+	AWS_SES_SUSPENSION_TEMPLATE        = "AWS_SES_TEMPLATE_SUSPENSION"
+	AWS_SES_BATCH_SUMMARY_TEMPLATE     = "AWS_SES_TEMPLATE_BATCH_SUMMARY"
+	AWS_SES_COMMENT_REPLY_TEMPLATE     = "AWS_SES_TEMPLATE_COMMENT_REPLY"
+	AWS_SES_SYLLABUS_COMMENT_TEMPLATE  = "AWS_SES_TEMPLATE_SYLLABUS_COMMENT"
+	AWS_SES_REQUEST_FULFILLED_TEMPLATE = "AWS_SES_TEMPLATE_REQUEST_FULFILLED"
+	AWS_SES_SYLLABUS_REMOVED_TEMPLATE  = "AWS_SES_TEMPLATE_SYLLABUS_REMOVED"
 )
@@ -0,0 +1,844 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/emailer"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxSuspensionReasonLength = 500
+	// Suspensions longer than a year should be issued as permanent.
+	maxSuspensionHours = 24 * 365
+	// Calendar requisites rarely nest more than a couple of levels.
+	maxRequisiteDepth = 5
+)
+
+type adminHandler struct {
+	log             logger.Logger
+	userRepo        repository.UserRepository
+	suspensionRepo  repository.SuspensionRepository
+	syllabusRepo    repository.SyllabusRepository
+	commentRepo     repository.CommentRepository
+	claimRepo       repository.ClaimRepository
+	removalRepo     repository.RemovalRepository
+	termRepo        repository.TermRepository
+	requisiteRepo   repository.RequisiteRepository
+	requirementRepo repository.RequirementRepository
+	emailer         emailer.NoReplyEmailer
+}
+
+func NewAdminHandler(log logger.Logger, user repository.UserRepository, suspension repository.SuspensionRepository, syllabus repository.SyllabusRepository, comment repository.CommentRepository, claim repository.ClaimRepository, removal repository.RemovalRepository, term repository.TermRepository, requisite repository.RequisiteRepository, requirement repository.RequirementRepository, emailer emailer.NoReplyEmailer) *adminHandler {
+	return &adminHandler{
+		log:             log,
+		userRepo:        user,
+		suspensionRepo:  suspension,
+		syllabusRepo:    syllabus,
+		commentRepo:     comment,
+		claimRepo:       claim,
+		removalRepo:     removal,
+		termRepo:        term,
+		requisiteRepo:   requisite,
+		requirementRepo: requirement,
+		emailer:         emailer,
+	}
+}
+
+type UserSuspensionRes struct {
+	Id          string  `json:"id"`
+	UserId      string  `json:"userId"`
+	SuspendedBy *string `json:"suspendedBy"`
+	Reason      string  `json:"reason"`
+	DateExpires *int64  `json:"dateExpires"` // Null for permanent suspensions
+	LiftedBy    *string `json:"liftedBy"`
+	DateLifted  *int64  `json:"dateLifted"`
+	DateAdded   int64   `json:"dateAdded"`
+} //@name UserSuspensionResponse
+
+// ListUserSuspensions retrieves a user's suspension history, most recent first.
+// @Summary List user suspensions
+// @Tags Admin
+// @Param userId path string true "User ID"
+// @Success 200 {array} UserSuspensionResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/users/{userId}/suspensions [get]
+func (a *adminHandler) ListUserSuspensions(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "userId")
+
+	suspensions, err := a.suspensionRepo.ListUserSuspensions(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]UserSuspensionRes, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		item := UserSuspensionRes{
+			Id:        suspension.Id,
+			UserId:    suspension.UserId,
+			Reason:    suspension.Reason,
+			DateAdded: suspension.DateAdded.UnixMicro(),
+		}
+		if suspension.SuspendedBy.Valid {
+			item.SuspendedBy = &suspension.SuspendedBy.String
+		}
+		if suspension.DateExpires.Valid {
+			dateExpires := suspension.DateExpires.Time.UnixMicro()
+			item.DateExpires = &dateExpires
+		}
+		if suspension.LiftedBy.Valid {
+			item.LiftedBy = &suspension.LiftedBy.String
+		}
+		if suspension.DateLifted.Valid {
+			dateLifted := suspension.DateLifted.Time.UnixMicro()
+			item.DateLifted = &dateLifted
+		}
+
+		res = append(res, item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+type SuspendUserReq struct {
+	Reason        string `json:"reason"`
+	DurationHours *int   `json:"durationHours"` // Omit for a permanent suspension
+} //@name SuspendUserRequest
+
+// SuspendUser suspends a user temporarily or permanently and notifies them by email.
+// @Summary Suspend a user
+// @Description Suspended users are denied on every authenticated request and cannot start new sessions.
+// @Tags Admin
+// @Accept json
+// @Param userId path string true "User ID"
+// @Param body body SuspendUserRequest true "Suspension reason and optional duration in hours, omit the duration for a permanent suspension"
+// @Success 201 {string} string "Created"
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "User is already suspended"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/users/{userId}/suspensions [post]
+func (a *adminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		a.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId == session.UserId {
+		http.Error(w, "You're not allowed to suspend yourself.", http.StatusForbidden)
+		return
+	}
+
+	var body SuspendUserReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body.Reason = strings.TrimSpace(body.Reason)
+	if body.Reason == "" || len(body.Reason) > maxSuspensionReasonLength {
+		http.Error(w, "A suspension reason of at most 500 characters is required.", http.StatusBadRequest)
+		return
+	}
+	if body.DurationHours != nil && (*body.DurationHours <= 0 || *body.DurationHours > maxSuspensionHours) {
+		http.Error(w, "Suspension duration must be between 1 hour and 1 year.", http.StatusBadRequest)
+		return
+	}
+
+	user, err := a.userRepo.GetUser(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	access, err := a.suspensionRepo.GetUserAccess(r.Context(), userId)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+	if access.Role == repository.RoleAdmin {
+		http.Error(w, "Admins cannot be suspended.", http.StatusForbidden)
+		return
+	}
+
+	_, err = a.suspensionRepo.SuspendUser(r.Context(), userId, session.UserId, repository.InsertUserSuspension{
+		Reason:        body.Reason,
+		DurationHours: body.DurationHours,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "User is already suspended.", http.StatusConflict)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Re-read the suspension for the expiry computed by the database
+	access, err = a.suspensionRepo.GetUserAccess(r.Context(), userId)
+	if err == nil && access.Suspension != nil {
+		var expires *time.Time
+		if access.Suspension.DateExpires.Valid {
+			expires = &access.Suspension.DateExpires.Time
+		}
+
+		// No need to do anything if email fails to send (logs will catch)
+		a.emailer.SendSuspensionEmail(r.Context(), user.Email, user.FullName, body.Reason, expires)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// LiftUserSuspension lifts a user's active suspension, restoring access immediately.
+// @Summary Lift a user suspension
+// @Tags Admin
+// @Param userId path string true "User ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string "User is not suspended"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/users/{userId}/suspensions [delete]
+func (a *adminHandler) LiftUserSuspension(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		a.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	err := a.suspensionRepo.LiftUserSuspension(r.Context(), userId, session.UserId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User is not suspended.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type UpdateSyllabusStatusReq struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+} //@name UpdateSyllabusStatusRequest
+
+// UpdateSyllabusStatus moderates a syllabus by rejecting, removing or restoring it.
+// @Summary Moderate a syllabus
+// @Description Verifying syllabi may be Rejected, Published syllabi may be Removed and Removed syllabi may be restored to Published. The uploader is emailed of rejections and removals.
+// @Tags Admin
+// @Accept json
+// @Param syllabusId path string true "Syllabus ID"
+// @Param body body UpdateSyllabusStatusRequest true "New status (Published, Rejected, Removed), a reason is required for rejections and removals"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Transition not allowed from the current status"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/syllabi/{syllabusId}/status [put]
+func (a *adminHandler) UpdateSyllabusStatus(w http.ResponseWriter, r *http.Request) {
+	var body UpdateSyllabusStatusReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body.Reason = strings.TrimSpace(body.Reason)
+	switch body.Status {
+	case repository.SyllabusRejected, repository.SyllabusRemoved:
+		if body.Reason == "" {
+			http.Error(w, "A reason is required to reject or remove a syllabus.", http.StatusBadRequest)
+			return
+		}
+	case repository.SyllabusPublished:
+	default:
+		http.Error(w, "Status must be one of Published, Rejected or Removed.", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	meta, err := a.syllabusRepo.TransitionSyllabus(r.Context(), syllabusId, body.Status, body.Reason)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed syllabus ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Syllabus cannot be moved to this status from "+meta.Status+".", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	switch body.Status {
+	case repository.SyllabusRejected:
+		a.emailer.SendSubmissionRejectedEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course, body.Reason)
+	case repository.SyllabusRemoved:
+		a.emailer.SendSyllabusRemovedEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course, body.Reason)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type RemoveCommentReq struct {
+	Reason string `json:"reason"`
+} //@name RemoveCommentRequest
+
+// RemoveComment removes a comment by moderation, leaving a placeholder in its thread.
+// @Summary Remove a comment
+// @Tags Admin
+// @Accept json
+// @Param commentId path string true "Comment ID"
+// @Param body body RemoveCommentRequest true "Removal reason"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Comment is already removed"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/comments/{commentId}/removal [put]
+func (a *adminHandler) RemoveComment(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		a.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body RemoveCommentReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body.Reason = strings.TrimSpace(body.Reason)
+	if body.Reason == "" {
+		http.Error(w, "A reason is required to remove a comment.", http.StatusBadRequest)
+		return
+	}
+
+	commentId := chi.URLParam(r, "commentId")
+	if err := a.commentRepo.RemoveComment(r.Context(), commentId, session.UserId, body.Reason); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Comment not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed comment ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Comment is already removed.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ApprovalReviewReq struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+} //@name ApprovalReviewRequest
+
+// parseApprovalReview validates a review of a claim or removal request, a reason is required for rejections.
+func parseApprovalReview(body ApprovalReviewReq) (ApprovalReviewReq, string) {
+	body.Reason = strings.TrimSpace(body.Reason)
+	switch body.Status {
+	case repository.ApprovalApproved:
+	case repository.ApprovalRejected:
+		if body.Reason == "" {
+			return body, "A reason is required for rejections."
+		}
+	default:
+		return body, "Status must be one of Approved or Rejected."
+	}
+
+	return body, ""
+}
+
+// ListInstructorClaims lists instructor profile claims, oldest first.
+// @Summary List instructor claims
+// @Tags Admin
+// @Param status query string false "Claim status (default: Pending)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} InstructorClaimResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/instructor-claims [get]
+func (a *adminHandler) ListInstructorClaims(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status == "" {
+		status = repository.ApprovalPending
+	}
+
+	claims, err := a.claimRepo.ListInstructorClaims(r.Context(), status, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid status value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]InstructorClaimRes, 0, len(claims))
+	for _, claim := range claims {
+		res = append(res, newInstructorClaimRes(claim))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ReviewInstructorClaim approves or rejects a pending instructor profile claim.
+// @Summary Review an instructor claim
+// @Description Approving verifies the user as the instructor and rejects the instructor's other pending claims.
+// @Tags Admin
+// @Accept json
+// @Param claimId path string true "Claim ID"
+// @Param body body ApprovalReviewRequest true "Approved or Rejected, a reason is required for rejections"
+// @Success 200 {object} InstructorClaimResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Claim is already reviewed, or the instructor or user is already verified"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/instructor-claims/{claimId} [put]
+func (a *adminHandler) ReviewInstructorClaim(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		a.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body ApprovalReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body, message := parseApprovalReview(body)
+	if message != "" {
+		http.Error(w, message, http.StatusBadRequest)
+		return
+	}
+
+	claimId := chi.URLParam(r, "claimId")
+	claim, err := a.claimRepo.ReviewInstructorClaim(r.Context(), claimId, session.UserId, body.Status, body.Reason)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Claim not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed claim ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Claim is already reviewed, or the instructor or user is already verified.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newInstructorClaimRes(claim))
+}
+
+// ListRemovalRequests lists syllabus removal requests from verified instructors, oldest first.
+// @Summary List removal requests
+// @Tags Admin
+// @Param status query string false "Request status (default: Pending)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} RemovalRequestResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/removal-requests [get]
+func (a *adminHandler) ListRemovalRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := query.Get("status")
+	if status == "" {
+		status = repository.ApprovalPending
+	}
+
+	requests, err := a.removalRepo.ListRemovalRequests(r.Context(), status, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid status value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]RemovalRequestRes, 0, len(requests))
+	for _, request := range requests {
+		res = append(res, newRemovalRequestRes(request))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ReviewRemovalRequest approves or rejects a pending syllabus removal request.
+// @Summary Review a removal request
+// @Description Approving removes the syllabus if it is still published and emails its uploader.
+// @Tags Admin
+// @Accept json
+// @Param requestId path string true "Removal request ID"
+// @Param body body ApprovalReviewRequest true "Approved or Rejected, a reason is required for rejections"
+// @Success 200 {object} RemovalRequestResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Request is already reviewed"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/removal-requests/{requestId} [put]
+func (a *adminHandler) ReviewRemovalRequest(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		a.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body ApprovalReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body, message := parseApprovalReview(body)
+	if message != "" {
+		http.Error(w, message, http.StatusBadRequest)
+		return
+	}
+
+	requestId := chi.URLParam(r, "requestId")
+	request, err := a.removalRepo.ReviewRemovalRequest(r.Context(), requestId, session.UserId, body.Status, body.Reason)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Removal request not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed removal request ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Removal request is already reviewed.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if body.Status == repository.ApprovalApproved {
+		// Syllabi removed or deleted since the request need no further action
+		meta, err := a.syllabusRepo.TransitionSyllabus(r.Context(), request.SyllabusId, repository.SyllabusRemoved, repository.RemovalRequestReason)
+		if err == nil {
+			a.emailer.SendSyllabusRemovedEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course, repository.RemovalRequestReason)
+		} else if !errors.Is(err, util.ErrConflict) && !errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newRemovalRequestRes(request))
+}
+
+type CreateTermReq struct {
+	Code        string `json:"code" validate:"required"` // e.g. 2024-fall
+	Name        string `json:"name" validate:"required"` // e.g. Fall 2024
+	Year        int16  `json:"year" validate:"required"`
+	Semester    string `json:"semester" validate:"required"`
+	Institution string `json:"institution"`                   // Defaults to Toronto Metropolitan University
+	DateStart   string `json:"dateStart" validate:"required"` // YYYY-MM-DD
+	DateEnd     string `json:"dateEnd" validate:"required"`   // YYYY-MM-DD
+} //@name CreateTermRequest
+
+// CreateTerm adds an academic term.
+// @Summary Create a term
+// @Description Syllabi can only reference existing terms, whether by id or by year and semester.
+// @Tags Admin
+// @Accept json
+// @Param body body CreateTermRequest true "Term data"
+// @Success 201 {object} TermResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 409 {string} string "The institution already has the term"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/terms [post]
+func (a *adminHandler) CreateTerm(w http.ResponseWriter, r *http.Request) {
+	var body CreateTermReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body.Code = strings.TrimSpace(body.Code)
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Code == "" || body.Name == "" || body.Year <= 0 || body.Semester == "" {
+		http.Error(w, "Invalid or missing request body fields.", http.StatusBadRequest)
+		return
+	}
+
+	dateStart, err := time.Parse(time.DateOnly, body.DateStart)
+	if err != nil {
+		http.Error(w, "Invalid start date.", http.StatusBadRequest)
+		return
+	}
+	dateEnd, err := time.Parse(time.DateOnly, body.DateEnd)
+	if err != nil || dateEnd.Before(dateStart) {
+		http.Error(w, "Invalid end date.", http.StatusBadRequest)
+		return
+	}
+
+	term, err := a.termRepo.CreateTerm(r.Context(), repository.InsertTerm{
+		Code:        body.Code,
+		Name:        body.Name,
+		Year:        body.Year,
+		Semester:    body.Semester,
+		Institution: strings.TrimSpace(body.Institution),
+		DateStart:   dateStart,
+		DateEnd:     dateEnd,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "The institution already has a term with this code or semester.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newTermRes(term))
+}
+
+type RequisiteGroupReq struct {
+	Operator  string              `json:"operator"` // All (default), or Any for one of
+	CourseIds []string            `json:"courseIds"`
+	Groups    []RequisiteGroupReq `json:"groups"`
+} //@name RequisiteGroupRequest
+
+type SetCourseRequisitesReq struct {
+	Type       string             `json:"type" validate:"required"` // Prerequisite, Corequisite or Antirequisite
+	Requisites *RequisiteGroupReq `json:"requisites"`               // Null removes the course's requisites of the type
+} //@name SetCourseRequisitesRequest
+
+// newInsertRequisiteGroup converts a requisite group request, returning false for groups which are too deep,
+// have an unknown operator, list the course itself or are nested without courses or groups.
+func newInsertRequisiteGroup(courseId string, group RequisiteGroupReq, depth int) (repository.InsertRequisiteGroup, bool) {
+	if depth > maxRequisiteDepth {
+		return repository.InsertRequisiteGroup{}, false
+	}
+	if depth > 1 && len(group.CourseIds) == 0 && len(group.Groups) == 0 {
+		return repository.InsertRequisiteGroup{}, false
+	}
+	if group.Operator != "" && group.Operator != repository.RequisiteAll && group.Operator != repository.RequisiteAny {
+		return repository.InsertRequisiteGroup{}, false
+	}
+
+	insert := repository.InsertRequisiteGroup{
+		Operator:  group.Operator,
+		CourseIds: group.CourseIds,
+	}
+	for _, requiredId := range group.CourseIds {
+		if requiredId == courseId {
+			return repository.InsertRequisiteGroup{}, false
+		}
+	}
+	for _, child := range group.Groups {
+		childInsert, ok := newInsertRequisiteGroup(courseId, child, depth+1)
+		if !ok {
+			return repository.InsertRequisiteGroup{}, false
+		}
+		insert.Groups = append(insert.Groups, childInsert)
+	}
+
+	return insert, true
+}
+
+// SetCourseRequisites replaces a course's requisites of a type.
+// @Summary Set course requisites
+// @Description Groups require all or any of their courses and nested groups, nested at most 5 levels deep.
+// @Tags Admin
+// @Accept json
+// @Param courseId path string true "Course ID"
+// @Param body body SetCourseRequisitesRequest true "Requisite type and group"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string "Course or required course not found"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/courses/{courseId}/requisites [put]
+func (a *adminHandler) SetCourseRequisites(w http.ResponseWriter, r *http.Request) {
+	var body SetCourseRequisitesReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	var group *repository.InsertRequisiteGroup
+	if body.Requisites != nil {
+		insert, ok := newInsertRequisiteGroup(courseId, *body.Requisites, 1)
+		if !ok {
+			http.Error(w, "Requisite groups must use the All or Any operator, nest at most 5 levels, not be empty when nested and not list the course itself.", http.StatusBadRequest)
+			return
+		}
+		group = &insert
+	}
+
+	err := a.requisiteRepo.SetCourseRequisites(r.Context(), courseId, body.Type, group)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid requisite type or course ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Course or required course not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ProgramRequirementReq struct {
+	Name        string   `json:"name" validate:"required"`
+	Description *string  `json:"description"`
+	Type        string   `json:"type" validate:"required"` // Required, Elective or Credits
+	Credits     *float64 `json:"credits"`                  // Required for Elective and Credits requirements, omitted for Required requirements
+	CourseIds   []string `json:"courseIds"`
+	CategoryIds []string `json:"categoryIds"` // Only counted by Elective and Credits requirements
+} //@name ProgramRequirementRequest
+
+type SetProgramRequirementsReq struct {
+	Requirements []ProgramRequirementReq `json:"requirements"`
+} //@name SetProgramRequirementsRequest
+
+// SetProgramRequirements replaces a program's requirements.
+// @Summary Set program requirements
+// @Description Requirements are evaluated and listed in the given order, an empty list removes the program's requirements.
+// @Tags Admin
+// @Accept json
+// @Param programId path string true "Program ID"
+// @Param body body SetProgramRequirementsRequest true "Program requirements"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string "Program, course or category not found"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/programs/{programId}/requirements [put]
+func (a *adminHandler) SetProgramRequirements(w http.ResponseWriter, r *http.Request) {
+	var body SetProgramRequirementsReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	requirements := make([]repository.InsertProgramRequirement, 0, len(body.Requirements))
+	for _, requirement := range body.Requirements {
+		name := strings.TrimSpace(requirement.Name)
+		if name == "" {
+			http.Error(w, "Every requirement needs a name.", http.StatusBadRequest)
+			return
+		}
+
+		switch requirement.Type {
+		case repository.RequirementRequired:
+			if requirement.Credits != nil || len(requirement.CourseIds) == 0 {
+				http.Error(w, "Required requirements list courses without credits.", http.StatusBadRequest)
+				return
+			}
+		case repository.RequirementElective:
+			if requirement.Credits == nil || *requirement.Credits <= 0 || (len(requirement.CourseIds) == 0 && len(requirement.CategoryIds) == 0) {
+				http.Error(w, "Elective requirements need credits and courses or categories.", http.StatusBadRequest)
+				return
+			}
+		case repository.RequirementCredits:
+			if requirement.Credits == nil || *requirement.Credits <= 0 {
+				http.Error(w, "Credits requirements need credits.", http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, "Requirement type must be one of Required, Elective or Credits.", http.StatusBadRequest)
+			return
+		}
+
+		requirements = append(requirements, repository.InsertProgramRequirement{
+			Name:        name,
+			Description: requirement.Description,
+			Type:        requirement.Type,
+			Credits:     requirement.Credits,
+			CourseIds:   requirement.CourseIds,
+			CategoryIds: requirement.CategoryIds,
+		})
+	}
+
+	err := a.requirementRepo.SetProgramRequirements(r.Context(), chi.URLParam(r, "programId"), requirements)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid program, course or category ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Program, course or category not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -26,16 +26,18 @@ type authHandler struct {
 	openIdProvider openid.OpenIdProvider
 	userRepo       repository.UserRepository
 	sessionRepo    repository.SessionRepository
+	suspensionRepo repository.SuspensionRepository
 	jwt            *authorizer.JwtAuthorizer
 	emailer        emailer.NoReplyEmailer
 }
 
-func NewAuthHandler(log logger.Logger, user repository.UserRepository, session repository.SessionRepository, openId openid.OpenIdProvider, jwt *authorizer.JwtAuthorizer, emailer emailer.NoReplyEmailer) *authHandler {
+func NewAuthHandler(log logger.Logger, user repository.UserRepository, session repository.SessionRepository, suspension repository.SuspensionRepository, openId openid.OpenIdProvider, jwt *authorizer.JwtAuthorizer, emailer emailer.NoReplyEmailer) *authHandler {
 	return &authHandler{
 		log:            log,
 		openIdProvider: openId,
 		userRepo:       user,
 		sessionRepo:    session,
+		suspensionRepo: suspension,
 		jwt:            jwt,
 		emailer:        emailer,
 	}
@@ -136,6 +138,21 @@ func (ah *authHandler) ProviderCallback(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// Refuse new sessions for suspended users
+	if !newUserFlag {
+		access, err := ah.suspensionRepo.GetUserAccess(r.Context(), userId)
+		if err != nil {
+			http.Error(w, "Unable to create session for user.", http.StatusInternalServerError)
+			return
+		}
+
+		if access.IsSuspended() {
+			ah.log.Info(fmt.Sprintf("suspended user %s attempted to log in", userId))
+			http.Redirect(w, r, os.Getenv(config.ClientDomain)+"/suspended", http.StatusFound)
+			return
+		}
+	}
+
 	sessionId, err := ah.sessionRepo.CreateSession(r.Context(), userId) // create session log in database
 	if err != nil {
 		ah.log.Error("failed to create session log", logger.Err(err))
@@ -253,14 +270,58 @@ func (ah *authHandler) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		access, err := ah.suspensionRepo.GetUserAccess(r.Context(), session.UserId)
+		if err != nil {
+			if errors.Is(err, util.ErrNotFound) || errors.Is(err, util.ErrMalformed) {
+				http.Error(w, "Invalid session token.", http.StatusUnauthorized)
+			} else {
+				http.Error(w, "Unable to verify session.", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		if access.IsSuspended() {
+			http.Error(w, suspensionMessage(access), http.StatusForbidden)
+			return
+		}
+		session.Role = access.Role
+
 		ctx := context.WithValue(r.Context(), config.AuthKey, session)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// AdminMiddleware restricts endpoints to admins, it must be used after [authHandler.AuthMiddleware].
+func (ah *authHandler) AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+		if !ok || session.Role != repository.RoleAdmin {
+			http.Error(w, "Admin access required.", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// suspensionMessage describes why a user is unable to access their account.
+func suspensionMessage(access repository.UserAccessSchema) string {
+	if access.Suspension == nil {
+		return "Your account has been deactivated."
+	}
+
+	if access.Suspension.DateExpires.Valid {
+		return fmt.Sprintf("Your account has been suspended until %s. Reason: %s",
+			access.Suspension.DateExpires.Time.UTC().Format(time.RFC3339), access.Suspension.Reason)
+	}
+
+	return fmt.Sprintf("Your account has been permanently suspended. Reason: %s", access.Suspension.Reason)
+}
+
 type SessionPayload struct {
 	Id     string `json:"id"`
 	UserId string `json:"userId"`
+	Role   string `json:"-"` // Populated by AuthMiddleware, not part of the session token
 } //@name SessionResponse
 
 // decodeSessionToken decodes a token string to a session model.
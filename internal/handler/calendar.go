@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/calendar"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+type calendarHandler struct {
+	log          logger.Logger
+	calendarRepo repository.CalendarRepository
+	termRepo     repository.TermRepository
+}
+
+func NewCalendarHandler(log logger.Logger, calendar repository.CalendarRepository, term repository.TermRepository) *calendarHandler {
+	return &calendarHandler{
+		log:          log,
+		calendarRepo: calendar,
+		termRepo:     term,
+	}
+}
+
+// GetSyllabusCalendar exports a syllabus' dated assessments as an iCalendar file.
+// @Summary Export syllabus assessments
+// @Description Events keep the same UID across exports, so re-importing updates rather than duplicates them.
+// @Tags Syllabus
+// @Produce text/calendar
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 200 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/calendar.ics [get]
+func (c *calendarHandler) GetSyllabusCalendar(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	syllabusCalendar, err := c.calendarRepo.GetSyllabusCalendar(r.Context(), session.UserId, syllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus details not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", calendarFileName(syllabusCalendar.Course)))
+	w.WriteHeader(http.StatusOK)
+	calendar.Write(w, fmt.Sprintf("%s %s %d", syllabusCalendar.Course, syllabusCalendar.Semester, syllabusCalendar.Year), newAssessmentEvents(syllabusCalendar))
+}
+
+type CalendarSubscriptionRes struct {
+	Url string `json:"url"` // Secret feed URL to subscribe to from a calendar app
+} //@name CalendarSubscriptionResponse
+
+// CreateCalendarSubscription creates the user's calendar feed URL, replacing any previous URL.
+// @Summary Create a calendar subscription
+// @Description The feed merges the dated assessments of the user's courses for the current term.
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 201 {object} CalendarSubscriptionResponse
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/calendar [post]
+func (c *calendarHandler) CreateCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if session.UserId != userId {
+		http.Error(w, "You do not have access to this user's calendar.", http.StatusForbidden)
+		return
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		c.log.Error("failed to generate calendar token", logger.Err(err))
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	if err := c.calendarRepo.SetCalendarToken(r.Context(), userId, hashCalendarToken(token)); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CalendarSubscriptionRes{
+		Url: fmt.Sprintf("%s/users/%s/calendar.ics?token=%s", os.Getenv(config.ServerDomain), userId, token),
+	})
+}
+
+// DeleteCalendarSubscription revokes the user's calendar feed URL.
+// @Summary Delete a calendar subscription
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 204 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/calendar [delete]
+func (c *calendarHandler) DeleteCalendarSubscription(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if session.UserId != userId {
+		http.Error(w, "You do not have access to this user's calendar.", http.StatusForbidden)
+		return
+	}
+
+	if err := c.calendarRepo.DeleteCalendarToken(r.Context(), userId); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Calendar subscription not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserCalendarFeed serves the user's calendar feed, authenticated by the token in its URL since calendar apps have no session.
+// @Summary Get a user's calendar feed
+// @Description Merges the dated assessments of the user's courses for the current term.
+// @Tags User
+// @Produce text/calendar
+// @Param userId path string true "User ID"
+// @Param token query string true "Calendar subscription token"
+// @Success 200 {string} string
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Router /users/{userId}/calendar.ics [get]
+func (c *calendarHandler) GetUserCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "userId")
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "Invalid calendar token.", http.StatusForbidden)
+		return
+	}
+
+	if err := c.calendarRepo.VerifyCalendarToken(r.Context(), userId, hashCalendarToken(token)); err != nil {
+		if errors.Is(err, util.ErrNotFound) || errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid calendar token.", http.StatusForbidden)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	events := []calendar.Event{}
+	name := "Syllabye"
+	term, err := c.termRepo.GetTerm(r.Context(), repository.CurrentTerm)
+	if err != nil && !errors.Is(err, util.ErrNotFound) {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	// The feed stays subscribed with no events when no term is current
+	if err == nil {
+		calendars, err := c.calendarRepo.ListTermCalendars(r.Context(), userId, term.Id)
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+
+		for _, termCalendar := range calendars {
+			events = append(events, newAssessmentEvents(termCalendar)...)
+		}
+		name += " " + term.Name
+	}
+
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.WriteHeader(http.StatusOK)
+	calendar.Write(w, name, events)
+}
+
+func hashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func calendarFileName(course string) string {
+	return strings.Join(strings.Fields(course), "-") + ".ics"
+}
+
+// newAssessmentEvents creates events for the dated assessments of a syllabus.
+// UIDs are derived from the course, term and assessment name so they stay the same when dates are corrected or another section's syllabus is used.
+func newAssessmentEvents(syllabusCalendar repository.CalendarSchema) []calendar.Event {
+	events := []calendar.Event{}
+	occurrences := map[string]int{}
+	for _, assessment := range syllabusCalendar.Details.Assessments {
+		date, err := time.Parse(extractor.DateLayout, assessment.Date)
+		if err != nil {
+			continue
+		}
+
+		name := strings.ToLower(assessment.Name)
+		occurrences[name]++
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%s|%d", syllabusCalendar.CourseId, syllabusCalendar.Year, syllabusCalendar.Semester, name, occurrences[name])))
+
+		description := ""
+		if assessment.Weight > 0 {
+			description = fmt.Sprintf("Worth %g%% of the final grade.", assessment.Weight)
+		}
+
+		events = append(events, calendar.Event{
+			Uid:          hex.EncodeToString(hash[:16]) + "@syllabye",
+			Summary:      fmt.Sprintf("%s: %s", syllabusCalendar.Course, assessment.Name),
+			Description:  description,
+			Date:         date,
+			DateModified: syllabusCalendar.DateModified,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,536 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/nullable"
+)
+
+type collectionHandler struct {
+	log                logger.Logger
+	collectionRepo     repository.CollectionRepository
+	syllabusRepo       repository.SyllabusRepository
+	thumbnailPresigner bucket.PresignerClient
+}
+
+func NewCollectionHandler(log logger.Logger, collection repository.CollectionRepository, syllabus repository.SyllabusRepository, thumbnailPresigner bucket.PresignerClient) *collectionHandler {
+	return &collectionHandler{
+		log:                log,
+		collectionRepo:     collection,
+		syllabusRepo:       syllabus,
+		thumbnailPresigner: thumbnailPresigner,
+	}
+}
+
+type CollectionRes struct {
+	Id            string        `json:"id"`
+	Name          string        `json:"name"`
+	Description   *string       `json:"description"`
+	Visibility    string        `json:"visibility"` // Private or Shared
+	ShareUrl      *string       `json:"shareUrl"`   // Read only link, null unless shared
+	IsDefault     bool          `json:"isDefault"`  // The default collection holds bookmarks
+	SyllabusCount int           `json:"syllabusCount"`
+	Syllabi       []SyllabusRes `json:"syllabi,omitempty"` // Only included when getting a single collection
+	DateAdded     int64         `json:"dateAdded"`
+	DateModified  int64         `json:"dateModified"`
+} //@name CollectionResponse
+
+func newCollectionRes(collection repository.CollectionSchema) CollectionRes {
+	res := CollectionRes{
+		Id:            collection.Id,
+		Name:          collection.Name,
+		Visibility:    collection.Visibility,
+		IsDefault:     collection.IsDefault,
+		SyllabusCount: collection.SyllabusCount,
+		DateAdded:     collection.DateAdded.UnixMicro(),
+		DateModified:  collection.DateModified.UnixMicro(),
+	}
+	if collection.Description.Valid {
+		res.Description = &collection.Description.String
+	}
+	if collection.Visibility == repository.CollectionShared {
+		shareUrl := os.Getenv(config.ServerDomain) + "/collections/shared/" + collection.ShareToken
+		res.ShareUrl = &shareUrl
+	}
+
+	return res
+}
+
+// ListCollections lists the user's collections, the default collection first.
+// @Summary List collections
+// @Tags Collection
+// @Success 200 {array} CollectionResponse
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections [get]
+func (c *collectionHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	collections, err := c.collectionRepo.ListUserCollections(r.Context(), session.UserId)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]CollectionRes, 0, len(collections))
+	for _, collection := range collections {
+		res = append(res, newCollectionRes(collection))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetCollection retrieves one of the user's collections with its syllabi in order.
+// @Summary Get a collection
+// @Tags Collection
+// @Param collectionId path string true "Collection ID"
+// @Success 200 {object} CollectionResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId} [get]
+func (c *collectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	collection, err := c.collectionRepo.GetUserCollection(r.Context(), session.UserId, chi.URLParam(r, "collectionId"))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid collection ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.writeCollection(w, r, session.UserId, collection)
+}
+
+// GetSharedCollection retrieves a shared collection by its read only link.
+// @Summary Get a shared collection
+// @Tags Collection
+// @Param shareToken path string true "Share token of the collection link"
+// @Success 200 {object} CollectionResponse
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /collections/shared/{shareToken} [get]
+func (c *collectionHandler) GetSharedCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	collection, err := c.collectionRepo.GetSharedCollection(r.Context(), chi.URLParam(r, "shareToken"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.writeCollection(w, r, session.UserId, collection)
+}
+
+// writeCollection writes a collection with the syllabi visible to the viewer.
+func (c *collectionHandler) writeCollection(w http.ResponseWriter, r *http.Request, viewerId string, collection repository.CollectionSchema) {
+	syllabi, err := c.collectionRepo.ListCollectionSyllabi(r.Context(), viewerId, collection.Id)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	res := newCollectionRes(collection)
+	// Shared viewers do not see the owner's unpublished syllabi
+	res.SyllabusCount = len(syllabi)
+	res.Syllabi = make([]SyllabusRes, 0, len(syllabi))
+	for _, syllabus := range syllabi {
+		res.Syllabi = append(res.Syllabi, newSyllabusRes(r.Context(), c.thumbnailPresigner, syllabus))
+	}
+	if err := setSyllabusReactions(r.Context(), c.syllabusRepo, viewerId, res.Syllabi); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+type CreateCollectionReq struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Visibility  string  `json:"visibility"` // Private (default) or Shared
+} //@name CreateCollectionRequest
+
+// CreateCollection creates a collection for the user.
+// @Summary Create a collection
+// @Tags Collection
+// @Accept json
+// @Param body body CreateCollectionRequest true "Name of at most 100 characters, optional description and visibility"
+// @Success 201 {object} CollectionResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections [post]
+func (c *collectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body CreateCollectionReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		http.Error(w, "A collection name is required.", http.StatusBadRequest)
+		return
+	}
+	if body.Visibility == "" {
+		body.Visibility = repository.CollectionPrivate
+	}
+
+	collection, err := c.collectionRepo.CreateCollection(r.Context(), session.UserId, repository.InsertCollection{
+		Name:        body.Name,
+		Description: body.Description,
+		Visibility:  body.Visibility,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCollectionRes(collection))
+}
+
+type UpdateCollectionReq struct {
+	Name        nullable.Nullable[string] `json:"name" swaggertype:"primitive,string"`
+	Description nullable.Nullable[string] `json:"description" swaggertype:"primitive,string" extensions:"x-nullable"`
+	// Visibility is Private or Shared, a null value resets to Private.
+	Visibility nullable.Nullable[string] `json:"visibility" swaggertype:"primitive,string" extensions:"x-nullable"`
+} //@name UpdateCollectionRequest
+
+// UpdateCollection updates the name, description or visibility of the user's collection.
+// @Summary Update a collection
+// @Description The default collection cannot be renamed. Making a collection private replaces its share link, so links shared before stop working.
+// @Tags Collection
+// @Accept json
+// @Param collectionId path string true "Collection ID"
+// @Param body body UpdateCollectionRequest true "Fields to update"
+// @Success 200 {object} CollectionResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId} [patch]
+func (c *collectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body UpdateCollectionReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if name, err := body.Name.Get(); err == nil {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			http.Error(w, "A collection name is required.", http.StatusBadRequest)
+			return
+		}
+		body.Name.Set(name)
+	}
+
+	collection, err := c.collectionRepo.UpdateCollection(r.Context(), session.UserId, chi.URLParam(r, "collectionId"), repository.UpdateCollection{
+		Name:        body.Name,
+		Description: body.Description,
+		Visibility:  body.Visibility,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "The default collection cannot be renamed.", http.StatusForbidden)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newCollectionRes(collection))
+}
+
+// DeleteCollection deletes the user's collection.
+// @Summary Delete a collection
+// @Description The default collection cannot be deleted.
+// @Tags Collection
+// @Param collectionId path string true "Collection ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId} [delete]
+func (c *collectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := c.collectionRepo.DeleteCollection(r.Context(), session.UserId, chi.URLParam(r, "collectionId")); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid collection ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "The default collection cannot be deleted.", http.StatusForbidden)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type AddCollectionSyllabusReq struct {
+	SyllabusId string `json:"syllabusId"`
+} //@name AddCollectionSyllabusRequest
+
+// AddCollectionSyllabus adds a syllabus to the end of the user's collection.
+// @Summary Add a syllabus to a collection
+// @Tags Collection
+// @Accept json
+// @Param collectionId path string true "Collection ID"
+// @Param body body AddCollectionSyllabusRequest true "Syllabus to add"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Syllabus is already in the collection"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId}/syllabi [post]
+func (c *collectionHandler) AddCollectionSyllabus(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body AddCollectionSyllabusReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	err := c.collectionRepo.AddCollectionSyllabus(r.Context(), session.UserId, chi.URLParam(r, "collectionId"), body.SyllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid collection or syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection or syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Syllabus is already in the collection.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveCollectionSyllabus removes a syllabus from the user's collection.
+// @Summary Remove a syllabus from a collection
+// @Tags Collection
+// @Param collectionId path string true "Collection ID"
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId}/syllabi/{syllabusId} [delete]
+func (c *collectionHandler) RemoveCollectionSyllabus(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	err := c.collectionRepo.RemoveCollectionSyllabus(r.Context(), session.UserId, chi.URLParam(r, "collectionId"), chi.URLParam(r, "syllabusId"))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid collection or syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found in the collection.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ReorderCollectionReq struct {
+	SyllabusIds []string `json:"syllabusIds"` // Every syllabus listed in the collection, in the new order
+} //@name ReorderCollectionRequest
+
+// ReorderCollection orders the syllabi of the user's collection.
+// @Summary Reorder a collection
+// @Description Syllabi which were removed, rejected or expired are not listed, they keep their order after the listed syllabi.
+// @Tags Collection
+// @Accept json
+// @Param collectionId path string true "Collection ID"
+// @Param body body ReorderCollectionRequest true "Every syllabus listed in the collection, in the new order"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /me/collections/{collectionId}/syllabi [put]
+func (c *collectionHandler) ReorderCollection(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body ReorderCollectionReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	err := c.collectionRepo.ReorderCollectionSyllabi(r.Context(), session.UserId, chi.URLParam(r, "collectionId"), body.SyllabusIds)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Every syllabus in the collection must be listed once.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Collection not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BookmarkSyllabus saves a syllabus to the user's default collection.
+// @Summary Bookmark a syllabus
+// @Description Bookmarks are kept in the default "Saved" collection, which is created by the first bookmark.
+// @Tags Collection
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/bookmark [put]
+func (c *collectionHandler) BookmarkSyllabus(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := c.collectionRepo.BookmarkSyllabus(r.Context(), session.UserId, chi.URLParam(r, "syllabusId")); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveBookmark removes a syllabus from the user's default collection.
+// @Summary Remove a bookmark
+// @Tags Collection
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/bookmark [delete]
+func (c *collectionHandler) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	if err := c.collectionRepo.RemoveBookmark(r.Context(), session.UserId, chi.URLParam(r, "syllabusId")); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Bookmark not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/emailer"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxCommentLength = 2000
+	// Notification emails quote the start of long comments.
+	maxCommentExcerptLength = 200
+)
+
+// Comment status values, deleted and removed comments are placeholders without their content.
+const (
+	CommentVisible = "Visible"
+	CommentDeleted = "Deleted"
+	CommentRemoved = "Removed"
+)
+
+type commentHandler struct {
+	log         logger.Logger
+	commentRepo repository.CommentRepository
+	emailer     emailer.NoReplyEmailer
+}
+
+func NewCommentHandler(log logger.Logger, comment repository.CommentRepository, emailer emailer.NoReplyEmailer) *commentHandler {
+	return &commentHandler{
+		log:         log,
+		commentRepo: comment,
+		emailer:     emailer,
+	}
+}
+
+type CommentRes struct {
+	Id         string  `json:"id"`
+	SyllabusId string  `json:"syllabusId"`
+	UserId     *string `json:"userId"`   // Null for placeholders and comments of deleted users
+	ThreadId   *string `json:"threadId"` // Top level comment of the thread, null for top level comments
+	ParentId   *string `json:"parentId"`
+	Content    *string `json:"content"` // Null for placeholders
+	Status     string  `json:"status"`  // Visible, Deleted or Removed
+	ReplyCount int     `json:"replyCount"`
+	DateEdited *int64  `json:"dateEdited"`
+	DateAdded  int64   `json:"dateAdded"`
+} //@name CommentResponse
+
+func newCommentRes(comment repository.CommentSchema) CommentRes {
+	res := CommentRes{
+		Id:         comment.Id,
+		SyllabusId: comment.SyllabusId,
+		Status:     CommentVisible,
+		ReplyCount: comment.ReplyCount,
+		DateAdded:  comment.DateAdded.UnixMicro(),
+	}
+	if comment.ThreadId.Valid {
+		res.ThreadId = &comment.ThreadId.String
+	}
+	if comment.ParentId.Valid {
+		res.ParentId = &comment.ParentId.String
+	}
+
+	if comment.DateRemoved.Valid {
+		res.Status = CommentRemoved
+	} else if comment.DateDeleted.Valid {
+		res.Status = CommentDeleted
+	}
+	if comment.IsHidden() {
+		return res
+	}
+
+	res.Content = &comment.Content
+	if comment.UserId.Valid {
+		res.UserId = &comment.UserId.String
+	}
+	if comment.DateEdited.Valid {
+		dateEdited := comment.DateEdited.Time.UnixMicro()
+		res.DateEdited = &dateEdited
+	}
+
+	return res
+}
+
+// ListComments lists the top level comments of a syllabus.
+// @Summary List syllabus comments
+// @Description Newest first. Deleted and removed comments are listed as placeholders while they have replies.
+// @Tags Comment
+// @Param syllabusId path string true "Syllabus ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} CommentResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/comments [get]
+func (c *commentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	syllabusId := chi.URLParam(r, "syllabusId")
+	comments, err := c.commentRepo.ListComments(r.Context(), session.UserId, syllabusId, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]CommentRes, 0, len(comments))
+	for _, comment := range comments {
+		res = append(res, newCommentRes(comment))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ListCommentReplies lists the replies in a comment's thread.
+// @Summary List comment replies
+// @Description Oldest first, including placeholders of deleted and removed replies. Nest replies by their parent ID.
+// @Tags Comment
+// @Param syllabusId path string true "Syllabus ID"
+// @Param commentId path string true "Comment ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} CommentResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/comments/{commentId}/replies [get]
+func (c *commentHandler) ListCommentReplies(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	syllabusId := chi.URLParam(r, "syllabusId")
+	commentId := chi.URLParam(r, "commentId")
+	replies, err := c.commentRepo.ListCommentReplies(r.Context(), session.UserId, syllabusId, commentId, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus or comment ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]CommentRes, 0, len(replies))
+	for _, reply := range replies {
+		res = append(res, newCommentRes(reply))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+type CreateCommentReq struct {
+	Content  string `json:"content"`
+	ParentId string `json:"parentId"` // Comment being replied to, omit for a top level comment
+} //@name CreateCommentRequest
+
+// CreateComment comments on a syllabus or replies to a comment.
+// @Summary Create a comment
+// @Description The syllabus uploader is notified of new comments and authors are notified of replies by email.
+// @Tags Comment
+// @Accept json
+// @Param syllabusId path string true "Syllabus ID"
+// @Param body body CreateCommentRequest true "Comment of at most 2000 characters"
+// @Success 201 {object} CommentResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Syllabus is not published or the parent comment was deleted"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/comments [post]
+func (c *commentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body CreateCommentReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	content, ok := parseCommentContent(body.Content)
+	if !ok {
+		http.Error(w, "A comment of at most 2000 characters is required.", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	comment, notice, err := c.commentRepo.CreateComment(r.Context(), session.UserId, syllabusId, body.ParentId, content)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus or parent comment ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus or parent comment not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Only published syllabi and visible comments can be commented on.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	excerpt := commentExcerpt(content)
+	if notice.Parent != nil && notice.Parent.UserId != session.UserId {
+		c.emailer.SendCommentReplyEmail(r.Context(), notice.Parent.Email, notice.Parent.Name, notice.Course, excerpt)
+	}
+	// Uploaders replied to directly already received a reply email
+	if notice.Owner.UserId != session.UserId && (notice.Parent == nil || notice.Parent.UserId != notice.Owner.UserId) {
+		c.emailer.SendSyllabusCommentEmail(r.Context(), notice.Owner.Email, notice.Owner.Name, notice.Course, excerpt)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCommentRes(comment))
+}
+
+type UpdateCommentReq struct {
+	Content string `json:"content"`
+} //@name UpdateCommentRequest
+
+// UpdateComment edits the user's comment.
+// @Summary Edit a comment
+// @Tags Comment
+// @Accept json
+// @Param syllabusId path string true "Syllabus ID"
+// @Param commentId path string true "Comment ID"
+// @Param body body UpdateCommentRequest true "Comment of at most 2000 characters"
+// @Success 200 {object} CommentResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Comment was deleted or removed"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/comments/{commentId} [patch]
+func (c *commentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body UpdateCommentReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	content, ok := parseCommentContent(body.Content)
+	if !ok {
+		http.Error(w, "A comment of at most 2000 characters is required.", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	commentId := chi.URLParam(r, "commentId")
+	comment, err := c.commentRepo.UpdateComment(r.Context(), session.UserId, syllabusId, commentId, content)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus or comment ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Comment not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "You can only edit your own comments.", http.StatusForbidden)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Deleted and removed comments cannot be edited.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newCommentRes(comment))
+}
+
+// DeleteComment deletes the user's comment, leaving a placeholder in its thread.
+// @Summary Delete a comment
+// @Tags Comment
+// @Param syllabusId path string true "Syllabus ID"
+// @Param commentId path string true "Comment ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/comments/{commentId} [delete]
+func (c *commentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	commentId := chi.URLParam(r, "commentId")
+	if err := c.commentRepo.DeleteComment(r.Context(), session.UserId, syllabusId, commentId); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus or comment ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Comment not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "You can only delete your own comments.", http.StatusForbidden)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseCommentContent trims a comment, reporting whether it is non-empty and within the length limit.
+func parseCommentContent(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	return content, content != "" && utf8.RuneCountInString(content) <= maxCommentLength
+}
+
+func commentExcerpt(content string) string {
+	runes := []rune(content)
+	if len(runes) <= maxCommentExcerptLength {
+		return content
+	}
+
+	return strings.TrimSpace(string(runes[:maxCommentExcerptLength])) + "…"
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/JackieLi565/syllabye/internal/config"
@@ -13,14 +14,16 @@ import (
 )
 
 type courseHandler struct {
-	log        logger.Logger
-	courseRepo repository.CourseRepository
+	log           logger.Logger
+	courseRepo    repository.CourseRepository
+	requisiteRepo repository.RequisiteRepository
 }
 
-func NewCourseHandler(log logger.Logger, course repository.CourseRepository) *courseHandler {
+func NewCourseHandler(log logger.Logger, course repository.CourseRepository, requisite repository.RequisiteRepository) *courseHandler {
 	return &courseHandler{
-		log:        log,
-		courseRepo: course,
+		log:           log,
+		courseRepo:    course,
+		requisiteRepo: requisite,
 	}
 }
 
@@ -31,8 +34,50 @@ type CourseRes struct {
 	Description nullable.Nullable[string] `json:"description" swaggertype:"primitive,string" extensions:"x-nullable"`
 	Uri         string                    `json:"uri"`
 	Course      string                    `json:"course"`
+	Credits     float64                   `json:"credits"`
+	Ratings     CourseRatingsRes          `json:"ratings"`
+	// Requisites as published in the academic calendar, see GET /courses/{courseId}/requisites for linked courses
+	Prerequisites  nullable.Nullable[string] `json:"prerequisites" swaggertype:"primitive,string" extensions:"x-nullable"`
+	Corequisites   nullable.Nullable[string] `json:"corequisites" swaggertype:"primitive,string" extensions:"x-nullable"`
+	Antirequisites nullable.Nullable[string] `json:"antirequisites" swaggertype:"primitive,string" extensions:"x-nullable"`
 } //@name CourseResponse
 
+type CourseRatingsRes struct {
+	ReviewCount int      `json:"reviewCount"`
+	Difficulty  *float64 `json:"difficulty"` // Averages from 1 to 5, null without reviews
+	Workload    *float64 `json:"workload"`
+	Usefulness  *float64 `json:"usefulness"`
+} //@name CourseRatingsResponse
+
+func newCourseRes(course repository.CourseSchema) CourseRes {
+	res := CourseRes{
+		Id:          course.Id,
+		CategoryId:  course.CategoryId,
+		Title:       course.Title,
+		Description: util.DefaultNullable(course.Description.Valid, course.Description.String),
+		Uri:         course.Uri,
+		Course:      course.Course,
+		Credits:     course.Credits,
+		Ratings: CourseRatingsRes{
+			ReviewCount: course.Ratings.ReviewCount,
+		},
+		Prerequisites:  util.DefaultNullable(course.Prerequisites.Valid, course.Prerequisites.String),
+		Corequisites:   util.DefaultNullable(course.Corequisites.Valid, course.Corequisites.String),
+		Antirequisites: util.DefaultNullable(course.Antirequisites.Valid, course.Antirequisites.String),
+	}
+	if course.Ratings.Difficulty.Valid {
+		res.Ratings.Difficulty = &course.Ratings.Difficulty.Float64
+	}
+	if course.Ratings.Workload.Valid {
+		res.Ratings.Workload = &course.Ratings.Workload.Float64
+	}
+	if course.Ratings.Usefulness.Valid {
+		res.Ratings.Usefulness = &course.Ratings.Usefulness.Float64
+	}
+
+	return res
+}
+
 // GetCourse retrieves a specific course by ID.
 // @Summary Get a course
 // @Tags Course
@@ -54,24 +99,21 @@ func (c *courseHandler) GetCourse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(CourseRes{
-		Id:          course.Id,
-		CategoryId:  course.CategoryId,
-		Title:       course.Title,
-		Description: util.DefaultNullable(course.Description.Valid, course.Description.String),
-		Uri:         course.Uri,
-		Course:      course.Course,
-	})
+	json.NewEncoder(w).Encode(newCourseRes(course))
 }
 
 // ListCourses returns a paginated list of courses, optionally filtered by name or category.
 // @Summary List courses
+// @Description Courses are ordered by code unless sorted by a rating, courses without reviews are listed last.
 // @Tags Course
 // @Param search query string false "Search by course name or code"
 // @Param category query string false "Filter by category ID"
+// @Param sort query string false "Sort by difficulty, workload, usefulness or reviews"
+// @Param order query string false "Sort order, asc (default) or desc"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 25)"
 // @Success 200 {array} CourseResponse
+// @Failure 400 {string} string
 // @Failure 500 {string} string
 // @Security Session
 // @Router /courses [get]
@@ -85,25 +127,244 @@ func (c *courseHandler) ListCourses(w http.ResponseWriter, r *http.Request) {
 	queryFilters := repository.CourseFilters{
 		Search:     query.Get("search"),
 		CategoryId: query.Get("category"),
+		Sort:       query.Get("sort"),
+		Descending: query.Get("order") == "desc",
 	}
 	paginateOptions := util.NewPaginate(query.Get("page"), query.Get("size"))
 	courses, err := c.courseRepo.ListCourses(r.Context(), queryFilters, paginateOptions)
 	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Sort must be one of difficulty, workload, usefulness or reviews.", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Failed to get faculties", http.StatusInternalServerError)
 		return
 	}
 
 	courseRes := make([]CourseRes, 0, len(courses))
 	for _, course := range courses {
-		courseRes = append(courseRes, CourseRes{
-			Id:          course.Id,
-			CategoryId:  course.CategoryId,
-			Title:       course.Title,
-			Description: util.DefaultNullable(course.Description.Valid, course.Description.String),
-			Uri:         course.Uri,
-			Course:      course.Course,
-		})
+		courseRes = append(courseRes, newCourseRes(course))
 	}
 
 	json.NewEncoder(w).Encode(courseRes)
 }
+
+type RequisiteCourseRes struct {
+	CourseId string `json:"courseId"`
+	Course   string `json:"course"`
+	Title    string `json:"title"`
+	Taken    *bool  `json:"taken"`   // Whether the user completed prerequisites, or has the course at all otherwise, only set on eligibility checks
+	Unknown  *bool  `json:"unknown"` // Whether the user has a prerequisite without a term, so its completion is unknown, only set on eligibility checks
+} //@name RequisiteCourseResponse
+
+type RequisiteGroupRes struct {
+	Id        string               `json:"id"`
+	Operator  string               `json:"operator"` // All, or Any for one of
+	Courses   []RequisiteCourseRes `json:"courses"`
+	Groups    []RequisiteGroupRes  `json:"groups"`
+	Satisfied *bool                `json:"satisfied"` // Only set on eligibility checks
+} //@name RequisiteGroupResponse
+
+type CourseRequisitesRes struct {
+	Prerequisites  *RequisiteGroupRes `json:"prerequisites"`
+	Corequisites   *RequisiteGroupRes `json:"corequisites"`
+	Antirequisites *RequisiteGroupRes `json:"antirequisites"` // Taking any of the courses excludes the course
+} //@name CourseRequisitesResponse
+
+// newRequisiteGroupRes converts a requisite tree, evaluating it against the taken courses when they are given.
+// Unknown holds the courses whose completion is unknown, which are not counted as taken.
+func newRequisiteGroupRes(group *repository.RequisiteGroupSchema, taken map[string]bool, unknown map[string]bool) *RequisiteGroupRes {
+	if group == nil {
+		return nil
+	}
+
+	res := &RequisiteGroupRes{
+		Id:       group.Id,
+		Operator: group.Operator,
+		Courses:  make([]RequisiteCourseRes, 0, len(group.Courses)),
+		Groups:   make([]RequisiteGroupRes, 0, len(group.Groups)),
+	}
+	if taken != nil {
+		satisfied := group.Satisfied(taken)
+		res.Satisfied = &satisfied
+	}
+	for _, course := range group.Courses {
+		courseRes := RequisiteCourseRes{
+			CourseId: course.CourseId,
+			Course:   course.Course,
+			Title:    course.Title,
+		}
+		if taken != nil {
+			courseTaken := taken[course.CourseId]
+			courseUnknown := unknown[course.CourseId]
+			courseRes.Taken = &courseTaken
+			courseRes.Unknown = &courseUnknown
+		}
+		res.Courses = append(res.Courses, courseRes)
+	}
+	for _, child := range group.Groups {
+		res.Groups = append(res.Groups, *newRequisiteGroupRes(&child, taken, unknown))
+	}
+
+	return res
+}
+
+// newCourseRequisitesRes converts a course's requisites, evaluating them against the user's courses unless taken is nil.
+// Prerequisites count completed courses, corequisites and antirequisites also count courses in progress.
+func newCourseRequisitesRes(requisites repository.CourseRequisitesSchema, taken *repository.TakenCoursesSchema) CourseRequisitesRes {
+	if taken == nil {
+		taken = &repository.TakenCoursesSchema{}
+	}
+
+	return CourseRequisitesRes{
+		Prerequisites:  newRequisiteGroupRes(requisites.Prerequisites, taken.Completed, taken.Unknown),
+		Corequisites:   newRequisiteGroupRes(requisites.Corequisites, taken.Taken, nil),
+		Antirequisites: newRequisiteGroupRes(requisites.Antirequisites, taken.Taken, nil),
+	}
+}
+
+// GetCourseRequisites retrieves a course's prerequisites, corequisites and antirequisites.
+// @Summary Get course requisites
+// @Description Requisites are trees of groups, each requiring all or any of its courses and nested groups. Types without requisites are null.
+// @Tags Course
+// @Param courseId path string true "Course ID"
+// @Success 200 {object} CourseRequisitesResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/requisites [get]
+func (c *courseHandler) GetCourseRequisites(w http.ResponseWriter, r *http.Request) {
+	requisites, err := c.requisiteRepo.GetCourseRequisites(r.Context(), chi.URLParam(r, "courseId"))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Course not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newCourseRequisitesRes(requisites, nil))
+}
+
+type UnlockedCourseRes struct {
+	CourseId string `json:"courseId"`
+	Course   string `json:"course"`
+	Title    string `json:"title"`
+	Type     string `json:"type"` // Prerequisite or Corequisite
+} //@name UnlockedCourseResponse
+
+// ListUnlockedCourses lists the courses which require a course.
+// @Summary List courses unlocked by a course
+// @Description Courses listing the course anywhere in their prerequisites or corequisites.
+// @Tags Course
+// @Param courseId path string true "Course ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} UnlockedCourseResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/unlocks [get]
+func (c *courseHandler) ListUnlockedCourses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	courses, err := c.requisiteRepo.ListUnlockedCourses(r.Context(), chi.URLParam(r, "courseId"), util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]UnlockedCourseRes, 0, len(courses))
+	for _, course := range courses {
+		res = append(res, UnlockedCourseRes{
+			CourseId: course.CourseId,
+			Course:   course.Course,
+			Title:    course.Title,
+			Type:     course.Type,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+type CourseEligibilityRes struct {
+	Eligible             bool                 `json:"eligible"`             // Prerequisites are met and the user has no antirequisite
+	Taken                bool                 `json:"taken"`                // The user already has the course
+	PrerequisitesMet     bool                 `json:"prerequisitesMet"`     // Prerequisites must be completed
+	PrerequisitesUnknown bool                 `json:"prerequisitesUnknown"` // Prerequisites are only met counting courses without a term, whose completion is unknown
+	CorequisitesMet      bool                 `json:"corequisitesMet"`      // Corequisites may be in progress or taken alongside the course and do not affect eligibility
+	Antirequisites       []RequisiteCourseRes `json:"antirequisites"`       // Antirequisites the user has completed or is taking
+	Requisites           CourseRequisitesRes  `json:"requisites"`
+} //@name CourseEligibilityResponse
+
+// GetCourseEligibility checks whether the user is eligible to take a course.
+// @Summary Check course eligibility
+// @Description Evaluates the course's requisites against the user's courses. Courses count as completed once their term has ended. Prerequisites met only by courses without a term are reported as unknown rather than met. Antirequisites include courses in progress.
+// @Tags Course
+// @Param courseId path string true "Course ID"
+// @Success 200 {object} CourseEligibilityResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/eligibility [get]
+func (c *courseHandler) GetCourseEligibility(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		c.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	requisites, err := c.requisiteRepo.GetCourseRequisites(r.Context(), courseId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Course not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	taken, err := c.requisiteRepo.ListTakenCourses(r.Context(), session.UserId)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	eligibility := repository.EvaluateEligibility(requisites, taken)
+	res := CourseEligibilityRes{
+		Eligible:             eligibility.Eligible(),
+		Taken:                taken.Taken[courseId],
+		PrerequisitesMet:     eligibility.PrerequisitesMet,
+		PrerequisitesUnknown: eligibility.PrerequisitesUnknown,
+		CorequisitesMet:      eligibility.CorequisitesMet,
+		Antirequisites:       make([]RequisiteCourseRes, 0, len(eligibility.Antirequisites)),
+		Requisites:           newCourseRequisitesRes(requisites, &taken),
+	}
+	for _, course := range eligibility.Antirequisites {
+		courseTaken, courseUnknown := true, false
+		res.Antirequisites = append(res.Antirequisites, RequisiteCourseRes{
+			CourseId: course.CourseId,
+			Course:   course.Course,
+			Title:    course.Title,
+			Taken:    &courseTaken,
+			Unknown:  &courseUnknown,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+type detailsHandler struct {
+	log         logger.Logger
+	detailsRepo repository.DetailsRepository
+}
+
+func NewDetailsHandler(log logger.Logger, details repository.DetailsRepository) *detailsHandler {
+	return &detailsHandler{
+		log:         log,
+		detailsRepo: details,
+	}
+}
+
+type GradeWeightRes struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Percentage of the final grade
+} //@name GradeWeight
+
+type AssessmentRes struct {
+	Name   string  `json:"name"`
+	Date   string  `json:"date,omitempty"`   // YYYY-MM-DD
+	Weight float64 `json:"weight,omitempty"` // Percentage of the final grade
+} //@name Assessment
+
+type TextbookRes struct {
+	Title    string `json:"title"`
+	Isbn     string `json:"isbn,omitempty"`
+	Required bool   `json:"required"`
+} //@name Textbook
+
+type InstructorContactRes struct {
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Phone  string `json:"phone,omitempty"`
+	Office string `json:"office,omitempty"`
+} //@name InstructorContact
+
+type SyllabusDetailsRes struct {
+	SyllabusId    string               `json:"syllabusId"`
+	Revision      int16                `json:"revision"`   // Revision the details were read from
+	Confidence    float32              `json:"confidence"` // From 0 to 1, how complete the parsed details are likely to be
+	Edited        bool                 `json:"edited"`     // Whether the uploader corrected the details
+	GradingScheme []GradeWeightRes     `json:"gradingScheme"`
+	Assessments   []AssessmentRes      `json:"assessments"`
+	Textbooks     []TextbookRes        `json:"textbooks"`
+	Instructor    InstructorContactRes `json:"instructor"`
+	DateModified  int64                `json:"dateModified"`
+} //@name SyllabusDetailsResponse
+
+func newSyllabusDetailsRes(details repository.SyllabusDetailsSchema) SyllabusDetailsRes {
+	res := SyllabusDetailsRes{
+		SyllabusId:    details.SyllabusId,
+		Revision:      details.Revision,
+		Confidence:    details.Confidence,
+		Edited:        details.Edited,
+		GradingScheme: make([]GradeWeightRes, 0, len(details.Details.GradingScheme)),
+		Assessments:   make([]AssessmentRes, 0, len(details.Details.Assessments)),
+		Textbooks:     make([]TextbookRes, 0, len(details.Details.Textbooks)),
+		Instructor:    InstructorContactRes(details.Details.Instructor),
+		DateModified:  details.DateModified.UnixMicro(),
+	}
+	for _, weight := range details.Details.GradingScheme {
+		res.GradingScheme = append(res.GradingScheme, GradeWeightRes(weight))
+	}
+	for _, assessment := range details.Details.Assessments {
+		res.Assessments = append(res.Assessments, AssessmentRes(assessment))
+	}
+	for _, textbook := range details.Details.Textbooks {
+		res.Textbooks = append(res.Textbooks, TextbookRes(textbook))
+	}
+
+	return res
+}
+
+// GetSyllabusDetails gets the grading scheme, assessments, textbooks and instructor contact read from a syllabus.
+// @Summary Get syllabus details
+// @Description Details are parsed from the syllabus text once it is published and may be corrected by the uploader.
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 200 {object} SyllabusDetailsResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/details [get]
+func (d *detailsHandler) GetSyllabusDetails(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		d.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	details, err := d.detailsRepo.GetSyllabusDetails(r.Context(), session.UserId, syllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus details not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newSyllabusDetailsRes(details))
+}
+
+type UpdateSyllabusDetailsReq struct {
+	GradingScheme []GradeWeightRes     `json:"gradingScheme"`
+	Assessments   []AssessmentRes      `json:"assessments"`
+	Textbooks     []TextbookRes        `json:"textbooks"`
+	Instructor    InstructorContactRes `json:"instructor"`
+} //@name UpdateSyllabusDetailsRequest
+
+// UpdateSyllabusDetails replaces a syllabus' details with the uploader's corrections.
+// @Summary Correct syllabus details
+// @Description Only the uploader may edit details. Corrections are kept until a new revision of the syllabus is published.
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Param body body UpdateSyllabusDetailsRequest true "Corrected syllabus details"
+// @Success 200 {object} SyllabusDetailsResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/details [put]
+func (d *detailsHandler) UpdateSyllabusDetails(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		d.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body UpdateSyllabusDetailsReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	details := extractor.SyllabusDetails{
+		GradingScheme: make([]extractor.GradeWeight, 0, len(body.GradingScheme)),
+		Assessments:   make([]extractor.Assessment, 0, len(body.Assessments)),
+		Textbooks:     make([]extractor.Textbook, 0, len(body.Textbooks)),
+		Instructor:    extractor.InstructorContact(body.Instructor),
+	}
+	for _, weight := range body.GradingScheme {
+		details.GradingScheme = append(details.GradingScheme, extractor.GradeWeight(weight))
+	}
+	for _, assessment := range body.Assessments {
+		details.Assessments = append(details.Assessments, extractor.Assessment(assessment))
+	}
+	for _, textbook := range body.Textbooks {
+		details.Textbooks = append(details.Textbooks, extractor.Textbook(textbook))
+	}
+	if err := extractor.ValidateDetails(details); err != nil {
+		http.Error(w, "Invalid syllabus details, "+err.Error()+".", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	updated, err := d.detailsRepo.UpdateSyllabusDetails(r.Context(), session.UserId, syllabusId, details)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "You do not have access to update this syllabus.", http.StatusForbidden)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newSyllabusDetailsRes(updated))
+}
@@ -0,0 +1,461 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxInstructorNameLength = 100
+	maxClaimMessageLength   = 1000
+	maxRemovalReasonLength  = 1000
+)
+
+type instructorHandler struct {
+	log                logger.Logger
+	instructorRepo     repository.InstructorRepository
+	claimRepo          repository.ClaimRepository
+	removalRepo        repository.RemovalRepository
+	syllabusRepo       repository.SyllabusRepository
+	thumbnailPresigner bucket.PresignerClient
+}
+
+func NewInstructorHandler(log logger.Logger, instructor repository.InstructorRepository, claim repository.ClaimRepository, removal repository.RemovalRepository, syllabus repository.SyllabusRepository, thumbnailPresigner bucket.PresignerClient) *instructorHandler {
+	return &instructorHandler{
+		log:                log,
+		instructorRepo:     instructor,
+		claimRepo:          claim,
+		removalRepo:        removal,
+		syllabusRepo:       syllabus,
+		thumbnailPresigner: thumbnailPresigner,
+	}
+}
+
+type InstructorRes struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Verified      bool   `json:"verified"`      // Claimed by a verified faculty account
+	SyllabusCount int    `json:"syllabusCount"` // Published syllabi of the instructor
+	DateAdded     int64  `json:"dateAdded"`
+} //@name InstructorResponse
+
+func newInstructorRes(instructor repository.InstructorSchema) InstructorRes {
+	return InstructorRes{
+		Id:            instructor.Id,
+		Name:          instructor.Name,
+		Verified:      instructor.VerifiedUserId.Valid,
+		SyllabusCount: instructor.SyllabusCount,
+		DateAdded:     instructor.DateAdded.UnixMicro(),
+	}
+}
+
+type InstructorCourseRes struct {
+	CourseId      string `json:"courseId"`
+	Course        string `json:"course"`
+	Title         string `json:"title"`
+	SyllabusCount int    `json:"syllabusCount"`
+} //@name InstructorCourseResponse
+
+type InstructorDetailsRes struct {
+	InstructorRes
+	Courses []InstructorCourseRes `json:"courses"` // Courses the instructor has published syllabi for
+} //@name InstructorDetailsResponse
+
+type InstructorReq struct {
+	Name string `json:"name"`
+} //@name InstructorRequest
+
+type InstructorClaimRes struct {
+	Id           string  `json:"id"`
+	InstructorId string  `json:"instructorId"`
+	Instructor   string  `json:"instructor"`
+	UserId       string  `json:"userId"`
+	UserName     string  `json:"userName"`
+	UserEmail    string  `json:"userEmail"`
+	Message      *string `json:"message"`
+	Status       string  `json:"status"` // Pending, Approved or Rejected
+	ReviewedBy   *string `json:"reviewedBy"`
+	ReviewReason *string `json:"reviewReason"`
+	DateReviewed *int64  `json:"dateReviewed"`
+	DateAdded    int64   `json:"dateAdded"`
+} //@name InstructorClaimResponse
+
+func newInstructorClaimRes(claim repository.InstructorClaimSchema) InstructorClaimRes {
+	res := InstructorClaimRes{
+		Id:           claim.Id,
+		InstructorId: claim.InstructorId,
+		Instructor:   claim.Instructor,
+		UserId:       claim.UserId,
+		UserName:     claim.UserName,
+		UserEmail:    claim.UserEmail,
+		Status:       claim.Status,
+		DateAdded:    claim.DateAdded.UnixMicro(),
+	}
+	if claim.Message.Valid {
+		res.Message = &claim.Message.String
+	}
+	if claim.ReviewedBy.Valid {
+		res.ReviewedBy = &claim.ReviewedBy.String
+	}
+	if claim.ReviewReason.Valid {
+		res.ReviewReason = &claim.ReviewReason.String
+	}
+	if claim.DateReviewed.Valid {
+		dateReviewed := claim.DateReviewed.Time.UnixMicro()
+		res.DateReviewed = &dateReviewed
+	}
+
+	return res
+}
+
+type InstructorClaimReq struct {
+	Message *string `json:"message"`
+} //@name InstructorClaimRequest
+
+type RemovalRequestRes struct {
+	Id           string  `json:"id"`
+	SyllabusId   string  `json:"syllabusId"`
+	UserId       *string `json:"userId"` // Null once the requesting instructor's account is deleted
+	Reason       string  `json:"reason"`
+	Status       string  `json:"status"` // Pending, Approved or Rejected
+	ReviewedBy   *string `json:"reviewedBy"`
+	ReviewReason *string `json:"reviewReason"`
+	DateReviewed *int64  `json:"dateReviewed"`
+	DateAdded    int64   `json:"dateAdded"`
+} //@name RemovalRequestResponse
+
+func newRemovalRequestRes(request repository.RemovalRequestSchema) RemovalRequestRes {
+	res := RemovalRequestRes{
+		Id:         request.Id,
+		SyllabusId: request.SyllabusId,
+		Reason:     request.Reason,
+		Status:     request.Status,
+		DateAdded:  request.DateAdded.UnixMicro(),
+	}
+	if request.UserId.Valid {
+		res.UserId = &request.UserId.String
+	}
+	if request.ReviewedBy.Valid {
+		res.ReviewedBy = &request.ReviewedBy.String
+	}
+	if request.ReviewReason.Valid {
+		res.ReviewReason = &request.ReviewReason.String
+	}
+	if request.DateReviewed.Valid {
+		dateReviewed := request.DateReviewed.Time.UnixMicro()
+		res.DateReviewed = &dateReviewed
+	}
+
+	return res
+}
+
+type RemovalRequestReq struct {
+	Reason string `json:"reason"`
+} //@name RemovalRequestRequest
+
+// ListInstructors lists instructors, suggesting similar names when searching.
+// @Summary List instructors
+// @Description Clients should search by name before adding an instructor, similar names are listed most similar first to avoid duplicates.
+// @Tags Instructor
+// @Param name query string false "Fuzzy match on the instructor's name"
+// @Param courseId query string false "Instructors with published syllabi for the course"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} InstructorResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /instructors [get]
+func (ih *instructorHandler) ListInstructors(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	instructors, err := ih.instructorRepo.ListInstructors(r.Context(), repository.InstructorFilters{
+		Name:     strings.TrimSpace(query.Get("name")),
+		CourseId: query.Get("courseId"),
+	}, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]InstructorRes, 0, len(instructors))
+	for _, instructor := range instructors {
+		res = append(res, newInstructorRes(instructor))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// CreateInstructor adds an instructor.
+// @Summary Add an instructor
+// @Tags Instructor
+// @Accept json
+// @Param body body InstructorRequest true "Name of at most 100 characters"
+// @Success 201 {object} InstructorResponse
+// @Header 201 {string} Location "URL to access the created instructor"
+// @Failure 400 {string} string
+// @Failure 409 {string} string "An instructor with the same name exists"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /instructors [post]
+func (ih *instructorHandler) CreateInstructor(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		ih.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body InstructorReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.Join(strings.Fields(body.Name), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxInstructorNameLength {
+		http.Error(w, "Instructor name must be between 1 and 100 characters.", http.StatusBadRequest)
+		return
+	}
+
+	created, err := ih.instructorRepo.CreateInstructor(r.Context(), session.UserId, name)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid instructor name.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "An instructor with this name already exists.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Location", os.Getenv(config.ServerDomain)+"/instructors/"+created.Id)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newInstructorRes(created))
+}
+
+// GetInstructor returns an instructor with the courses they have syllabi for.
+// @Summary Get an instructor
+// @Tags Instructor
+// @Param instructorId path string true "Instructor ID"
+// @Success 200 {object} InstructorDetailsResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /instructors/{instructorId} [get]
+func (ih *instructorHandler) GetInstructor(w http.ResponseWriter, r *http.Request) {
+	instructorId := chi.URLParam(r, "instructorId")
+	instructor, err := ih.instructorRepo.GetInstructor(r.Context(), instructorId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid instructor ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Instructor not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	courses, err := ih.instructorRepo.ListInstructorCourses(r.Context(), instructor.Id)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	res := InstructorDetailsRes{
+		InstructorRes: newInstructorRes(instructor),
+		Courses:       make([]InstructorCourseRes, 0, len(courses)),
+	}
+	for _, course := range courses {
+		res.Courses = append(res.Courses, InstructorCourseRes{
+			CourseId:      course.CourseId,
+			Course:        course.Course,
+			Title:         course.Title,
+			SyllabusCount: course.SyllabusCount,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ListInstructorSyllabi lists the published syllabi of an instructor.
+// @Summary List an instructor's syllabi
+// @Tags Instructor
+// @Param instructorId path string true "Instructor ID"
+// @Param courseId query string false "Filter by course ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} SyllabusResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /instructors/{instructorId}/syllabi [get]
+func (ih *instructorHandler) ListInstructorSyllabi(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		ih.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	syllabi, err := ih.syllabusRepo.ListSyllabi(r.Context(), session.UserId, repository.SyllabusFilters{
+		InstructorId: chi.URLParam(r, "instructorId"),
+		CourseId:     query.Get("courseId"),
+		SyncedOnly:   true,
+	}, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid instructor or course ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]SyllabusRes, 0, len(syllabi))
+	for _, syllabus := range syllabi {
+		res = append(res, newSyllabusRes(r.Context(), ih.thumbnailPresigner, syllabus))
+	}
+	if err := setSyllabusReactions(r.Context(), ih.syllabusRepo, session.UserId, res); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// CreateInstructorClaim claims an instructor profile for the user's faculty account.
+// @Summary Claim an instructor profile
+// @Description Claims are reviewed by an admin, once approved the user's uploads for the instructor are marked official.
+// @Tags Instructor
+// @Accept json
+// @Param instructorId path string true "Instructor ID"
+// @Param body body InstructorClaimRequest true "Optional message of at most 1000 characters for the reviewing admin"
+// @Success 201 {object} InstructorClaimResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Instructor or user is already verified, or a claim is pending"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /instructors/{instructorId}/claims [post]
+func (ih *instructorHandler) CreateInstructorClaim(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		ih.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body InstructorClaimReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	var message *string
+	if body.Message != nil {
+		trimmed := strings.TrimSpace(*body.Message)
+		if utf8.RuneCountInString(trimmed) > maxClaimMessageLength {
+			http.Error(w, "Message must be at most 1000 characters.", http.StatusBadRequest)
+			return
+		}
+		if trimmed != "" {
+			message = &trimmed
+		}
+	}
+
+	instructorId := chi.URLParam(r, "instructorId")
+	claim, err := ih.claimRepo.CreateInstructorClaim(r.Context(), session.UserId, instructorId, message)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid instructor ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Instructor not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "The instructor or your account is already verified, or your claim is pending review.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newInstructorClaimRes(claim))
+}
+
+// CreateRemovalRequest requests the removal of a copy of the instructor's syllabus uploaded by another user.
+// @Summary Request a syllabus removal
+// @Description Only the verified instructor of a published syllabus may request its removal, requests are reviewed by an admin.
+// @Tags Instructor
+// @Accept json
+// @Param syllabusId path string true "Syllabus ID"
+// @Param body body RemovalRequestRequest true "Reason of at most 1000 characters"
+// @Success 201 {object} RemovalRequestResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string "User is not the verified instructor of the syllabus"
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Syllabus is not published, is the user's own upload or already has a pending request"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/removal-requests [post]
+func (ih *instructorHandler) CreateRemovalRequest(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		ih.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body RemovalRequestReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	reason := strings.TrimSpace(body.Reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxRemovalReasonLength {
+		http.Error(w, "Reason must be between 1 and 1000 characters.", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	request, err := ih.removalRepo.CreateRemovalRequest(r.Context(), session.UserId, syllabusId, reason)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "Only the verified instructor of this syllabus may request its removal.", http.StatusForbidden)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "This syllabus cannot be requested for removal.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newRemovalRequestRes(request))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const previewUrlSecs = 60 * 60
+
+// icons are generic previews of syllabi without a thumbnail, one per kind of document.
+//
+//go:embed icons/*.svg
+var icons embed.FS
+
+// syllabusIcon returns the icon name for a syllabus' content type.
+func syllabusIcon(contentType string) string {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	switch {
+	case mediaType == extractor.ContentTypePdf:
+		return "pdf"
+	case mediaType == extractor.ContentTypeDocx, mediaType == "application/msword",
+		mediaType == "application/vnd.oasis.opendocument.text", mediaType == "application/rtf":
+		return "document"
+	case strings.HasPrefix(mediaType, "text/"):
+		return "text"
+	default:
+		return "file"
+	}
+}
+
+func syllabusIconUrl(contentType string) string {
+	return os.Getenv(config.ServerDomain) + "/icons/" + syllabusIcon(contentType) + ".svg"
+}
+
+// hasThumbnail reports whether the thumbnail lambda has rendered the syllabus' current file.
+func hasThumbnail(syllabus repository.SyllabusSchema) bool {
+	return syllabus.Status == repository.SyllabusPublished && syllabus.HasThumbnail()
+}
+
+// thumbnailObjectKey returns the thumbnail bucket key of a syllabus file's thumbnail.
+func thumbnailObjectKey(objectKey string) string {
+	return objectKey + ".jpg"
+}
+
+// previewPagesPrefix returns the thumbnail bucket prefix of a syllabus file's page images, numbered from 1.
+func previewPagesPrefix(objectKey string) string {
+	return objectKey + "/pages/"
+}
+
+// recordThumbnail records the thumbnail of a syllabus revision if the thumbnail lambda has rendered it.
+// Only PDFs are rendered, other files never have a thumbnail to find.
+func (s *syllabusHandler) recordThumbnail(ctx context.Context, syllabusId string, revision int16, objectKey string) bool {
+	if _, err := s.thumbnailObjects.HeadObject(ctx, thumbnailObjectKey(objectKey)); err != nil {
+		if !errors.Is(err, util.ErrNotFound) {
+			s.log.Warn(fmt.Sprintf("failed to check thumbnail of syllabus %s", syllabusId))
+		}
+		return false
+	}
+
+	if err := s.syllabusRepo.RecordSyllabusThumbnail(ctx, syllabusId, revision); err != nil {
+		s.log.Warn(fmt.Sprintf("failed to record thumbnail of syllabus %s", syllabusId))
+		return false
+	}
+
+	return true
+}
+
+// syllabusThumbnailUrl presigns the syllabus thumbnail, returning nil if it has none.
+func syllabusThumbnailUrl(ctx context.Context, thumbnails bucket.PresignerClient, syllabus repository.SyllabusSchema) *string {
+	if !hasThumbnail(syllabus) {
+		return nil
+	}
+
+	signedUrl, err := thumbnails.GetObject(ctx, thumbnailObjectKey(syllabus.ObjectKey()), previewUrlSecs)
+	if err != nil {
+		return nil
+	}
+
+	return &signedUrl
+}
+
+type SyllabusPreviewRes struct {
+	ThumbnailUrl *string  `json:"thumbnailUrl"`
+	IconUrl      string   `json:"iconUrl"`
+	Pages        []string `json:"pages"` // Presigned URLs of the first pages' images, in page order
+} //@name SyllabusPreviewResponse
+
+// GetSyllabusPreview gets image URLs of the first pages of a syllabus.
+// @Summary Get a syllabus preview
+// @Description Pages are only rendered for PDFs, other syllabi have no pages and should show the icon.
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 200 {object} SyllabusPreviewResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/preview [get]
+func (s *syllabusHandler) GetSyllabusPreview(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	syllabus, err := s.syllabusRepo.GetSyllabus(r.Context(), session.UserId, syllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Thumbnails rendered after the upload was synced are recorded on the first preview
+	if syllabus.Status == repository.SyllabusPublished && !syllabus.HasThumbnail() && syllabusIcon(syllabus.ContentType) == "pdf" &&
+		s.recordThumbnail(r.Context(), syllabus.Id, syllabus.Revision, syllabus.ObjectKey()) {
+		syllabus.ThumbnailRevision = sql.NullInt16{Int16: syllabus.Revision, Valid: true}
+	}
+
+	res := SyllabusPreviewRes{
+		ThumbnailUrl: syllabusThumbnailUrl(r.Context(), s.thumbnailPresigner, syllabus),
+		IconUrl:      syllabusIconUrl(syllabus.ContentType),
+		Pages:        []string{},
+	}
+
+	if hasThumbnail(syllabus) {
+		prefix := previewPagesPrefix(syllabus.ObjectKey())
+		keys, err := s.thumbnailObjects.ListObjectKeys(r.Context(), prefix)
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+
+		// Keys are listed in lexical order, so page 10 would sort before page 2
+		pageNumber := func(key string) int {
+			number, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(key, prefix), ".jpg"))
+			return number
+		}
+		slices.SortFunc(keys, func(a, b string) int {
+			return pageNumber(a) - pageNumber(b)
+		})
+
+		for _, key := range keys {
+			signedUrl, err := s.thumbnailPresigner.GetObject(r.Context(), key, previewUrlSecs)
+			if err != nil {
+				http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+				return
+			}
+			res.Pages = append(res.Pages, signedUrl)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetSyllabusIcon serves the generic preview icons of syllabi without a thumbnail.
+func (s *syllabusHandler) GetSyllabusIcon(w http.ResponseWriter, r *http.Request) {
+	icon, err := icons.ReadFile(path.Join("icons", path.Base(chi.URLParam(r, "icon"))))
+	if err != nil {
+		http.Error(w, "Icon not found.", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	w.Write(icon)
+}
@@ -2,23 +2,27 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/JackieLi565/syllabye/internal/config"
 	"github.com/JackieLi565/syllabye/internal/repository"
 	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
 	"github.com/go-chi/chi/v5"
 )
 
 type programHandler struct {
-	log         logger.Logger
-	programRepo repository.ProgramRepository
+	log             logger.Logger
+	programRepo     repository.ProgramRepository
+	requirementRepo repository.RequirementRepository
 }
 
-func NewProgramHandler(log logger.Logger, program repository.ProgramRepository) *programHandler {
+func NewProgramHandler(log logger.Logger, program repository.ProgramRepository, requirement repository.RequirementRepository) *programHandler {
 	return &programHandler{
-		log:         log,
-		programRepo: program,
+		log:             log,
+		programRepo:     program,
+		requirementRepo: requirement,
 	}
 }
 
@@ -95,3 +99,84 @@ func (p *programHandler) ListPrograms(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(programRes)
 }
+
+type RequirementCourseRes struct {
+	CourseId string  `json:"courseId"`
+	Course   string  `json:"course"`
+	Title    string  `json:"title"`
+	Credits  float64 `json:"credits"`
+} //@name RequirementCourseResponse
+
+type ProgramRequirementRes struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description"`
+	Type        string                 `json:"type"`    // Required, Elective or Credits
+	Credits     *float64               `json:"credits"` // Credits needed, null for Required requirements which need every course
+	Courses     []RequirementCourseRes `json:"courses"`
+	CategoryIds []string               `json:"categoryIds"` // Elective and Credits requirements count courses of the categories
+} //@name ProgramRequirementResponse
+
+func newRequirementCourseRes(course repository.RequirementCourseSchema) RequirementCourseRes {
+	return RequirementCourseRes{
+		CourseId: course.CourseId,
+		Course:   course.Course,
+		Title:    course.Title,
+		Credits:  course.Credits,
+	}
+}
+
+func newProgramRequirementRes(requirement repository.ProgramRequirementSchema) ProgramRequirementRes {
+	res := ProgramRequirementRes{
+		Id:          requirement.Id,
+		Name:        requirement.Name,
+		Type:        requirement.Type,
+		Courses:     make([]RequirementCourseRes, 0, len(requirement.Courses)),
+		CategoryIds: requirement.CategoryIds,
+	}
+	if requirement.Description.Valid {
+		res.Description = &requirement.Description.String
+	}
+	if requirement.Credits.Valid {
+		res.Credits = &requirement.Credits.Float64
+	}
+	for _, course := range requirement.Courses {
+		res.Courses = append(res.Courses, newRequirementCourseRes(course))
+	}
+
+	return res
+}
+
+// ListProgramRequirements lists a program's requirements.
+// @Summary List program requirements
+// @Description Required requirements need every listed course. Elective requirements need credits from the listed courses and categories,
+// @Description Credits requirements count credits from any course when no course or category is listed.
+// @Tags Program
+// @Param programId path string true "Program ID"
+// @Success 200 {array} ProgramRequirementResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /programs/{programId}/requirements [get]
+func (p *programHandler) ListProgramRequirements(w http.ResponseWriter, r *http.Request) {
+	requirements, err := p.requirementRepo.ListProgramRequirements(r.Context(), chi.URLParam(r, "programId"))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid program ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Program not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]ProgramRequirementRes, 0, len(requirements))
+	for _, requirement := range requirements {
+		res = append(res, newProgramRequirementRes(requirement))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
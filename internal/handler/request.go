@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+type requestHandler struct {
+	log         logger.Logger
+	requestRepo repository.SyllabusRequestRepository
+}
+
+func NewRequestHandler(log logger.Logger, request repository.SyllabusRequestRepository) *requestHandler {
+	return &requestHandler{
+		log:         log,
+		requestRepo: request,
+	}
+}
+
+type SyllabusRequestRes struct {
+	Id            string  `json:"id"`
+	CourseId      string  `json:"courseId"`
+	Course        string  `json:"course"`
+	Year          *int16  `json:"year"` // Null for requests of any term
+	Semester      *string `json:"semester"`
+	Status        string  `json:"status"`
+	SyllabusId    *string `json:"syllabusId"`
+	Votes         int     `json:"votes"`
+	Voted         bool    `json:"voted"`
+	DateFulfilled *int64  `json:"dateFulfilled"`
+	DateAdded     int64   `json:"dateAdded"`
+} //@name SyllabusRequestResponse
+
+func newSyllabusRequestRes(request repository.SyllabusRequestSchema) SyllabusRequestRes {
+	res := SyllabusRequestRes{
+		Id:        request.Id,
+		CourseId:  request.CourseId,
+		Course:    request.Course,
+		Status:    request.Status,
+		Votes:     request.Votes,
+		Voted:     request.Voted,
+		DateAdded: request.DateAdded.UnixMicro(),
+	}
+	if request.Year.Valid {
+		res.Year = &request.Year.Int16
+	}
+	if request.Semester.Valid {
+		res.Semester = &request.Semester.String
+	}
+	if request.SyllabusId.Valid {
+		res.SyllabusId = &request.SyllabusId.String
+	}
+	if request.DateFulfilled.Valid {
+		dateFulfilled := request.DateFulfilled.Time.UnixMicro()
+		res.DateFulfilled = &dateFulfilled
+	}
+
+	return res
+}
+
+type SyllabusRequestReq struct {
+	Year     *int16  `json:"year"`
+	Semester *string `json:"semester"`
+} //@name SyllabusRequestRequest
+
+// ListSyllabusRequests lists requested syllabi by demand.
+// @Summary List syllabus requests
+// @Description Requests with the most votes first. Only open requests are listed unless another status is given.
+// @Tags Request
+// @Param courseId query string false "Course ID"
+// @Param status query string false "Request status (default: Open)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} SyllabusRequestResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /requests [get]
+func (rh *requestHandler) ListSyllabusRequests(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	filters := repository.SyllabusRequestFilters{
+		CourseId: query.Get("courseId"),
+		Status:   query.Get("status"),
+	}
+	if filters.Status == "" {
+		filters.Status = repository.SyllabusRequestOpen
+	}
+
+	requests, err := rh.requestRepo.ListSyllabusRequests(r.Context(), session.UserId, filters, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID or status value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]SyllabusRequestRes, 0, len(requests))
+	for _, request := range requests {
+		res = append(res, newSyllabusRequestRes(request))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// CreateSyllabusRequest requests a syllabus for a course.
+// @Summary Request a syllabus
+// @Description Requests a syllabus for a course, optionally for a term. Requesting a syllabus which is already requested votes for the existing request instead.
+// @Description Users who voted for a request are emailed once a matching syllabus is published.
+// @Tags Request
+// @Accept json
+// @Param courseId path string true "Course ID"
+// @Param body body SyllabusRequestRequest true "Year and semester, both omitted to request any term"
+// @Success 201 {object} SyllabusRequestResponse "Request created"
+// @Success 200 {object} SyllabusRequestResponse "Existing request voted for"
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Syllabus is already available"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/requests [post]
+func (rh *requestHandler) CreateSyllabusRequest(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body SyllabusRequestReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if (body.Year == nil) != (body.Semester == nil) || (body.Year != nil && *body.Year <= 0) {
+		http.Error(w, "Year and semester must be given together.", http.StatusBadRequest)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	request, created, err := rh.requestRepo.CreateSyllabusRequest(r.Context(), session.UserId, courseId, repository.InsertSyllabusRequest{
+		Year:     body.Year,
+		Semester: body.Semester,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Course not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "A syllabus for this course is already available.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(newSyllabusRequestRes(request))
+}
+
+// VoteSyllabusRequest votes for an open syllabus request.
+// @Summary Vote for a syllabus request
+// @Tags Request
+// @Param requestId path string true "Request ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Request is already fulfilled"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /requests/{requestId}/vote [put]
+func (rh *requestHandler) VoteSyllabusRequest(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	requestId := chi.URLParam(r, "requestId")
+	if err := rh.requestRepo.VoteSyllabusRequest(r.Context(), session.UserId, requestId); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid request ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Request not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Request is already fulfilled.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveSyllabusRequestVote removes the user's vote for a syllabus request.
+// @Summary Remove a syllabus request vote
+// @Tags Request
+// @Param requestId path string true "Request ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /requests/{requestId}/vote [delete]
+func (rh *requestHandler) RemoveSyllabusRequestVote(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	requestId := chi.URLParam(r, "requestId")
+	if err := rh.requestRepo.RemoveSyllabusRequestVote(r.Context(), session.UserId, requestId); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid request ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Vote not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const maxReviewLength = 5000
+
+type reviewHandler struct {
+	log        logger.Logger
+	reviewRepo repository.ReviewRepository
+}
+
+func NewReviewHandler(log logger.Logger, review repository.ReviewRepository) *reviewHandler {
+	return &reviewHandler{
+		log:        log,
+		reviewRepo: review,
+	}
+}
+
+type CourseReviewRes struct {
+	Id           string  `json:"id"`
+	CourseId     string  `json:"courseId"`
+	Difficulty   int16   `json:"difficulty"`
+	Workload     int16   `json:"workload"`
+	Usefulness   int16   `json:"usefulness"`
+	Year         int16   `json:"year"`
+	Semester     string  `json:"semester"`
+	Content      *string `json:"content"`
+	IsAuthor     bool    `json:"isAuthor"` // Reviews are anonymous, authors can only identify their own
+	DateAdded    int64   `json:"dateAdded"`
+	DateModified int64   `json:"dateModified"`
+} //@name CourseReviewResponse
+
+func newCourseReviewRes(userId string, review repository.CourseReviewSchema) CourseReviewRes {
+	res := CourseReviewRes{
+		Id:           review.Id,
+		CourseId:     review.CourseId,
+		Difficulty:   review.Difficulty,
+		Workload:     review.Workload,
+		Usefulness:   review.Usefulness,
+		Year:         review.Year,
+		Semester:     review.Semester,
+		IsAuthor:     review.UserId == userId,
+		DateAdded:    review.DateAdded.UnixMicro(),
+		DateModified: review.DateModified.UnixMicro(),
+	}
+	if review.Content.Valid {
+		res.Content = &review.Content.String
+	}
+
+	return res
+}
+
+type CourseReviewReq struct {
+	Difficulty int16   `json:"difficulty"`
+	Workload   int16   `json:"workload"`
+	Usefulness int16   `json:"usefulness"`
+	Year       int16   `json:"year"`
+	Semester   string  `json:"semester"`
+	Content    *string `json:"content"`
+} //@name CourseReviewRequest
+
+// parseCourseReview validates a review request, reporting whether its ratings, term and text are valid.
+func parseCourseReview(body CourseReviewReq) (repository.InsertCourseReview, bool) {
+	for _, rating := range []int16{body.Difficulty, body.Workload, body.Usefulness} {
+		if rating < 1 || rating > 5 {
+			return repository.InsertCourseReview{}, false
+		}
+	}
+	if body.Year <= 0 || body.Semester == "" {
+		return repository.InsertCourseReview{}, false
+	}
+
+	review := repository.InsertCourseReview{
+		Difficulty: body.Difficulty,
+		Workload:   body.Workload,
+		Usefulness: body.Usefulness,
+		Year:       body.Year,
+		Semester:   body.Semester,
+	}
+	if body.Content != nil {
+		content := strings.TrimSpace(*body.Content)
+		if utf8.RuneCountInString(content) > maxReviewLength {
+			return repository.InsertCourseReview{}, false
+		}
+		if content != "" {
+			review.Content = &content
+		}
+	}
+
+	return review, true
+}
+
+// ListCourseReviews lists the reviews of a course.
+// @Summary List course reviews
+// @Description Most recent first, aggregate ratings are included in the course.
+// @Tags Course
+// @Param courseId path string true "Course ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} CourseReviewResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/reviews [get]
+func (rh *reviewHandler) ListCourseReviews(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	courseId := chi.URLParam(r, "courseId")
+	reviews, err := rh.reviewRepo.ListCourseReviews(r.Context(), courseId, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course ID value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]CourseReviewRes, 0, len(reviews))
+	for _, review := range reviews {
+		res = append(res, newCourseReviewRes(session.UserId, review))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// CreateCourseReview reviews a course the user has recorded taking.
+// @Summary Review a course
+// @Description Users may review each course in their course history once.
+// @Tags Course
+// @Accept json
+// @Param courseId path string true "Course ID"
+// @Param body body CourseReviewRequest true "Ratings from 1 to 5, the term the course was taken and optional text of at most 5000 characters"
+// @Success 201 {object} CourseReviewResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string "Course is not in the user's course history"
+// @Failure 409 {string} string "Course is already reviewed"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/reviews [post]
+func (rh *reviewHandler) CreateCourseReview(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body CourseReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	review, ok := parseCourseReview(body)
+	if !ok {
+		http.Error(w, "Invalid or missing request body fields.", http.StatusBadRequest)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	created, err := rh.reviewRepo.CreateCourseReview(r.Context(), session.UserId, courseId, review)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "Add the course to your course history to review it.", http.StatusForbidden)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "You have already reviewed this course.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCourseReviewRes(session.UserId, created))
+}
+
+// UpdateCourseReview replaces the user's review of a course.
+// @Summary Update a course review
+// @Tags Course
+// @Accept json
+// @Param courseId path string true "Course ID"
+// @Param reviewId path string true "Review ID"
+// @Param body body CourseReviewRequest true "Ratings from 1 to 5, the term the course was taken and optional text of at most 5000 characters"
+// @Success 200 {object} CourseReviewResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/reviews/{reviewId} [put]
+func (rh *reviewHandler) UpdateCourseReview(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body CourseReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	review, ok := parseCourseReview(body)
+	if !ok {
+		http.Error(w, "Invalid or missing request body fields.", http.StatusBadRequest)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	reviewId := chi.URLParam(r, "reviewId")
+	updated, err := rh.reviewRepo.UpdateCourseReview(r.Context(), session.UserId, courseId, reviewId, review)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Review not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newCourseReviewRes(session.UserId, updated))
+}
+
+// DeleteCourseReview deletes the user's review of a course.
+// @Summary Delete a course review
+// @Tags Course
+// @Param courseId path string true "Course ID"
+// @Param reviewId path string true "Review ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /courses/{courseId}/reviews/{reviewId} [delete]
+func (rh *reviewHandler) DeleteCourseReview(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		rh.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	courseId := chi.URLParam(r, "courseId")
+	reviewId := chi.URLParam(r, "reviewId")
+	if err := rh.reviewRepo.DeleteCourseReview(r.Context(), session.UserId, courseId, reviewId); err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid course or review ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Review not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
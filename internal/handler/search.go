@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const maxSearchQueryLength = 200
+
+// snippetMarker marks up search matches once a snippet is HTML escaped.
+var snippetMarker = strings.NewReplacer(repository.SnippetStartSel, "<mark>", repository.SnippetStopSel, "</mark>")
+
+type searchHandler struct {
+	log          logger.Logger
+	searchRepo   repository.SearchRepository
+	syllabusRepo repository.SyllabusRepository
+	detailsRepo  repository.DetailsRepository
+	objects      bucket.ObjectClient
+	// Thumbnails rendered by the thumbnail lambda
+	thumbnailPresigner bucket.PresignerClient
+	extractor          extractor.TextExtractor
+}
+
+func NewSearchHandler(log logger.Logger, search repository.SearchRepository, syllabus repository.SyllabusRepository, details repository.DetailsRepository, objects bucket.ObjectClient, thumbnailPresigner bucket.PresignerClient, extractor extractor.TextExtractor) *searchHandler {
+	return &searchHandler{
+		log:                log,
+		searchRepo:         search,
+		syllabusRepo:       syllabus,
+		detailsRepo:        details,
+		objects:            objects,
+		thumbnailPresigner: thumbnailPresigner,
+		extractor:          extractor,
+	}
+}
+
+type SyllabusSearchRes struct {
+	Syllabus SyllabusRes `json:"syllabus"`
+	Snippet  string      `json:"snippet"` // HTML escaped matching excerpts, matches are wrapped in <mark> tags
+} //@name SyllabusSearchResponse
+
+// SearchSyllabi searches the text of published syllabi.
+// @Summary Search syllabi
+// @Description Supports web search syntax, such as "quoted phrases", or and -excluded words.
+// @Tags Syllabus
+// @Param q query string true "Search query"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} SyllabusSearchResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/search [get]
+func (s *searchHandler) SearchSyllabi(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" || len(q) > maxSearchQueryLength {
+		http.Error(w, "A search query of at most 200 characters is required.", http.StatusBadRequest)
+		return
+	}
+
+	matches, err := s.searchRepo.SearchSyllabi(r.Context(), q, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabi := make([]SyllabusRes, 0, len(matches))
+	for _, match := range matches {
+		syllabi = append(syllabi, newSyllabusRes(r.Context(), s.thumbnailPresigner, match.Syllabus))
+	}
+	if err := setSyllabusReactions(r.Context(), s.syllabusRepo, session.UserId, syllabi); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]SyllabusSearchRes, 0, len(matches))
+	for i, match := range matches {
+		res = append(res, SyllabusSearchRes{
+			Syllabus: syllabi[i],
+			Snippet:  snippetMarker.Replace(html.EscapeString(match.Snippet)),
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// ExtractSyllabusText indexes the text of a syllabus' current file for search and parses its details, queued once the file is published.
+func (s *searchHandler) ExtractSyllabusText(w http.ResponseWriter, r *http.Request) {
+	syllabusId := chi.URLParam(r, "syllabusId")
+
+	syllabus, err := s.searchRepo.GetSyllabusSource(r.Context(), syllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+		} else if errors.Is(err, util.ErrMalformed) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if syllabus.Status != repository.SyllabusPublished {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	body, _, err := s.objects.GetObject(r.Context(), syllabus.ObjectKey())
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	defer body.Close()
+
+	text, err := s.extractor.Extract(r.Context(), syllabus.ContentType, body)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			// Unsupported documents are simply not searchable, their empty text keeps them from being queued again
+			s.log.Info(fmt.Sprintf("syllabus %s text could not be extracted", syllabusId))
+			if err := s.searchRepo.SaveSyllabusText(r.Context(), syllabus.Id, syllabus.Revision, ""); err != nil && !errors.Is(err, util.ErrNotFound) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := s.searchRepo.SaveSyllabusText(r.Context(), syllabus.Id, syllabus.Revision, text); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Dates in the text rarely include the year, the syllabus' academic year is used
+	details, confidence := extractor.ParseDetails(text, int(syllabus.Year))
+	if err := s.detailsRepo.SaveParsedSyllabusDetails(r.Context(), syllabus.Id, syllabus.Revision, details, confidence); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
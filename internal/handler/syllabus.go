@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
@@ -23,42 +28,129 @@ import (
 type syllabusHandler struct {
 	log          logger.Logger
 	syllabusRepo repository.SyllabusRepository
+	uploadRepo   repository.UploadRepository
+	requestRepo  repository.SyllabusRequestRepository
 	presigner    bucket.PresignerClient
-	jwt          *authorizer.JwtAuthorizer
-	queue        queue.WebhookQueue
-	emailer      emailer.NoReplyEmailer
+	objects      bucket.ObjectClient
+	// Thumbnails and page images rendered by the thumbnail lambda
+	thumbnailPresigner bucket.PresignerClient
+	thumbnailObjects   bucket.ObjectClient
+	jwt                *authorizer.JwtAuthorizer
+	queue              queue.WebhookQueue
+	emailer            emailer.NoReplyEmailer
 }
 
-func NewSyllabusHandler(log logger.Logger, syllabus repository.SyllabusRepository, presigner bucket.PresignerClient, jwt *authorizer.JwtAuthorizer, queue queue.WebhookQueue, emailer emailer.NoReplyEmailer) *syllabusHandler {
+func NewSyllabusHandler(log logger.Logger, syllabus repository.SyllabusRepository, upload repository.UploadRepository, request repository.SyllabusRequestRepository, presigner bucket.PresignerClient, objects bucket.ObjectClient, thumbnailPresigner bucket.PresignerClient, thumbnailObjects bucket.ObjectClient, jwt *authorizer.JwtAuthorizer, queue queue.WebhookQueue, emailer emailer.NoReplyEmailer) *syllabusHandler {
 	return &syllabusHandler{
-		log:          log,
-		syllabusRepo: syllabus,
-		presigner:    presigner,
-		jwt:          jwt,
-		queue:        queue,
-		emailer:      emailer,
+		log:                log,
+		syllabusRepo:       syllabus,
+		uploadRepo:         upload,
+		requestRepo:        request,
+		presigner:          presigner,
+		objects:            objects,
+		thumbnailPresigner: thumbnailPresigner,
+		thumbnailObjects:   thumbnailObjects,
+		jwt:                jwt,
+		queue:              queue,
+		emailer:            emailer,
 	}
 }
 
 type SyllabusRes struct {
-	Id          string `json:"id"`
-	UserId      string `json:"userId"`
-	CourseId    string `json:"courseId"`
-	File        string `json:"fileName"`
-	FileSize    int    `json:"fileSize"`
-	ContentType string `json:"contentType"`
-	Year        int16  `json:"year"`
-	Semester    string `json:"semester"`
-	DateAdded   int64  `json:"dateAdded"`
-	Received    bool   `json:"received"`
+	Id                string  `json:"id"`
+	UserId            string  `json:"userId"`
+	CourseId          string  `json:"courseId"`
+	File              string  `json:"fileName"`
+	FileSize          int     `json:"fileSize"`
+	ContentType       string  `json:"contentType"`
+	Year              int16   `json:"year"`
+	Semester          string  `json:"semester"`
+	TermId            string  `json:"termId"`
+	InstructorId      *string `json:"instructorId"`
+	Official          bool    `json:"official"` // Uploaded by the syllabus' verified instructor
+	DateAdded         int64   `json:"dateAdded"`
+	Received          bool    `json:"received"`
+	Status            string  `json:"status"` // Uploading, Verifying, Published, Rejected, Expired or Removed
+	StatusReason      *string `json:"statusReason"`
+	DateStatusChanged int64   `json:"dateStatusChanged"`
+	ThumbnailUrl      *string `json:"thumbnailUrl"` // Presigned URL of the first page's image, only PDFs have thumbnails
+	IconUrl           string  `json:"iconUrl"`      // Generic icon for the content type, shown without a thumbnail
+	Likes             int     `json:"likes"`
+	Dislikes          int     `json:"dislikes"`
+	Views             int     `json:"views"`    // Unique users who viewed the syllabus
+	Reaction          *string `json:"reaction"` // The caller's reaction, Like or Dislike
 } //@name SyllabusResponse
 
-// GetSyllabus retrieves a specific syllabus by ID and returns a signed URL in the header.
+const (
+	reactionLike    = "Like"
+	reactionDislike = "Dislike"
+)
+
+func newSyllabusRes(ctx context.Context, thumbnails bucket.PresignerClient, syllabus repository.SyllabusSchema) SyllabusRes {
+	res := SyllabusRes{
+		Id:                syllabus.Id,
+		UserId:            syllabus.UserId,
+		CourseId:          syllabus.CourseId,
+		File:              syllabus.File,
+		FileSize:          syllabus.FileSize,
+		ContentType:       syllabus.ContentType,
+		Year:              syllabus.Year,
+		Semester:          syllabus.Semester,
+		TermId:            syllabus.TermId,
+		DateAdded:         syllabus.DateAdded.UnixMicro(),
+		Received:          syllabus.DateSynced.Valid,
+		Status:            syllabus.Status,
+		DateStatusChanged: syllabus.DateStatusChanged().UnixMicro(),
+		ThumbnailUrl:      syllabusThumbnailUrl(ctx, thumbnails, syllabus),
+		IconUrl:           syllabusIconUrl(syllabus.ContentType),
+		Likes:             syllabus.LikeCount,
+		Dislikes:          syllabus.DislikeCount,
+		Views:             syllabus.ViewCount,
+		Official:          syllabus.IsOfficial,
+	}
+	if syllabus.StatusReason.Valid {
+		res.StatusReason = &syllabus.StatusReason.String
+	}
+	if syllabus.InstructorId.Valid {
+		res.InstructorId = &syllabus.InstructorId.String
+	}
+
+	return res
+}
+
+// setSyllabusReactions sets the user's reaction on each syllabus, read with a single query for the whole page.
+func setSyllabusReactions(ctx context.Context, syllabusRepo repository.SyllabusRepository, userId string, syllabi []SyllabusRes) error {
+	syllabusIds := make([]string, 0, len(syllabi))
+	for _, syllabus := range syllabi {
+		syllabusIds = append(syllabusIds, syllabus.Id)
+	}
+
+	reactions, err := syllabusRepo.ListUserReactions(ctx, userId, syllabusIds)
+	if err != nil {
+		return err
+	}
+
+	for i := range syllabi {
+		isDislike, ok := reactions[syllabi[i].Id]
+		if !ok {
+			continue
+		}
+
+		reaction := reactionLike
+		if isDislike {
+			reaction = reactionDislike
+		}
+		syllabi[i].Reaction = &reaction
+	}
+
+	return nil
+}
+
+// GetSyllabus retrieves a specific syllabus by ID, the file is downloaded separately.
 // @Summary Get a syllabus
 // @Tags Syllabus
 // @Param syllabusId path string true "Syllabus ID"
 // @Success 200 {object} SyllabusResponse
-// @Header 200 {string} X-Presigned-Url "Presigned URL to access the syllabus file"
 // @Failure 400 {string} string
 // @Failure 404 {string} string
 // @Failure 500 {string} string
@@ -85,26 +177,64 @@ func (s *syllabusHandler) GetSyllabus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	signedUrl, err := s.presigner.GetObject(r.Context(), syllabus.Id, 60*60)
-	if err != nil {
+	res := []SyllabusRes{newSyllabusRes(r.Context(), s.thumbnailPresigner, syllabus)}
+	if err := setSyllabusReactions(r.Context(), s.syllabusRepo, sessionValue.UserId, res); err != nil {
 		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Add("X-Presigned-Url", signedUrl)
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(SyllabusRes{
-		Id:          syllabus.Id,
-		UserId:      syllabus.UserId,
-		CourseId:    syllabus.CourseId,
-		File:        syllabus.File,
-		FileSize:    syllabus.FileSize,
-		ContentType: syllabus.ContentType,
-		Year:        syllabus.Year,
-		Semester:    syllabus.Semester,
-		DateAdded:   syllabus.DateAdded.UnixMicro(),
-		Received:    syllabus.DateSynced.Valid,
-	})
+	json.NewEncoder(w).Encode(res[0])
+}
+
+// downloadUrlSecs is short since the URL is only followed once, right after the redirect.
+const downloadUrlSecs = 60
+
+// DownloadSyllabus redirects to the syllabus file, saved under its original file name.
+// @Summary Download a syllabus
+// @Description Downloads are rate limited per user, a 429 response has a Retry-After header in seconds.
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 302 {string} string
+// @Header 302 {string} Location "Short-lived presigned URL of the syllabus file"
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 429 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/download [get]
+func (s *syllabusHandler) DownloadSyllabus(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	syllabus, err := s.syllabusRepo.DownloadSyllabus(r.Context(), session.UserId, syllabusId)
+	if err != nil {
+		var limitErr *repository.DownloadLimitError
+		if errors.As(err, &limitErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+			http.Error(w, "Download limit reached, try again later.", http.StatusTooManyRequests)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	signedUrl, err := s.presigner.GetObjectDownload(r.Context(), syllabus.ObjectKey(), syllabus.File, downloadUrlSecs)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, signedUrl, http.StatusFound)
 }
 
 type AddSyllabusReq struct {
@@ -113,8 +243,13 @@ type AddSyllabusReq struct {
 	FileSize    int    `json:"fileSize" validate:"required"`
 	ContentType string `json:"contentType" validate:"required"`
 	Checksum    string `json:"checksum" validate:"required"`
-	Year        int16  `json:"year" validate:"required"`
-	Semester    string `json:"semester" validate:"required"`
+	// Year and semester are required unless a term is given, they must match an existing term
+	Year     int16  `json:"year"`
+	Semester string `json:"semester"`
+	// Takes precedence over year and semester, see GET /terms for existing terms
+	TermId string `json:"termId"`
+	// Optional, see GET /instructors for existing instructors
+	InstructorId string `json:"instructorId"`
 } //@name CreateSyllabusRequest
 
 // CreateSyllabus creates a new syllabus and returns a presigned upload URL in the response header.
@@ -126,6 +261,8 @@ type AddSyllabusReq struct {
 // @Header 201 {string} X-Presigned-Url "Presigned URL to upload the syllabus file"
 // @Header 201 {string} Location "URL to access the created syllabus"
 // @Failure 400 {string} string
+// @Failure 404 {string} string "Course or instructor not found"
+// @Failure 409 {string} string "An identical syllabus exists for the course and term, linked by the Location header"
 // @Failure 500 {string} string
 // @Security Session
 // @Router /syllabi [post]
@@ -143,18 +280,32 @@ func (s *syllabusHandler) CreateSyllabus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if body.TermId == "" && (body.Year <= 0 || body.Semester == "") {
+		http.Error(w, "A term or year and semester are required.", http.StatusBadRequest)
+		return
+	}
+
 	syllabusId, err := s.syllabusRepo.CreateSyllabus(r.Context(), repository.InsertSyllabus{
-		UserId:      session.UserId,
-		CourseId:    body.CourseId,
-		File:        body.File,
-		FileSize:    body.FileSize,
-		ContentType: body.ContentType,
-		Year:        body.Year,
-		Semester:    body.Semester,
+		UserId:       session.UserId,
+		CourseId:     body.CourseId,
+		File:         body.File,
+		FileSize:     body.FileSize,
+		ContentType:  body.ContentType,
+		Checksum:     body.Checksum,
+		Year:         body.Year,
+		Semester:     body.Semester,
+		TermId:       body.TermId,
+		InstructorId: body.InstructorId,
 	})
 	if err != nil {
-		if errors.Is(err, util.ErrMalformed) {
-			http.Error(w, "Invalid body parameter.", http.StatusBadRequest)
+		var duplicate *repository.DuplicateSyllabusError
+		if errors.As(err, &duplicate) {
+			setDuplicateLocation(w, duplicate)
+			http.Error(w, duplicateUploadReason, http.StatusConflict)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid body parameter, or no term exists for the year and semester.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Course, term or instructor not found.", http.StatusNotFound)
 		} else {
 			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
 		}
@@ -167,32 +318,284 @@ func (s *syllabusHandler) CreateSyllabus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var delaySeconds int32
-	if os.Getenv(config.ENV) == "development" {
-		delaySeconds = 10
-	} else {
-		delaySeconds = 60 * 5 // 5 minutes
-	}
-
 	// Clean up syllabus API
-	token, err := s.jwt.EncodeJwt(nil)
-	if err != nil {
+	if err := s.queueVerification(r, "/syllabi/"+syllabusId+"/verify"); err != nil {
 		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
 	}
-	requestId, _ := r.Context().Value(config.RequestIdKey).(string)
-	s.queue.SendMessage(r.Context(), queue.WebhookMessage{
-		RequestId: requestId,
-		Url:       os.Getenv(config.ServerDomain) + "/syllabi/" + syllabusId + "/verify",
-		Headers: map[string]string{
-			"Authorization": "Bearer " + token,
-		},
-	}, delaySeconds)
 
 	w.Header().Add("X-Presigned-Url", signedUrl)
 	w.Header().Set("Location", os.Getenv(config.ServerDomain)+"/syllabi/"+syllabusId)
 	w.WriteHeader(http.StatusCreated)
 }
 
+// maxSyllabusBatchSize is the most syllabi accepted by a single batch upload.
+const maxSyllabusBatchSize = 25
+
+type CreateSyllabusBatchReq struct {
+	Syllabi []AddSyllabusReq `json:"syllabi"`
+} //@name CreateSyllabusBatchRequest
+
+type SyllabusBatchItemRes struct {
+	SyllabusId   *string `json:"syllabusId"`   // Null when the syllabus was not created
+	PresignedUrl *string `json:"presignedUrl"` // URL to upload the syllabus file
+	Location     *string `json:"location"`     // URL of the created syllabus, or of the syllabus it duplicates
+	Error        *string `json:"error"`
+} //@name SyllabusBatchItemResponse
+
+type SyllabusBatchRes struct {
+	BatchId *string                `json:"batchId"` // Null when no syllabi were created
+	Syllabi []SyllabusBatchItemRes `json:"syllabi"` // Results in the order of the request
+} //@name SyllabusBatchResponse
+
+// CreateSyllabusBatch creates several syllabi at once, returning a presigned upload URL or error for each.
+// @Summary Create a batch of syllabi
+// @Description Valid syllabi are created together and invalid ones are reported without failing the batch.
+// @Description A single summary email is sent once the batch's upload window has passed.
+// @Tags Syllabus
+// @Accept json
+// @Param body body CreateSyllabusBatchRequest true "Up to 25 syllabi"
+// @Success 200 {object} SyllabusBatchResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/batch [post]
+func (s *syllabusHandler) CreateSyllabusBatch(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body CreateSyllabusBatchReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(body.Syllabi) == 0 || len(body.Syllabi) > maxSyllabusBatchSize {
+		http.Error(w, fmt.Sprintf("A batch must contain between 1 and %d syllabi.", maxSyllabusBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	res := SyllabusBatchRes{Syllabi: make([]SyllabusBatchItemRes, len(body.Syllabi))}
+
+	// Only complete syllabi are sent to the database, keeping their position in the request
+	inserts := make([]repository.InsertSyllabus, 0, len(body.Syllabi))
+	positions := make([]int, 0, len(body.Syllabi))
+	for i, item := range body.Syllabi {
+		if item.CourseId == "" || item.File == "" || item.FileSize <= 0 || item.ContentType == "" || item.Checksum == "" ||
+			(item.TermId == "" && (item.Year <= 0 || item.Semester == "")) {
+			message := "Missing required syllabus fields."
+			res.Syllabi[i].Error = &message
+			continue
+		}
+
+		inserts = append(inserts, repository.InsertSyllabus{
+			UserId:       session.UserId,
+			CourseId:     item.CourseId,
+			File:         item.File,
+			FileSize:     item.FileSize,
+			ContentType:  item.ContentType,
+			Checksum:     item.Checksum,
+			Year:         item.Year,
+			Semester:     item.Semester,
+			TermId:       item.TermId,
+			InstructorId: item.InstructorId,
+		})
+		positions = append(positions, i)
+	}
+
+	batchId := ""
+	if len(inserts) > 0 {
+		var items []repository.SyllabusBatchItem
+		var err error
+		batchId, items, err = s.syllabusRepo.CreateSyllabusBatch(r.Context(), session.UserId, inserts)
+		if err != nil {
+			if errors.Is(err, util.ErrMalformed) {
+				http.Error(w, "Invalid body parameter.", http.StatusBadRequest)
+			} else {
+				http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		for j, item := range items {
+			result := &res.Syllabi[positions[j]]
+			if item.Err != nil {
+				message := "Invalid syllabus data, or no term exists for the year and semester."
+				var duplicate *repository.DuplicateSyllabusError
+				if errors.As(item.Err, &duplicate) {
+					message = duplicateUploadReason
+					if duplicate.SyllabusId != "" {
+						location := os.Getenv(config.ServerDomain) + "/syllabi/" + duplicate.SyllabusId
+						result.Location = &location
+					}
+				} else if errors.Is(item.Err, util.ErrNotFound) {
+					message = "Course, term or instructor not found."
+				}
+				result.Error = &message
+				continue
+			}
+
+			syllabusId := item.SyllabusId
+			location := os.Getenv(config.ServerDomain) + "/syllabi/" + syllabusId
+			result.SyllabusId = &syllabusId
+			result.Location = &location
+
+			signedUrl, err := s.presigner.PutObject(r.Context(), syllabusId, inserts[j].ContentType, inserts[j].Checksum, 60*60)
+			if err != nil {
+				// The syllabus expires with the rest of the batch if it is never uploaded
+				message := "Failed to create an upload URL."
+				result.Error = &message
+				continue
+			}
+			result.PresignedUrl = &signedUrl
+		}
+	}
+
+	if batchId != "" {
+		res.BatchId = &batchId
+
+		if err := s.queueVerification(r, "/syllabi/batches/"+batchId+"/verify"); err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// VerifySyllabusBatch expires the syllabi of a batch which were not uploaded and emails the user a summary of the batch.
+func (s *syllabusHandler) VerifySyllabusBatch(w http.ResponseWriter, r *http.Request) {
+	batchId := chi.URLParam(r, "batchId")
+
+	active, err := s.uploadRepo.HasActiveSyllabusBatchUpload(r.Context(), batchId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if active {
+		if err := s.queueVerification(r, "/syllabi/batches/"+batchId+"/verify"); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.log.Info(fmt.Sprintf("syllabus batch %s still uploading", batchId))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	summary, err := s.syllabusRepo.VerifySyllabusBatch(r.Context(), batchId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) || errors.Is(err, util.ErrConflict) {
+			w.WriteHeader(http.StatusNoContent)
+		} else if errors.Is(err, util.ErrMalformed) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	received := 0
+	items := make([]emailer.BatchSummaryItem, 0, len(summary.Syllabi))
+	for _, syllabus := range summary.Syllabi {
+		item := emailer.BatchSummaryItem{Course: syllabus.Course}
+		switch syllabus.Status {
+		case repository.SyllabusExpired:
+			item.Outcome = "we did not receive your upload file"
+		case repository.SyllabusRejected:
+			item.Outcome = "rejected, " + syllabus.StatusReason.String
+		case repository.SyllabusVerifying:
+			item.Outcome = "received and still being verified"
+		default:
+			item.Outcome = "published"
+		}
+		if syllabus.Status != repository.SyllabusExpired {
+			received++
+		}
+
+		items = append(items, item)
+	}
+
+	// No need to do anything if email fails to send (logs will catch)
+	s.emailer.SendBatchSummaryEmail(r.Context(), summary.UserEmail, summary.UserName, received, items)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckSyllabusChecksum checks whether a file has already been uploaded before creating a syllabus.
+// @Summary Check for a duplicate syllabus
+// @Description Matches published syllabi and the user's own pending uploads by the CRC32 checksum of the file.
+// @Tags Syllabus
+// @Param checksum query string true "Base64 encoded CRC32 checksum of the file"
+// @Param courseId query string false "Restrict the check to a course"
+// @Param year query int false "Restrict the check to a year"
+// @Param semester query string false "Restrict the check to a semester"
+// @Success 200 {string} string "A matching syllabus exists"
+// @Header 200 {string} Location "URL to access the matching syllabus"
+// @Failure 400 {string} string
+// @Failure 404 {string} string "No matching syllabus"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi [head]
+func (s *syllabusHandler) CheckSyllabusChecksum(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	checksum := query.Get("checksum")
+	if checksum == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var year *int16
+	yearQuery, err := strconv.Atoi(query.Get("year"))
+	if err == nil {
+		yearInt16 := int16(yearQuery)
+		year = &yearInt16
+	}
+
+	syllabusId, err := s.syllabusRepo.FindSyllabusByChecksum(r.Context(), session.UserId, repository.SyllabusChecksumFilter{
+		Checksum: checksum,
+		CourseId: query.Get("courseId"),
+		Year:     year,
+		Semester: query.Get("semester"),
+	})
+	if err != nil {
+		// HEAD responses have no body
+		if errors.Is(err, util.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Location", os.Getenv(config.ServerDomain)+"/syllabi/"+syllabusId)
+	w.WriteHeader(http.StatusOK)
+}
+
+// setDuplicateLocation links the syllabus a rejected upload duplicates, when known.
+func setDuplicateLocation(w http.ResponseWriter, duplicate *repository.DuplicateSyllabusError) {
+	if duplicate.SyllabusId != "" {
+		w.Header().Set("Location", os.Getenv(config.ServerDomain)+"/syllabi/"+duplicate.SyllabusId)
+	}
+}
+
 // ListSyllabi returns a paginated list of syllabi with optional filters.
 // @Summary List syllabi
 // @Tags Syllabus
@@ -200,6 +603,9 @@ func (s *syllabusHandler) CreateSyllabus(w http.ResponseWriter, r *http.Request)
 // @Param courseId query string false "Filter by course ID"
 // @Param year query int false "Filter by year"
 // @Param semester query string false "Filter by semester"
+// @Param termId query string false "Filter by term ID, or current for the current term"
+// @Param status query string false "Filter by status, syllabi other than Published are only listed for their owner"
+// @Param instructorId query string false "Filter by instructor ID"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 10)"
 // @Success 200 {array} SyllabusResponse
@@ -225,14 +631,17 @@ func (s *syllabusHandler) ListSyllabi(w http.ResponseWriter, r *http.Request) {
 	}
 
 	syllabi, err := s.syllabusRepo.ListSyllabi(r.Context(), session.UserId, repository.SyllabusFilters{
-		UserId:   query.Get("userId"),
-		CourseId: query.Get("courseId"),
-		Year:     year,
-		Semester: query.Get("semester"),
+		UserId:       query.Get("userId"),
+		CourseId:     query.Get("courseId"),
+		Year:         year,
+		Semester:     query.Get("semester"),
+		TermId:       query.Get("termId"),
+		Status:       query.Get("status"),
+		InstructorId: query.Get("instructorId"),
 	}, util.NewPaginate(query.Get("page"), query.Get("size")))
 	if err != nil {
 		if errors.Is(err, util.ErrMalformed) {
-			http.Error(w, "Invalid user or course ID.", http.StatusBadRequest)
+			http.Error(w, "Invalid user, course, term or instructor ID.", http.StatusBadRequest)
 		} else {
 			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
 		}
@@ -241,18 +650,11 @@ func (s *syllabusHandler) ListSyllabi(w http.ResponseWriter, r *http.Request) {
 
 	publicSyllabi := make([]SyllabusRes, 0, len(syllabi))
 	for _, syllabus := range syllabi {
-		publicSyllabi = append(publicSyllabi, SyllabusRes{
-			Id:          syllabus.Id,
-			UserId:      syllabus.UserId,
-			CourseId:    syllabus.CourseId,
-			File:        syllabus.File,
-			FileSize:    syllabus.FileSize,
-			ContentType: syllabus.ContentType,
-			Year:        syllabus.Year,
-			Semester:    syllabus.Semester,
-			DateAdded:   syllabus.DateAdded.UnixMicro(),
-			Received:    syllabus.DateSynced.Valid,
-		})
+		publicSyllabi = append(publicSyllabi, newSyllabusRes(r.Context(), s.thumbnailPresigner, syllabus))
+	}
+	if err := setSyllabusReactions(r.Context(), s.syllabusRepo, session.UserId, publicSyllabi); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -262,15 +664,20 @@ func (s *syllabusHandler) ListSyllabi(w http.ResponseWriter, r *http.Request) {
 type UpdateSyllabusReq struct {
 	Year     nullable.Nullable[int16]  `json:"year" swaggertype:"primitive,integer" extensions:"x-nullable"`
 	Semester nullable.Nullable[string] `json:"semester" swaggertype:"primitive,string" extensions:"x-nullable"`
+	// Sets the year and semester from the term
+	TermId nullable.Nullable[string] `json:"termId" swaggertype:"primitive,string"`
+	// Null removes the syllabus' instructor
+	InstructorId nullable.Nullable[string] `json:"instructorId" swaggertype:"primitive,string" extensions:"x-nullable"`
 } //@name UpdateSyllabusRequest
 
-// UpdateSyllabus updates a syllabus' metadata (year and semester).
+// UpdateSyllabus updates a syllabus' metadata (term and instructor).
 // @Summary Update a syllabus
 // @Tags Syllabus
 // @Param syllabusId path string true "Syllabus ID"
 // @Param body body UpdateSyllabusRequest true "Updated syllabus data"
 // @Success 204 {string} string
 // @Header 204 {string} Location "URL to access the updated syllabus"
+// @Failure 400 {string} string
 // @Failure 403 {string} string
 // @Failure 404 {string} string
 // @Failure 500 {string} string
@@ -292,12 +699,16 @@ func (s *syllabusHandler) UpdateSyllabus(w http.ResponseWriter, r *http.Request)
 
 	syllabusId := chi.URLParam(r, "syllabusId")
 	err := s.syllabusRepo.UpdateSyllabus(r.Context(), session.UserId, syllabusId, repository.UpdateSyllabus{
-		Year:     body.Year,
-		Semester: body.Semester,
+		Year:         body.Year,
+		Semester:     body.Semester,
+		TermId:       body.TermId,
+		InstructorId: body.InstructorId,
 	})
 	if err != nil {
 		if errors.Is(err, util.ErrNotFound) {
 			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus, term or instructor ID, or no term exists for the year and semester.", http.StatusBadRequest)
 		} else if errors.Is(err, util.ErrForbidden) {
 			http.Error(w, "You do not have access to update this syllabus.", http.StatusForbidden)
 		} else {
@@ -469,40 +880,462 @@ func (s *syllabusHandler) ListSyllabusLikes(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(publicLikes)
 }
 
-// SyncSyllabus triggers synchronization of a syllabus resource.
-func (s *syllabusHandler) SyncSyllabus(w http.ResponseWriter, r *http.Request) {
+type SyllabusRevisionRes struct {
+	SyllabusId        string  `json:"syllabusId"`
+	Revision          int16   `json:"revision"`
+	File              string  `json:"fileName"`
+	FileSize          int     `json:"fileSize"`
+	ContentType       string  `json:"contentType"`
+	Status            string  `json:"status"` // Uploading, Verifying, Published, Rejected or Expired
+	StatusReason      *string `json:"statusReason"`
+	DateAdded         int64   `json:"dateAdded"`
+	DateStatusChanged int64   `json:"dateStatusChanged"`
+} //@name SyllabusRevisionResponse
+
+func newSyllabusRevisionRes(revision repository.SyllabusRevisionSchema) SyllabusRevisionRes {
+	res := SyllabusRevisionRes{
+		SyllabusId:        revision.SyllabusId,
+		Revision:          revision.Revision,
+		File:              revision.File,
+		FileSize:          revision.FileSize,
+		ContentType:       revision.ContentType,
+		Status:            revision.Status,
+		DateAdded:         revision.DateAdded.UnixMicro(),
+		DateStatusChanged: revision.DateStatusChanged().UnixMicro(),
+	}
+	if revision.StatusReason.Valid {
+		res.StatusReason = &revision.StatusReason.String
+	}
+
+	return res
+}
+
+type AddSyllabusRevisionReq struct {
+	File        string `json:"fileName" validate:"required"`
+	FileSize    int    `json:"fileSize" validate:"required"`
+	ContentType string `json:"contentType" validate:"required"`
+	Checksum    string `json:"checksum" validate:"required"`
+} //@name CreateSyllabusRevisionRequest
+
+// CreateSyllabusRevision starts uploading a new file version of a published syllabus, keeping its reactions and views.
+// @Summary Create a syllabus revision
+// @Description The revision replaces the syllabus' current file once the upload has been verified.
+// @Tags Syllabus
+// @Accept json
+// @Param syllabusId path string true "Syllabus ID"
+// @Param body body CreateSyllabusRevisionRequest true "Revision file data"
+// @Success 201 {object} SyllabusRevisionResponse
+// @Header 201 {string} X-Presigned-Url "Presigned URL to upload the revision file"
+// @Header 201 {string} Location "URL to access the created revision"
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Syllabus is not published, already has a pending revision or the file is a duplicate"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/revisions [post]
+func (s *syllabusHandler) CreateSyllabusRevision(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	var body AddSyllabusRevisionReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	revision, err := s.syllabusRepo.CreateSyllabusRevision(r.Context(), session.UserId, syllabusId, repository.InsertSyllabusRevision{
+		File:        body.File,
+		FileSize:    body.FileSize,
+		ContentType: body.ContentType,
+		Checksum:    body.Checksum,
+	})
+	if err != nil {
+		var duplicate *repository.DuplicateSyllabusError
+		if errors.As(err, &duplicate) {
+			setDuplicateLocation(w, duplicate)
+			http.Error(w, duplicateUploadReason, http.StatusConflict)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid body parameter.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "You do not have access to revise this syllabus.", http.StatusForbidden)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Only published syllabi without a pending revision can be revised.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	signedUrl, err := s.presigner.PutObject(r.Context(), revision.ObjectKey, body.ContentType, body.Checksum, 60*60)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	revisionPath := fmt.Sprintf("/syllabi/%s/revisions/%d", syllabusId, revision.Revision)
+	if err := s.queueVerification(r, revisionPath+"/verify"); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("X-Presigned-Url", signedUrl)
+	w.Header().Set("Location", os.Getenv(config.ServerDomain)+revisionPath)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newSyllabusRevisionRes(revision))
+}
+
+// ListSyllabusRevisions lists a syllabus' file versions, newest first. Other users only see published revisions.
+// @Summary List syllabus revisions
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Success 200 {array} SyllabusRevisionResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/revisions [get]
+func (s *syllabusHandler) ListSyllabusRevisions(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
 	syllabusId := chi.URLParam(r, "syllabusId")
-	err := s.syllabusRepo.SyncSyllabus(r.Context(), syllabusId)
+	revisions, err := s.syllabusRepo.ListSyllabusRevisions(r.Context(), session.UserId, syllabusId)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	publicRevisions := make([]SyllabusRevisionRes, 0, len(revisions))
+	for _, revision := range revisions {
+		publicRevisions = append(publicRevisions, newSyllabusRevisionRes(revision))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(publicRevisions)
+}
+
+// GetSyllabusRevision retrieves a syllabus revision, published revisions include a signed URL in the header.
+// @Summary Get a syllabus revision
+// @Tags Syllabus
+// @Param syllabusId path string true "Syllabus ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} SyllabusRevisionResponse
+// @Header 200 {string} X-Presigned-Url "Presigned URL to access the revision file, only set for published revisions"
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /syllabi/{syllabusId}/revisions/{revision} [get]
+func (s *syllabusHandler) GetSyllabusRevision(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		s.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	revisionNumber, err := strconv.ParseInt(chi.URLParam(r, "revision"), 10, 16)
+	if err != nil {
+		http.Error(w, "Invalid revision number.", http.StatusBadRequest)
+		return
+	}
+
+	syllabusId := chi.URLParam(r, "syllabusId")
+	revision, err := s.syllabusRepo.GetSyllabusRevision(r.Context(), session.UserId, syllabusId, int16(revisionNumber))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabus ID.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus revision not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if revision.Status == repository.SyllabusPublished {
+		signedUrl, err := s.presigner.GetObject(r.Context(), revision.ObjectKey, 60*60)
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("X-Presigned-Url", signedUrl)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newSyllabusRevisionRes(revision))
+}
+
+// SyncSyllabusRevision verifies an uploaded revision, replacing the syllabus' current file once published.
+func (s *syllabusHandler) SyncSyllabusRevision(w http.ResponseWriter, r *http.Request) {
+	syllabusId := chi.URLParam(r, "syllabusId")
+	revision, err := strconv.ParseInt(chi.URLParam(r, "revision"), 10, 16)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	_, meta, err := s.syllabusRepo.VerifySyllabus(r.Context(), syllabusId)
+	s.syncUpload(w, r, func(status string, reason string) (repository.SyllabusMeta, error) {
+		return s.syllabusRepo.TransitionSyllabusRevision(r.Context(), syllabusId, int16(revision), status, reason)
+	})
+}
+
+// VerifySyllabusRevision expires a revision which has not been uploaded within the upload window.
+func (s *syllabusHandler) VerifySyllabusRevision(w http.ResponseWriter, r *http.Request) {
+	syllabusId := chi.URLParam(r, "syllabusId")
+	revision, err := strconv.ParseInt(chi.URLParam(r, "revision"), 10, 16)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.expireUpload(w, r, syllabusId, int16(revision), func(status string, reason string) (repository.SyllabusMeta, error) {
+		return s.syllabusRepo.TransitionSyllabusRevision(r.Context(), syllabusId, int16(revision), status, reason)
+	})
+}
+
+type QueueSyllabusExtractionsRes struct {
+	Queued int `json:"queued"`
+} //@name QueueSyllabusExtractionsResponse
+
+// QueueSyllabusExtractions queues text extraction for published syllabi missing from the search index, such as those whose extraction failed to queue when synced.
+// @Summary Queue missing syllabus text extractions
+// @Tags Admin
+// @Param size query int false "Maximum number of syllabi to queue (default: 25)"
+// @Success 200 {object} QueueSyllabusExtractionsResponse
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /admin/syllabi/extract [post]
+func (s *syllabusHandler) QueueSyllabusExtractions(w http.ResponseWriter, r *http.Request) {
+	paginate := util.NewPaginate("", r.URL.Query().Get("size"))
+	syllabusIds, err := s.syllabusRepo.ListUnindexedSyllabusIds(r.Context(), paginate.Size)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	queued := 0
+	for _, syllabusId := range syllabusIds {
+		if err := s.queueWebhook(r, "/syllabi/"+syllabusId+"/extract", 0); err != nil {
+			s.log.Warn(fmt.Sprintf("failed to queue text extraction for syllabus %s", syllabusId))
+			continue
+		}
+		queued++
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(QueueSyllabusExtractionsRes{Queued: queued})
+}
+
+// queueVerification schedules an internal request to the given path once the upload window has passed.
+func (s *syllabusHandler) queueVerification(r *http.Request, path string) error {
+	var delaySeconds int32
+	if os.Getenv(config.ENV) == "development" {
+		delaySeconds = 10
+	} else {
+		delaySeconds = 60 * 5 // 5 minutes
+	}
+
+	return s.queueWebhook(r, path, delaySeconds)
+}
+
+// queueWebhook schedules an authorized request back to the server.
+func (s *syllabusHandler) queueWebhook(r *http.Request, path string, delaySeconds int32) error {
+	token, err := s.jwt.EncodeJwt(nil)
+	if err != nil {
+		s.log.Error("failed to encode webhook token", logger.Err(err))
+		return err
+	}
+
+	requestId, _ := r.Context().Value(config.RequestIdKey).(string)
+	return s.queue.SendMessage(r.Context(), queue.WebhookMessage{
+		RequestId: requestId,
+		Url:       os.Getenv(config.ServerDomain) + path,
+		Headers: map[string]string{
+			"Authorization": "Bearer " + token,
+		},
+	}, delaySeconds)
+}
+
+const duplicateUploadReason = "An identical syllabus has already been uploaded for this course and term."
+
+// uploadTransition moves a syllabus or one of its revisions to a new status.
+type uploadTransition func(status string, reason string) (repository.SyllabusMeta, error)
+
+// SyncSyllabus moves an uploaded syllabus into verification, publishing or rejecting it based on the uploaded file.
+func (s *syllabusHandler) SyncSyllabus(w http.ResponseWriter, r *http.Request) {
+	syllabusId := chi.URLParam(r, "syllabusId")
+	s.syncUpload(w, r, func(status string, reason string) (repository.SyllabusMeta, error) {
+		return s.syllabusRepo.TransitionSyllabus(r.Context(), syllabusId, status, reason)
+	})
+}
+
+// VerifySyllabus expires a syllabus which has not been uploaded within the upload window.
+func (s *syllabusHandler) VerifySyllabus(w http.ResponseWriter, r *http.Request) {
+	syllabusId := chi.URLParam(r, "syllabusId")
+	s.expireUpload(w, r, syllabusId, 1, func(status string, reason string) (repository.SyllabusMeta, error) {
+		return s.syllabusRepo.TransitionSyllabus(r.Context(), syllabusId, status, reason)
+	})
+}
+
+// syncUpload verifies an uploaded file, publishing it if it matches the submitted file or rejecting it otherwise.
+func (s *syllabusHandler) syncUpload(w http.ResponseWriter, r *http.Request, transition uploadTransition) {
+	meta, err := transition(repository.SyllabusVerifying, "")
 	if err != nil {
 		if errors.Is(err, util.ErrNotFound) {
 			w.WriteHeader(http.StatusNoContent)
 		} else if errors.Is(err, util.ErrMalformed) {
 			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			w.WriteHeader(http.StatusConflict)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
 	}
 
-	s.emailer.SendSubmissionMissingEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course)
+	reason := ""
+	sha, size, err := s.hashObject(r, meta.ObjectKey)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotFound) {
+			// Leave the upload in verification so it can be retried
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		reason = "We did not receive your upload file."
+	} else if size != int64(meta.FileSize) {
+		reason = "The uploaded file does not match the submitted file size."
+	} else if err := s.syllabusRepo.RecordSyllabusSha256(r.Context(), meta.Id, meta.Revision, sha); err != nil {
+		if !errors.Is(err, util.ErrConflict) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		reason = duplicateUploadReason
+	}
+
+	if reason == "" {
+		_, err := transition(repository.SyllabusPublished, "")
+		var duplicate *repository.DuplicateSyllabusError
+		if errors.As(err, &duplicate) {
+			// Another upload of the same file was published first
+			reason = duplicateUploadReason
+		} else if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if reason != "" {
+		if _, err := transition(repository.SyllabusRejected, reason); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-	s.log.Info(fmt.Sprintf("syllabus %s synced", syllabusId))
+		s.log.Info(fmt.Sprintf("syllabus upload %s rejected", meta.ObjectKey))
+		if !meta.BatchPending {
+			s.emailer.SendSubmissionRejectedEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course, reason)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// The thumbnail lambda runs alongside this sync, thumbnails rendered after it are recorded when previewed
+	s.recordThumbnail(r.Context(), meta.Id, meta.Revision, meta.ObjectKey)
+
+	// Syllabi missing from the search index are re-queued by the admin extract endpoint, no need to fail the sync
+	if err := s.queueWebhook(r, "/syllabi/"+meta.Id+"/extract", 0); err != nil {
+		s.log.Warn(fmt.Sprintf("failed to queue text extraction for syllabus %s", meta.Id))
+	}
+
+	// Batched syllabi are covered by the batch summary email
+	if !meta.BatchPending {
+		s.emailer.SendSubmissionSuccessEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course)
+	}
+
+	// Requests are left open if fulfilment fails, the next upload for the course fulfils them
+	recipients, err := s.requestRepo.FulfilSyllabusRequests(r.Context(), meta.Id)
+	if err != nil {
+		s.log.Warn(fmt.Sprintf("failed to fulfil syllabus requests for syllabus %s", meta.Id))
+	}
+	for _, recipient := range recipients {
+		s.emailer.SendRequestFulfilledEmail(r.Context(), recipient.Email, recipient.Name, meta.Course)
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus upload %s synced", meta.ObjectKey))
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// VerifySyllabus check if a syllabus has been synchronized.
-func (s *syllabusHandler) VerifySyllabus(w http.ResponseWriter, r *http.Request) {
-	syllabusId := chi.URLParam(r, "syllabusId")
-	isVerified, meta, err := s.syllabusRepo.VerifySyllabus(r.Context(), syllabusId)
+// hashObject streams an uploaded file, returning its hex encoded SHA-256 and size in bytes.
+func (s *syllabusHandler) hashObject(r *http.Request, objectKey string) (string, int64, error) {
+	body, _, err := s.objects.GetObject(r.Context(), objectKey)
+	if err != nil {
+		return "", 0, err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, body)
+	if err != nil {
+		s.log.Error(fmt.Sprintf("failed to read syllabus upload %s", objectKey), logger.Err(err))
+		return "", 0, util.ErrInternal
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// expireUpload expires an upload which was not received within the upload window.
+// Uploads still in progress through a resumable upload are checked again later instead.
+func (s *syllabusHandler) expireUpload(w http.ResponseWriter, r *http.Request, syllabusId string, revision int16, transition uploadTransition) {
+	active, err := s.uploadRepo.HasActiveSyllabusUpload(r.Context(), syllabusId, revision)
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if active {
+		path := "/syllabi/" + syllabusId + "/verify"
+		if revision > 1 {
+			path = fmt.Sprintf("/syllabi/%s/revisions/%d/verify", syllabusId, revision)
+		}
+		if err := s.queueVerification(r, path); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.log.Info(fmt.Sprintf("syllabus %s revision %d still uploading", syllabusId, revision))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	meta, err := transition(repository.SyllabusExpired, "")
 	if err != nil {
 		if errors.Is(err, util.ErrNotFound) {
 			w.WriteHeader(http.StatusNoContent)
+		} else if errors.Is(err, util.ErrConflict) {
+			// The upload was received in time
+			s.log.Info(fmt.Sprintf("syllabus upload %s verified", meta.ObjectKey))
+			w.WriteHeader(http.StatusNoContent)
 		} else if errors.Is(err, util.ErrMalformed) {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
@@ -511,12 +1344,8 @@ func (s *syllabusHandler) VerifySyllabus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if !isVerified {
-		s.log.Info(fmt.Sprintf("syllabus %s not verified", syllabusId))
-		s.emailer.SendSubmissionMissingEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course)
-	} else {
-		s.log.Info(fmt.Sprintf("syllabus %s verified", syllabusId))
-	}
+	s.log.Info(fmt.Sprintf("syllabus upload %s not verified", meta.ObjectKey))
+	s.emailer.SendSubmissionMissingEmail(r.Context(), meta.UserEmail, meta.UserName, meta.Course)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+type termHandler struct {
+	log      logger.Logger
+	termRepo repository.TermRepository
+}
+
+func NewTermHandler(log logger.Logger, term repository.TermRepository) *termHandler {
+	return &termHandler{
+		log:      log,
+		termRepo: term,
+	}
+}
+
+type TermRes struct {
+	Id          string `json:"id"`
+	Code        string `json:"code"` // e.g. 2024-fall
+	Name        string `json:"name"` // e.g. Fall 2024
+	Year        int16  `json:"year"`
+	Semester    string `json:"semester"`
+	Institution string `json:"institution"`
+	DateStart   int64  `json:"dateStart"`
+	DateEnd     int64  `json:"dateEnd"`
+} //@name TermResponse
+
+func newTermRes(term repository.TermSchema) TermRes {
+	return TermRes{
+		Id:          term.Id,
+		Code:        term.Code,
+		Name:        term.Name,
+		Year:        term.Year,
+		Semester:    term.Semester,
+		Institution: term.Institution,
+		DateStart:   term.DateStart.UnixMicro(),
+		DateEnd:     term.DateEnd.UnixMicro(),
+	}
+}
+
+// ListTerms lists academic terms, latest first.
+// @Summary List terms
+// @Tags Term
+// @Param year query int false "Filter by year"
+// @Param semester query string false "Filter by semester"
+// @Param institution query string false "Filter by institution"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 25)"
+// @Success 200 {array} TermResponse
+// @Failure 400 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /terms [get]
+func (t *termHandler) ListTerms(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var year *int16
+	yearQuery, err := strconv.Atoi(query.Get("year"))
+	if err == nil {
+		yearInt16 := int16(yearQuery)
+		year = &yearInt16
+	}
+
+	terms, err := t.termRepo.ListTerms(r.Context(), repository.TermFilters{
+		Year:        year,
+		Semester:    query.Get("semester"),
+		Institution: query.Get("institution"),
+	}, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid semester value.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := make([]TermRes, 0, len(terms))
+	for _, term := range terms {
+		res = append(res, newTermRes(term))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetTerm retrieves a term by id, or the current term.
+// @Summary Get a term
+// @Description The current term is the shortest term in progress, or the next term to start between terms.
+// @Tags Term
+// @Param termId path string true "Term ID, or current for the current term"
+// @Success 200 {object} TermResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /terms/{termId} [get]
+func (t *termHandler) GetTerm(w http.ResponseWriter, r *http.Request) {
+	term, err := t.termRepo.GetTerm(r.Context(), chi.URLParam(r, "termId"))
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid term ID value.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Term not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newTermRes(term))
+}
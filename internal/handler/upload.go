@@ -0,0 +1,426 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,termination"
+	// Uploads are capped since unfinished parts are buffered in memory and the database.
+	maxUploadSize = 100 << 20
+)
+
+// uploadHandler implements the tus 1.0 resumable upload protocol for syllabus files.
+// Completing an upload writes the object like a presigned upload would, so the bucket notification syncs it.
+type uploadHandler struct {
+	log          logger.Logger
+	uploadRepo   repository.UploadRepository
+	syllabusRepo repository.SyllabusRepository
+	multipart    bucket.MultipartClient
+}
+
+func NewUploadHandler(log logger.Logger, upload repository.UploadRepository, syllabus repository.SyllabusRepository, multipart bucket.MultipartClient) *uploadHandler {
+	return &uploadHandler{
+		log:          log,
+		uploadRepo:   upload,
+		syllabusRepo: syllabus,
+		multipart:    multipart,
+	}
+}
+
+// TusMiddleware rejects requests made with an unsupported tus protocol version.
+func (u *uploadHandler) TusMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Tus-Resumable", tusVersion)
+
+		if r.Method != http.MethodOptions && r.Header.Get("Tus-Resumable") != tusVersion {
+			w.Header().Set("Tus-Version", tusVersion)
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetUploadOptions describes the supported tus protocol version and extensions.
+// @Summary Get resumable upload options
+// @Tags Upload
+// @Success 204 {string} string
+// @Header 204 {string} Tus-Version "Supported tus protocol versions"
+// @Header 204 {string} Tus-Extension "Supported tus protocol extensions"
+// @Header 204 {integer} Tus-Max-Size "Maximum upload size in bytes"
+// @Security Session
+// @Router /uploads [options]
+func (u *uploadHandler) GetUploadOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Version", tusVersion)
+	w.Header().Set("Tus-Extension", tusExtensions)
+	w.Header().Set("Tus-Max-Size", strconv.Itoa(maxUploadSize))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateUpload starts a resumable upload of a syllabus or revision file which has been created but not yet uploaded.
+// @Summary Create a resumable upload
+// @Description Follows the tus 1.0 creation extension. Upload-Metadata must contain the base64 encoded syllabusId and may contain a revision, defaulting to the first upload.
+// @Tags Upload
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Param Upload-Length header int true "File size in bytes, matching the size submitted with the syllabus"
+// @Param Upload-Metadata header string true "tus metadata with syllabusId and optionally revision"
+// @Success 201 {string} string
+// @Header 201 {string} Location "URL of the created upload"
+// @Header 201 {string} Upload-Expires "Time after which the upload can no longer be resumed"
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "File was already uploaded or is being uploaded"
+// @Failure 412 {string} string "Unsupported tus version"
+// @Failure 413 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /uploads [post]
+func (u *uploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("Upload-Defer-Length") != "" {
+		http.Error(w, "Deferred upload lengths are not supported.", http.StatusBadRequest)
+		return
+	}
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		http.Error(w, "Invalid Upload-Length header.", http.StatusBadRequest)
+		return
+	}
+	if length > maxUploadSize {
+		http.Error(w, "File exceeds the maximum upload size.", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	metadata := parseUploadMetadata(r.Header.Get("Upload-Metadata"))
+	revisionNumber := int16(1)
+	if value, ok := metadata["revision"]; ok {
+		parsed, err := strconv.ParseInt(value, 10, 16)
+		if err != nil {
+			http.Error(w, "Invalid revision metadata.", http.StatusBadRequest)
+			return
+		}
+		revisionNumber = int16(parsed)
+	}
+
+	revision, err := u.syllabusRepo.GetSyllabusRevision(r.Context(), session.UserId, metadata["syllabusId"], revisionNumber)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid syllabusId metadata.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+	if revision.Status != repository.SyllabusUploading {
+		http.Error(w, "Syllabus file has already been uploaded.", http.StatusConflict)
+		return
+	}
+	if length != int64(revision.FileSize) {
+		http.Error(w, "Upload-Length does not match the submitted file size.", http.StatusBadRequest)
+		return
+	}
+
+	multipartId, err := u.multipart.CreateMultipartUpload(r.Context(), revision.ObjectKey, revision.ContentType)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	upload, err := u.uploadRepo.CreateSyllabusUpload(r.Context(), session.UserId, repository.InsertSyllabusUpload{
+		SyllabusId:  revision.SyllabusId,
+		Revision:    revision.Revision,
+		MultipartId: multipartId,
+		Length:      length,
+	})
+	if err != nil {
+		u.multipart.AbortMultipartUpload(r.Context(), revision.ObjectKey, multipartId)
+
+		if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "Syllabus file is already being uploaded.", http.StatusConflict)
+		} else if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Syllabus not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Location", os.Getenv(config.ServerDomain)+"/uploads/"+upload.Id)
+	w.Header().Set("Upload-Expires", upload.DateExpires().UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// GetUploadOffset returns how much of a resumable upload has been received.
+// @Summary Get a resumable upload offset
+// @Tags Upload
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Success 200 {string} string
+// @Header 200 {integer} Upload-Offset "Bytes received"
+// @Header 200 {integer} Upload-Length "Total bytes"
+// @Header 200 {string} Upload-Expires "Time after which the upload can no longer be resumed"
+// @Failure 404 {string} string
+// @Failure 410 {string} string "Upload expired"
+// @Failure 412 {string} string "Unsupported tus version"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /uploads/{uploadId} [head]
+func (u *uploadHandler) GetUploadOffset(w http.ResponseWriter, r *http.Request) {
+	upload, ok := u.getUpload(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if !upload.DateCompleted.Valid {
+		w.Header().Set("Upload-Expires", upload.DateExpires().UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UploadChunk appends a chunk to a resumable upload starting at the current offset.
+// @Summary Upload a chunk
+// @Description Chunks are written to storage in parts, bytes received before a dropped connection are kept and reflected in the offset.
+// @Tags Upload
+// @Accept application/offset+octet-stream
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Param Upload-Offset header int true "Offset the chunk starts at, matching the current offset"
+// @Success 204 {string} string
+// @Header 204 {integer} Upload-Offset "Bytes received"
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Upload-Offset does not match the current offset"
+// @Failure 410 {string} string "Upload expired"
+// @Failure 412 {string} string "Unsupported tus version"
+// @Failure 415 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /uploads/{uploadId} [patch]
+func (u *uploadHandler) UploadChunk(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != "application/offset+octet-stream" {
+		http.Error(w, "Content-Type must be application/offset+octet-stream.", http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, "Invalid Upload-Offset header.", http.StatusBadRequest)
+		return
+	}
+
+	upload, ok := u.getUpload(w, r)
+	if !ok {
+		return
+	}
+	if offset != upload.Offset {
+		http.Error(w, "Upload-Offset does not match the current offset.", http.StatusConflict)
+		return
+	}
+
+	// A previous request may have received every byte but failed to complete the upload
+	if upload.Offset < upload.Length {
+		progress, receiveErr := u.receiveChunk(r, upload)
+		if err := u.uploadRepo.AdvanceSyllabusUpload(r.Context(), upload.Id, upload.Offset, progress); err != nil {
+			if errors.Is(err, util.ErrConflict) {
+				http.Error(w, "Upload was modified by another request.", http.StatusConflict)
+			} else {
+				http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			}
+			return
+		}
+		if receiveErr != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+
+		upload.Offset = progress.Offset
+		upload.PartCount = progress.PartCount
+	}
+
+	if upload.Offset == upload.Length && !upload.DateCompleted.Valid {
+		if err := u.multipart.CompleteMultipartUpload(r.Context(), upload.ObjectKey(), upload.MultipartId, upload.PartCount); err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+		if err := u.uploadRepo.CompleteSyllabusUpload(r.Context(), upload.Id); err != nil && !errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// receiveChunk streams the request body into storage part by part, returning the progress made.
+// Bytes too few to form a part are kept as the pending part until more are received.
+// The progress is valid even when writing a part fails, so that the parts already written are kept.
+func (u *uploadHandler) receiveChunk(r *http.Request, upload repository.SyllabusUploadSchema) (repository.UpdateSyllabusUpload, error) {
+	body := io.LimitReader(r.Body, upload.Length-upload.Offset)
+	buf := make([]byte, bucket.MinPartSize)
+	pending := copy(buf, upload.PendingPart)
+	offset := upload.Offset
+	partCount := upload.PartCount
+
+	for {
+		n, readErr := io.ReadFull(body, buf[pending:])
+		pending += n
+		offset += int64(n)
+
+		complete := offset == upload.Length
+		if pending == len(buf) || (complete && pending > 0) {
+			err := u.multipart.UploadPart(r.Context(), upload.ObjectKey(), upload.MultipartId, partCount+1, buf[:pending])
+			if err != nil {
+				// The client resends everything from the start of the failed part
+				return repository.UpdateSyllabusUpload{
+					Offset:      offset - int64(pending),
+					PartCount:   partCount,
+					PendingPart: []byte{},
+				}, err
+			}
+			partCount++
+			pending = 0
+		}
+
+		if readErr != nil || complete {
+			// A dropped connection keeps whatever was received
+			if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+				u.log.Info(fmt.Sprintf("syllabus upload %s interrupted at offset %d", upload.Id, offset), logger.Err(readErr))
+			}
+			break
+		}
+	}
+
+	return repository.UpdateSyllabusUpload{
+		Offset:      offset,
+		PartCount:   partCount,
+		PendingPart: buf[:pending],
+	}, nil
+}
+
+// TerminateUpload cancels a resumable upload and discards the received bytes.
+// @Summary Terminate a resumable upload
+// @Tags Upload
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string "Upload already completed"
+// @Failure 412 {string} string "Unsupported tus version"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /uploads/{uploadId} [delete]
+func (u *uploadHandler) TerminateUpload(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	upload, err := u.uploadRepo.GetSyllabusUpload(r.Context(), session.UserId, chi.URLParam(r, "uploadId"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Upload not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed upload ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+	if upload.DateCompleted.Valid {
+		http.Error(w, "Upload already completed.", http.StatusConflict)
+		return
+	}
+
+	if err := u.uploadRepo.DeleteSyllabusUpload(r.Context(), session.UserId, upload.Id); err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Upload not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// No need to fail the request, abandoned multipart uploads are also aborted by the bucket lifecycle rules
+	if err := u.multipart.AbortMultipartUpload(r.Context(), upload.ObjectKey(), upload.MultipartId); err != nil && !errors.Is(err, util.ErrNotFound) {
+		u.log.Warn(fmt.Sprintf("failed to abort terminated syllabus upload %s", upload.Id))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUpload reads the session user's upload from the route, writing the error response if it cannot be resumed.
+func (u *uploadHandler) getUpload(w http.ResponseWriter, r *http.Request) (repository.SyllabusUploadSchema, bool) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return repository.SyllabusUploadSchema{}, false
+	}
+
+	upload, err := u.uploadRepo.GetSyllabusUpload(r.Context(), session.UserId, chi.URLParam(r, "uploadId"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) || errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Upload not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return repository.SyllabusUploadSchema{}, false
+	}
+
+	if upload.IsExpired {
+		http.Error(w, "Upload has expired.", http.StatusGone)
+		return repository.SyllabusUploadSchema{}, false
+	}
+
+	return upload, true
+}
+
+// parseUploadMetadata decodes a tus Upload-Metadata header of comma separated keys and base64 encoded values.
+func parseUploadMetadata(header string) map[string]string {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(value)
+	}
+
+	return metadata
+}
@@ -1,28 +1,50 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/JackieLi565/syllabye/internal/config"
 	"github.com/JackieLi565/syllabye/internal/repository"
+	"github.com/JackieLi565/syllabye/internal/service/bucket"
+	"github.com/JackieLi565/syllabye/internal/service/imaging"
 	"github.com/JackieLi565/syllabye/internal/service/logger"
 	"github.com/JackieLi565/syllabye/internal/util"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/oapi-codegen/nullable"
 )
 
 type userHandler struct {
-	log      logger.Logger
-	userRepo repository.UserRepository
+	log             logger.Logger
+	userRepo        repository.UserRepository
+	syllabusRepo    repository.SyllabusRepository
+	requirementRepo repository.RequirementRepository
+	avatarPresigner bucket.PresignerClient
+	avatarObject    bucket.ObjectClient
+	// Thumbnails rendered by the thumbnail lambda
+	thumbnailPresigner bucket.PresignerClient
 }
 
-func NewUserHandler(log logger.Logger, user repository.UserRepository) *userHandler {
+func NewUserHandler(log logger.Logger, user repository.UserRepository, syllabus repository.SyllabusRepository, requirement repository.RequirementRepository, avatarPresigner bucket.PresignerClient, avatarObject bucket.ObjectClient, thumbnailPresigner bucket.PresignerClient) *userHandler {
 	return &userHandler{
-		log:      log,
-		userRepo: user,
+		log:                log,
+		userRepo:           user,
+		syllabusRepo:       syllabus,
+		requirementRepo:    requirement,
+		avatarPresigner:    avatarPresigner,
+		avatarObject:       avatarObject,
+		thumbnailPresigner: thumbnailPresigner,
 	}
 }
 
@@ -35,11 +57,73 @@ type UserRes struct {
 	Gender      nullable.Nullable[string] `json:"gender,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
 	Email       string                    `json:"email,omitempty"`
 	Picture     nullable.Nullable[string] `json:"picture,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
+	Avatar      *UserAvatarRes            `json:"avatar,omitempty"`
 	Bio         nullable.Nullable[string] `json:"bio,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
-	Instagram   nullable.Nullable[string] `json:"instagram,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
+	Visibility  *UserVisibilityRes        `json:"visibility,omitempty"`
 } //@name UserResponse
 
-// GetUser retrieves a user by ID.
+// UserAvatarRes holds URLs to each standard size of an uploaded avatar.
+type UserAvatarRes struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+} //@name UserAvatarResponse
+
+type UserVisibilityRes struct {
+	Email       string `json:"email"`
+	Gender      string `json:"gender"`
+	CurrentYear string `json:"currentYear"`
+	Links       string `json:"links"`
+	Courses     string `json:"courses"`
+} //@name UserVisibilityResponse
+
+// newUserRes projects a user onto the fields the audience is allowed to see.
+// Hidden fields are left unset so they are omitted from the response.
+func (u *userHandler) newUserRes(ctx context.Context, user repository.UserSchema, audience repository.ProfileAudience) UserRes {
+	res := UserRes{
+		Id:        user.Id,
+		ProgramId: util.DefaultNullable(user.ProgramId.Valid, user.ProgramId.String),
+		FullName:  user.FullName,
+		Nickname:  util.DefaultNullable(user.Nickname.Valid, user.Nickname.String),
+		Picture:   util.DefaultNullable(user.Picture.Valid, user.Picture.String),
+		Bio:       util.DefaultNullable(user.Bio.Valid, user.Bio.String),
+	}
+
+	// Uploaded avatars take priority, falling back to the OpenID picture
+	if user.AvatarKey.Valid {
+		avatar, err := u.avatarUrls(ctx, user.AvatarKey.String)
+		if err == nil {
+			res.Avatar = &avatar
+			res.Picture = nullable.NewNullableWithValue(avatar.Large)
+		}
+	}
+
+	if audience.CanView(user.EmailVisibility) {
+		res.Email = user.Email
+	}
+	if audience.CanView(user.GenderVisibility) {
+		res.Gender = util.DefaultNullable(user.Gender.Valid, user.Gender.String)
+	}
+	if audience.CanView(user.CurrentYearVisibility) {
+		res.CurrentYear = util.DefaultNullable(user.CurrentYear.Valid, user.CurrentYear.Int16)
+	}
+
+	// Only the owner of the profile sees their own visibility settings
+	if audience == repository.AudienceSelf {
+		res.Visibility = &UserVisibilityRes{
+			Email:       user.EmailVisibility,
+			Gender:      user.GenderVisibility,
+			CurrentYear: user.CurrentYearVisibility,
+			Links:       user.LinksVisibility,
+			Courses:     user.CoursesVisibility,
+		}
+	}
+
+	return res
+}
+
+// GetUser retrieves a user by ID. Fields are included based on the user's visibility
+// settings and whether the caller is the user, in the same program, or anyone else.
 // @Summary Get a user
 // @Tags User
 // @Param userId path string true "User ID"
@@ -50,13 +134,13 @@ type UserRes struct {
 // @Security Session
 // @Router /users/{userId} [get]
 func (u *userHandler) GetUser(w http.ResponseWriter, r *http.Request) {
-	sessionValue := r.Context().Value(config.AuthKey)
-	if sessionValue == nil {
-		u.log.Error("missing session middleware")
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Info("user requested without session, using public profile")
 	}
 
 	userId := chi.URLParam(r, "userId")
-	user, err := u.userRepo.GetUser(r.Context(), userId)
+	user, audience, err := u.userRepo.GetUserProfile(r.Context(), session.UserId, userId)
 	if err != nil {
 		if errors.Is(err, util.ErrNotFound) {
 			http.Error(w, "User not found.", http.StatusNotFound)
@@ -70,18 +154,7 @@ func (u *userHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(UserRes{
-		Id:          user.Id,
-		ProgramId:   util.DefaultNullable(user.ProgramId.Valid, user.ProgramId.String),
-		FullName:    user.FullName,
-		Nickname:    util.DefaultNullable(user.Nickname.Valid, user.Nickname.String),
-		CurrentYear: util.DefaultNullable(user.CurrentYear.Valid, user.CurrentYear.Int16),
-		Gender:      util.DefaultNullable(user.Gender.Valid, user.Gender.String),
-		Email:       user.Email,
-		Picture:     util.DefaultNullable(user.Picture.Valid, user.Picture.String),
-		Bio:         util.DefaultNullable(user.Bio.Valid, user.Bio.String),
-		Instagram:   util.DefaultNullable(user.IgHandle.Valid, user.IgHandle.String),
-	})
+	json.NewEncoder(w).Encode(u.newUserRes(r.Context(), user, audience))
 }
 
 type UpdateUserRequest struct {
@@ -90,7 +163,12 @@ type UpdateUserRequest struct {
 	CurrentYear nullable.Nullable[int16]  `json:"currentYear" swaggertype:"primitive,integer" extensions:"x-nullable"`
 	Gender      nullable.Nullable[string] `json:"gender" swaggertype:"primitive,string" extensions:"x-nullable"`
 	Bio         nullable.Nullable[string] `json:"bio" swaggertype:"primitive,string" extensions:"x-nullable"`
-	Instagram   nullable.Nullable[string] `json:"instagram" swaggertype:"primitive,string" extensions:"x-nullable"`
+	// Visibility values are Public, Program or Private. A null value resets to the default.
+	EmailVisibility       nullable.Nullable[string] `json:"emailVisibility" swaggertype:"primitive,string" extensions:"x-nullable"`
+	GenderVisibility      nullable.Nullable[string] `json:"genderVisibility" swaggertype:"primitive,string" extensions:"x-nullable"`
+	CurrentYearVisibility nullable.Nullable[string] `json:"currentYearVisibility" swaggertype:"primitive,string" extensions:"x-nullable"`
+	LinksVisibility       nullable.Nullable[string] `json:"linksVisibility" swaggertype:"primitive,string" extensions:"x-nullable"`
+	CoursesVisibility     nullable.Nullable[string] `json:"coursesVisibility" swaggertype:"primitive,string" extensions:"x-nullable"`
 } //@name UpdateUserRequest
 
 // UpdateUser modifies a user's profile data.
@@ -133,7 +211,12 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		CurrentYear: body.CurrentYear,
 		Gender:      body.Gender,
 		Bio:         body.Bio,
-		IgHandle:    body.Instagram,
+
+		EmailVisibility:       body.EmailVisibility,
+		GenderVisibility:      body.GenderVisibility,
+		CurrentYearVisibility: body.CurrentYearVisibility,
+		LinksVisibility:       body.LinksVisibility,
+		CoursesVisibility:     body.CoursesVisibility,
 	})
 	if err != nil {
 		if errors.Is(err, util.ErrMalformed) {
@@ -152,8 +235,9 @@ func (u *userHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 type AddUserCourseReq struct {
 	CourseId      string  `json:"courseId" validate:"required"`
-	YearTaken     *int16  `json:"yearTaken"`
-	SemesterTaken *string `json:"semesterTaken"`
+	YearTaken     *int16  `json:"yearTaken"`     // Year of study
+	SemesterTaken *string `json:"semesterTaken"` // Without a term ID, the term is the latest of the semester starting before the course is added
+	TermId        *string `json:"termId"`        // Term ID or "current", sets the semester taken
 } //@name CreateUserCourseRequest
 
 // AddUserCourse adds a course to a user's academic history.
@@ -197,6 +281,7 @@ func (u *userHandler) AddUserCourse(w http.ResponseWriter, r *http.Request) {
 		CourseId:      body.CourseId,
 		YearTaken:     body.YearTaken,
 		SemesterTaken: body.SemesterTaken,
+		TermId:        body.TermId,
 	})
 	if err != nil {
 		if errors.Is(err, util.ErrMalformed) {
@@ -255,7 +340,8 @@ func (u *userHandler) DeleteUserCourse(w http.ResponseWriter, r *http.Request) {
 
 type UpdateUserCourseReq struct {
 	YearTaken     nullable.Nullable[int16]  `json:"yearTaken,omitempty" swaggertype:"primitive,integer" extensions:"x-nullable"`
-	SemesterTaken nullable.Nullable[string] `json:"semesterTaken,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
+	SemesterTaken nullable.Nullable[string] `json:"semesterTaken,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"` // Without a term ID, the term is derived from the semester or cleared with it
+	TermId        nullable.Nullable[string] `json:"termId,omitempty" swaggertype:"primitive,string" extensions:"x-nullable"`
 } //@name UpdateUserCourseRequest
 
 // UpdateUserCourse updates a user's course information.
@@ -295,6 +381,7 @@ func (u *userHandler) UpdateUserCourse(w http.ResponseWriter, r *http.Request) {
 	err := u.userRepo.UpdateUserCourse(r.Context(), session.UserId, chi.URLParam(r, "courseId"), repository.UpdateUserCourse{
 		YearTaken:     body.YearTaken,
 		SemesterTaken: body.SemesterTaken,
+		TermId:        body.TermId,
 	})
 	if err != nil {
 		if errors.Is(err, util.ErrNotFound) {
@@ -318,9 +405,10 @@ type UserCourseRes struct {
 	Course        string                    `json:"course"`
 	YearTaken     nullable.Nullable[int16]  `json:"yearTaken" swaggertype:"primitive,integer" extensions:"x-nullable"`
 	SemesterTaken nullable.Nullable[string] `json:"semesterTaken" swaggertype:"primitive,integer" extensions:"x-nullable"`
+	TermId        nullable.Nullable[string] `json:"termId" swaggertype:"primitive,string" extensions:"x-nullable"`
 } //@name UserCourseResponse
 
-// ListUserCourses retrieves a paginated list of a user's courses.
+// ListUserCourses retrieves a paginated list of a user's courses, respecting the user's course visibility.
 // @Summary List user courses
 // @Tags User
 // @Param userId path string true "User ID"
@@ -329,32 +417,48 @@ type UserCourseRes struct {
 // @Param page query string false "Page number (default: 1)"
 // @Param size query string false "Page size (default: 25)"
 // @Success 200 {array} UserCourseResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
 // @Failure 500 {string} string
 // @Security Session
 // @Router /users/{userId}/courses [get]
 func (u *userHandler) ListUserCourses(w http.ResponseWriter, r *http.Request) {
-	sessionValue := r.Context().Value(config.AuthKey)
-	if sessionValue == nil {
-		u.log.Error("session middleware potential missing")
-		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+	session, _ := r.Context().Value(config.AuthKey).(SessionPayload)
+
+	userId := chi.URLParam(r, "userId")
+	user, audience, err := u.userRepo.GetUserProfile(r.Context(), session.UserId, userId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	query := r.URL.Query()
-	queryFilters := repository.CourseFilters{
-		Search:     query.Get("search"),
-		CategoryId: query.Get("category"),
+	userCourses := []UserCourseRes{}
+	if audience.CanView(user.CoursesVisibility) {
+		query := r.URL.Query()
+		userCourses, err = u.listUserCourses(r, userId, repository.CourseFilters{
+			Search:     query.Get("search"),
+			CategoryId: query.Get("category"),
+		}, util.NewPaginate(query.Get("page"), query.Get("size")))
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
 	}
 
-	courses, err := u.userRepo.ListUserCourses(
-		r.Context(),
-		chi.URLParam(r, "userId"),
-		queryFilters,
-		util.NewPaginate(query.Get("page"), query.Get("size")),
-	)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(userCourses)
+}
+
+func (u *userHandler) listUserCourses(r *http.Request, userId string, filters repository.CourseFilters, paginate util.Paginate) ([]UserCourseRes, error) {
+	courses, err := u.userRepo.ListUserCourses(r.Context(), userId, filters, paginate)
 	if err != nil {
-		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
 	userCourses := make([]UserCourseRes, 0, len(courses))
@@ -365,11 +469,89 @@ func (u *userHandler) ListUserCourses(w http.ResponseWriter, r *http.Request) {
 			Course:        course.Course,
 			YearTaken:     util.DefaultNullable(course.YearTaken.Valid, course.YearTaken.Int16),
 			SemesterTaken: util.DefaultNullable(course.SemesterTaken.Valid, course.SemesterTaken.String),
+			TermId:        util.DefaultNullable(course.TermId.Valid, course.TermId.String),
 		})
 	}
 
+	return userCourses, nil
+}
+
+type PublicProfileRes struct {
+	User       UserRes         `json:"user"`
+	TotalLikes int             `json:"totalLikes"`
+	Uploads    []SyllabusRes   `json:"uploads"`
+	Courses    []UserCourseRes `json:"courses"`
+} //@name PublicProfileResponse
+
+// GetUserByNickname resolves a shareable public profile from a user's nickname.
+// The profile includes the user's synced uploads, the total likes received on them
+// and the courses taken when the user's course visibility allows it.
+// @Summary Get a public profile
+// @Tags User
+// @Param nickname path string true "User nickname"
+// @Param page query int false "Uploads page number (default: 1)"
+// @Param size query int false "Uploads page size (default: 25)"
+// @Success 200 {object} PublicProfileResponse
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+//
+// Served at /users/@{nickname}, swag is unable to parse "@" within router paths.
+func (u *userHandler) GetUserByNickname(w http.ResponseWriter, r *http.Request) {
+	session, _ := r.Context().Value(config.AuthKey).(SessionPayload)
+
+	user, audience, err := u.userRepo.GetUserProfileByNickname(r.Context(), session.UserId, chi.URLParam(r, "nickname"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	query := r.URL.Query()
+	syllabi, err := u.syllabusRepo.ListSyllabi(r.Context(), session.UserId, repository.SyllabusFilters{
+		UserId:     user.Id,
+		SyncedOnly: true,
+	}, util.NewPaginate(query.Get("page"), query.Get("size")))
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	totalLikes, err := u.syllabusRepo.CountUserSyllabusLikes(r.Context(), user.Id)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	courses := []UserCourseRes{}
+	if audience.CanView(user.CoursesVisibility) {
+		// Courses taken is a small set, return all of them with the profile
+		courses, err = u.listUserCourses(r, user.Id, repository.CourseFilters{}, util.Paginate{Page: 1, Size: 100})
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	uploads := make([]SyllabusRes, 0, len(syllabi))
+	for _, syllabus := range syllabi {
+		uploads = append(uploads, newSyllabusRes(r.Context(), u.thumbnailPresigner, syllabus))
+	}
+	if err := setSyllabusReactions(r.Context(), u.syllabusRepo, session.UserId, uploads); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(userCourses)
+	json.NewEncoder(w).Encode(PublicProfileRes{
+		User:       u.newUserRes(r.Context(), user, audience),
+		TotalLikes: totalLikes,
+		Uploads:    uploads,
+		Courses:    courses,
+	})
 }
 
 type NicknameExistsRes struct {
@@ -405,3 +587,714 @@ func (u *userHandler) SearchUserNickname(w http.ResponseWriter, r *http.Request)
 		Exists: exists,
 	})
 }
+
+type UserLinkRes struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Link string `json:"link"`
+} //@name UserLinkResponse
+
+// ListUserLinks retrieves the links on a user's profile, respecting the user's link visibility.
+// @Summary List user links
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 200 {array} UserLinkResponse
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/links [get]
+func (u *userHandler) ListUserLinks(w http.ResponseWriter, r *http.Request) {
+	session, _ := r.Context().Value(config.AuthKey).(SessionPayload)
+
+	userId := chi.URLParam(r, "userId")
+	user, audience, err := u.userRepo.GetUserProfile(r.Context(), session.UserId, userId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	userLinks := []UserLinkRes{}
+	if audience.CanView(user.LinksVisibility) {
+		links, err := u.userRepo.ListUserLinks(r.Context(), userId)
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+
+		for _, link := range links {
+			userLinks = append(userLinks, UserLinkRes{
+				Id:   link.Id,
+				Type: link.Type,
+				Link: link.Link,
+			})
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(userLinks)
+}
+
+type UserLinkReq struct {
+	Type string `json:"type" validate:"required"`
+	Link string `json:"link" validate:"required"`
+} //@name UserLinkRequest
+
+// AddUserLink adds a link to a user's profile. GitHub, LinkedIn and Instagram links
+// accept either a full URL or a bare handle, all links are normalized before saving.
+// @Summary Add a user link
+// @Tags User
+// @Param userId path string true "User ID"
+// @Param body body UserLinkRequest true "Link data (type: GitHub, LinkedIn, Website, Instagram)"
+// @Success 201 {object} UserLinkResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 409 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/links [post]
+func (u *userHandler) AddUserLink(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	var body UserLinkReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	link, err := normalizeUserLink(body.Type, body.Link)
+	if err != nil {
+		http.Error(w, "Invalid link type or URL.", http.StatusBadRequest)
+		return
+	}
+
+	linkId, err := u.userRepo.AddUserLink(r.Context(), session.UserId, repository.InsertUserLink{
+		Type: body.Type,
+		Link: link,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrForbidden) {
+			http.Error(w, "You have reached the maximum number of profile links.", http.StatusForbidden)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "This link has already been added.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(UserLinkRes{
+		Id:   linkId,
+		Type: body.Type,
+		Link: link,
+	})
+}
+
+// UpdateUserLink replaces the type and URL of a user's link.
+// @Summary Update a user link
+// @Tags User
+// @Param userId path string true "User ID"
+// @Param linkId path string true "Link ID"
+// @Param body body UserLinkRequest true "Link data (type: GitHub, LinkedIn, Website, Instagram)"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 409 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/links/{linkId} [put]
+func (u *userHandler) UpdateUserLink(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	var body UserLinkReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	link, err := normalizeUserLink(body.Type, body.Link)
+	if err != nil {
+		http.Error(w, "Invalid link type or URL.", http.StatusBadRequest)
+		return
+	}
+
+	err = u.userRepo.UpdateUserLink(r.Context(), session.UserId, chi.URLParam(r, "linkId"), repository.InsertUserLink{
+		Type: body.Type,
+		Link: link,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Link not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else if errors.Is(err, util.ErrConflict) {
+			http.Error(w, "This link has already been added.", http.StatusConflict)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteUserLink removes a link from a user's profile.
+// @Summary Delete a user link
+// @Tags User
+// @Param userId path string true "User ID"
+// @Param linkId path string true "Link ID"
+// @Success 204 {string} string
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/links/{linkId} [delete]
+func (u *userHandler) DeleteUserLink(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	err := u.userRepo.DeleteUserLink(r.Context(), session.UserId, chi.URLParam(r, "linkId"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "Link not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Malformed request data.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const maxUserLinkLength = 2048
+
+// linkProviders maps profile based link types to their canonical host and handle path prefix.
+var linkProviders = map[string]struct {
+	host       string
+	handlePath string
+}{
+	repository.LinkGitHub:    {host: "github.com", handlePath: "/"},
+	repository.LinkLinkedIn:  {host: "www.linkedin.com", handlePath: "/in/"},
+	repository.LinkInstagram: {host: "www.instagram.com", handlePath: "/"},
+}
+
+var linkHandleRegex = regexp.MustCompile(`^@?[A-Za-z0-9._-]{1,100}$`)
+
+// normalizeUserLink validates a link against its type and returns it in canonical form.
+// Provider links are forced to https on the provider's canonical host with query and fragment removed.
+func normalizeUserLink(linkType string, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || len(raw) > maxUserLinkLength {
+		return "", util.ErrMalformed
+	}
+
+	provider, isProvider := linkProviders[linkType]
+	if !isProvider && linkType != repository.LinkWebsite {
+		return "", util.ErrMalformed
+	}
+
+	// Bare handles such as "@syllabye" are expanded to the provider's profile URL
+	providerDomain := strings.TrimPrefix(provider.host, "www.")
+	if isProvider && !strings.Contains(raw, "/") && !strings.Contains(strings.ToLower(raw), providerDomain) {
+		if !linkHandleRegex.MatchString(raw) {
+			return "", util.ErrMalformed
+		}
+		return "https://" + provider.host + provider.handlePath + strings.TrimPrefix(raw, "@"), nil
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	link, err := url.Parse(raw)
+	if err != nil {
+		return "", util.ErrMalformed
+	}
+
+	link.Scheme = strings.ToLower(link.Scheme)
+	if (link.Scheme != "http" && link.Scheme != "https") || link.Hostname() == "" || link.User != nil {
+		return "", util.ErrMalformed
+	}
+	link.Host = strings.ToLower(link.Host)
+	link.Fragment = ""
+
+	if isProvider {
+		if strings.TrimPrefix(link.Hostname(), "www.") != providerDomain {
+			return "", util.ErrMalformed
+		}
+
+		link.Scheme = "https"
+		link.Host = provider.host
+		link.RawQuery = ""
+		link.Path = strings.TrimRight(link.Path, "/")
+		link.RawPath = ""
+		if link.Path == "" {
+			return "", util.ErrMalformed
+		}
+	} else if link.Path == "/" {
+		link.Path = ""
+	}
+
+	return link.String(), nil
+}
+
+const (
+	maxAvatarFileSize = 5 * 1024 * 1024 // 5 MB
+	avatarUploadSecs  = 60 * 10
+	avatarUrlSecs     = 60 * 60
+)
+
+// avatarFormats maps accepted avatar content types to their decoded image format.
+var avatarFormats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+var avatarSizes = []struct {
+	name string
+	size int
+}{
+	{"small", 64},
+	{"medium", 128},
+	{"large", 256},
+}
+
+type UploadAvatarReq struct {
+	ContentType string `json:"contentType" validate:"required"`
+	FileSize    int    `json:"fileSize" validate:"required"`
+	Checksum    string `json:"checksum" validate:"required"`
+} //@name UploadAvatarRequest
+
+// UploadAvatar returns a presigned URL to upload a new avatar image. Once uploaded,
+// the avatar must be confirmed with a PUT request to be resized and applied.
+// @Summary Upload an avatar
+// @Tags User
+// @Param userId path string true "User ID"
+// @Param body body UploadAvatarRequest true "Avatar file data (jpeg, png or webp up to 5 MB)"
+// @Success 201 {string} string
+// @Header 201 {string} X-Presigned-Url "Presigned URL to upload the avatar file"
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/avatar [post]
+func (u *userHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	var body UploadAvatarReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body.", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := avatarFormats[body.ContentType]; !ok {
+		http.Error(w, "Avatars must be a jpeg, png or webp image.", http.StatusBadRequest)
+		return
+	}
+	if body.FileSize <= 0 || body.FileSize > maxAvatarFileSize {
+		http.Error(w, "Avatars must be 5 MB or smaller.", http.StatusBadRequest)
+		return
+	}
+	if body.Checksum == "" {
+		http.Error(w, "Invalid or missing request body fields.", http.StatusBadRequest)
+		return
+	}
+
+	signedUrl, err := u.avatarPresigner.PutObject(r.Context(), avatarUploadKey(userId), body.ContentType, body.Checksum, avatarUploadSecs)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("X-Presigned-Url", signedUrl)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ConfirmAvatar validates the uploaded avatar, resizes it to the standard sizes and applies it to the user's profile.
+// @Summary Confirm an avatar upload
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 200 {object} UserAvatarResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/avatar [put]
+func (u *userHandler) ConfirmAvatar(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	user, err := u.userRepo.GetUser(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	uploadKey := avatarUploadKey(userId)
+	object, info, err := u.avatarObject.GetObject(r.Context(), uploadKey)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "No avatar upload was found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+	defer object.Close()
+
+	// Read one byte past the limit to detect oversized uploads without trusting the object metadata
+	data, err := io.ReadAll(io.LimitReader(object, maxAvatarFileSize+1))
+	if err != nil {
+		u.log.Error("failed to read avatar upload", logger.Err(err))
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+	if len(data) > maxAvatarFileSize {
+		u.avatarObject.DeleteObject(r.Context(), uploadKey)
+		http.Error(w, "Avatars must be 5 MB or smaller.", http.StatusBadRequest)
+		return
+	}
+
+	img, format, err := imaging.DecodeImage(data)
+	if err != nil || avatarFormats[info.ContentType] != format {
+		u.avatarObject.DeleteObject(r.Context(), uploadKey)
+		http.Error(w, "Avatars must be a valid jpeg, png or webp image.", http.StatusBadRequest)
+		return
+	}
+
+	avatarKey := fmt.Sprintf("avatars/%s/%s", userId, uuid.New().String())
+	for _, avatarSize := range avatarSizes {
+		resized, err := imaging.SquareJpeg(img, avatarSize.size)
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+
+		err = u.avatarObject.PutObject(r.Context(), avatarObjectKey(avatarKey, avatarSize.size), "image/jpeg", bytes.NewReader(resized))
+		if err != nil {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := u.userRepo.SetUserAvatar(r.Context(), userId, &avatarKey); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	// Clean up is best effort, failures are logged by the object client
+	u.avatarObject.DeleteObject(r.Context(), uploadKey)
+	if user.AvatarKey.Valid {
+		u.deleteAvatarObjects(r.Context(), user.AvatarKey.String)
+	}
+
+	avatar, err := u.avatarUrls(r.Context(), avatarKey)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(avatar)
+}
+
+// DeleteAvatar removes a user's uploaded avatar, reverting to their OpenID picture.
+// @Summary Delete an avatar
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 204 {string} string
+// @Failure 403 {string} string
+// @Failure 404 {string} string
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/avatar [delete]
+func (u *userHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	userId := chi.URLParam(r, "userId")
+	if userId != session.UserId {
+		http.Error(w, "You're not allowed to modify another user's profile.", http.StatusForbidden)
+		return
+	}
+
+	user, err := u.userRepo.GetUser(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !user.AvatarKey.Valid {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := u.userRepo.SetUserAvatar(r.Context(), userId, nil); err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+	u.deleteAvatarObjects(r.Context(), user.AvatarKey.String)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// avatarUrls resolves URLs for each avatar size, using the avatar CDN when configured and presigned URLs otherwise.
+func (u *userHandler) avatarUrls(ctx context.Context, avatarKey string) (UserAvatarRes, error) {
+	urls := make(map[string]string, len(avatarSizes))
+	for _, avatarSize := range avatarSizes {
+		objectKey := avatarObjectKey(avatarKey, avatarSize.size)
+
+		if cdn := os.Getenv(config.AWS_S3_AVATAR_CDN_URL); cdn != "" {
+			urls[avatarSize.name] = strings.TrimRight(cdn, "/") + "/" + objectKey
+			continue
+		}
+
+		signedUrl, err := u.avatarPresigner.GetObject(ctx, objectKey, avatarUrlSecs)
+		if err != nil {
+			return UserAvatarRes{}, err
+		}
+		urls[avatarSize.name] = signedUrl
+	}
+
+	return UserAvatarRes{
+		Small:  urls["small"],
+		Medium: urls["medium"],
+		Large:  urls["large"],
+	}, nil
+}
+
+func (u *userHandler) deleteAvatarObjects(ctx context.Context, avatarKey string) {
+	for _, avatarSize := range avatarSizes {
+		u.avatarObject.DeleteObject(ctx, avatarObjectKey(avatarKey, avatarSize.size))
+	}
+}
+
+// avatarUploadKey is the object key of a user's pending avatar upload, a new upload replaces the previous.
+func avatarUploadKey(userId string) string {
+	return "uploads/" + userId
+}
+
+func avatarObjectKey(avatarKey string, size int) string {
+	return avatarKey + "/" + strconv.Itoa(size) + ".jpg"
+}
+
+type ProgressCourseRes struct {
+	CourseId string  `json:"courseId"`
+	Course   string  `json:"course"`
+	Title    string  `json:"title"`
+	Credits  float64 `json:"credits"`
+} //@name ProgressCourseResponse
+
+type RequirementProgressRes struct {
+	Requirement       ProgramRequirementRes  `json:"requirement"`
+	Status            string                 `json:"status"` // Completed, InProgress, Unknown if only met counting courses without a term, or Missing
+	CreditsCompleted  float64                `json:"creditsCompleted"`
+	CreditsInProgress float64                `json:"creditsInProgress"`
+	CreditsUnknown    float64                `json:"creditsUnknown"`
+	Completed         []ProgressCourseRes    `json:"completed"`
+	InProgress        []ProgressCourseRes    `json:"inProgress"`
+	Unknown           []ProgressCourseRes    `json:"unknown"` // Courses without a term, which may or may not be completed
+	Missing           []RequirementCourseRes `json:"missing"` // Courses of Required requirements which were not taken
+} //@name RequirementProgressResponse
+
+type ProgramProgressRes struct {
+	ProgramId         string                   `json:"programId"`
+	Status            string                   `json:"status"` // Completed once every requirement is completed, NoRequirements if the program has none
+	CreditsCompleted  float64                  `json:"creditsCompleted"`
+	CreditsInProgress float64                  `json:"creditsInProgress"`
+	CreditsUnknown    float64                  `json:"creditsUnknown"`
+	Requirements      []RequirementProgressRes `json:"requirements"`
+} //@name ProgramProgressResponse
+
+func newProgressCourseRes(courses []repository.ProgressCourseSchema) []ProgressCourseRes {
+	res := make([]ProgressCourseRes, 0, len(courses))
+	for _, course := range courses {
+		res = append(res, ProgressCourseRes{
+			CourseId: course.CourseId,
+			Course:   course.Course,
+			Title:    course.Title,
+			Credits:  course.Credits,
+		})
+	}
+
+	return res
+}
+
+// GetUserProgress evaluates a user's courses against their program's requirements.
+// @Summary Get degree progress
+// @Description Courses taken in a term which has not ended are in progress, courses without a term are reported as unknown until their term is set.
+// @Description A course counts towards at most one Required or Elective requirement, Credits requirements count every matching course.
+// @Tags User
+// @Param userId path string true "User ID"
+// @Success 200 {object} ProgramProgressResponse
+// @Failure 400 {string} string
+// @Failure 403 {string} string "The user's courses are not visible"
+// @Failure 404 {string} string
+// @Failure 409 {string} string "The user has not set a program"
+// @Failure 500 {string} string
+// @Security Session
+// @Router /users/{userId}/progress [get]
+func (u *userHandler) GetUserProgress(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(config.AuthKey).(SessionPayload)
+	if !ok {
+		u.log.Error("session middleware potential missing")
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	user, audience, err := u.userRepo.GetUserProfile(r.Context(), session.UserId, chi.URLParam(r, "userId"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotFound) {
+			http.Error(w, "User not found.", http.StatusNotFound)
+		} else if errors.Is(err, util.ErrMalformed) {
+			http.Error(w, "Invalid user ID.", http.StatusBadRequest)
+		} else {
+			http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		}
+		return
+	}
+	if !audience.CanView(user.CoursesVisibility) {
+		http.Error(w, "You're not allowed to view this user's courses.", http.StatusForbidden)
+		return
+	}
+	if !user.ProgramId.Valid {
+		http.Error(w, "The user has not set a program.", http.StatusConflict)
+		return
+	}
+
+	requirements, err := u.requirementRepo.ListProgramRequirements(r.Context(), user.ProgramId.String)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := u.requirementRepo.ListProgressCourses(r.Context(), user.Id)
+	if err != nil {
+		http.Error(w, "An internal error occurred.", http.StatusInternalServerError)
+		return
+	}
+
+	res := ProgramProgressRes{
+		ProgramId:    user.ProgramId.String,
+		Status:       repository.ProgressCompleted,
+		Requirements: []RequirementProgressRes{},
+	}
+	for _, course := range courses {
+		switch course.Status {
+		case repository.ProgressCompleted:
+			res.CreditsCompleted += course.Credits
+		case repository.ProgressInProgress:
+			res.CreditsInProgress += course.Credits
+		default:
+			res.CreditsUnknown += course.Credits
+		}
+	}
+	if len(requirements) == 0 {
+		res.Status = repository.ProgressNoRequirements
+	}
+	for _, progress := range repository.EvaluateProgramProgress(requirements, courses) {
+		missing := make([]RequirementCourseRes, 0, len(progress.Missing))
+		for _, course := range progress.Missing {
+			missing = append(missing, newRequirementCourseRes(course))
+		}
+
+		res.Requirements = append(res.Requirements, RequirementProgressRes{
+			Requirement:       newProgramRequirementRes(progress.Requirement),
+			Status:            progress.Status,
+			CreditsCompleted:  progress.CreditsCompleted,
+			CreditsInProgress: progress.CreditsInProgress,
+			CreditsUnknown:    progress.CreditsUnknown,
+			Completed:         newProgressCourseRes(progress.Completed),
+			InProgress:        newProgressCourseRes(progress.InProgress),
+			Unknown:           newProgressCourseRes(progress.Unknown),
+			Missing:           missing,
+		})
+
+		if repository.ProgressOutranks(progress.Status, res.Status) {
+			res.Status = progress.Status
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
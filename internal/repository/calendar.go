@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CalendarSchema holds a syllabus' assessments along with the course they belong to.
+type CalendarSchema struct {
+	SyllabusId   string
+	CourseId     string
+	Course       string
+	Year         int16
+	Semester     string
+	Details      extractor.SyllabusDetails
+	DateModified time.Time
+}
+
+type CalendarRepository interface {
+	// GetSyllabusCalendar reads the assessments of a syllabus visible to the user.
+	GetSyllabusCalendar(ctx context.Context, userId string, syllabusId string) (CalendarSchema, error)
+	// ListTermCalendars reads the assessments of the user's courses taken in the term, using the most complete syllabus of each course.
+	// Courses and syllabi of overlapping terms are included, so Spring/Summer courses are listed in the Spring term. [CurrentTerm] resolves to the current term.
+	ListTermCalendars(ctx context.Context, userId string, termId string) ([]CalendarSchema, error)
+	// SetCalendarToken replaces the user's calendar feed token.
+	SetCalendarToken(ctx context.Context, userId string, tokenHash string) error
+	DeleteCalendarToken(ctx context.Context, userId string) error
+	// VerifyCalendarToken returns ErrNotFound unless the token belongs to the user.
+	VerifyCalendarToken(ctx context.Context, userId string, tokenHash string) error
+}
+
+type pgCalendarRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgCalendarRepository(db *database.PostgresDb, log logger.Logger) *pgCalendarRepository {
+	return &pgCalendarRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const calendarColumns = "s.id, s.course_id, c.course, s.year, s.semester, d.details, d.date_modified"
+
+func scanCalendar(row pgx.Row, calendar *CalendarSchema) error {
+	return row.Scan(
+		&calendar.SyllabusId,
+		&calendar.CourseId,
+		&calendar.Course,
+		&calendar.Year,
+		&calendar.Semester,
+		&calendar.Details,
+		&calendar.DateModified,
+	)
+}
+
+func (c *pgCalendarRepository) GetSyllabusCalendar(ctx context.Context, userId string, syllabusId string) (CalendarSchema, error) {
+	result, err := c.getSyllabusCalendarQuery(userId, syllabusId)
+	if err != nil {
+		return CalendarSchema{}, err
+	}
+
+	calendar := CalendarSchema{}
+	err = scanCalendar(c.db.Pool.QueryRow(ctx, result.Query, result.Args...), &calendar)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CalendarSchema{}, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled get syllabus calendar query error", logger.Err(err))
+		return CalendarSchema{}, util.ErrInternal
+	}
+
+	return calendar, nil
+}
+
+func (c *pgCalendarRepository) getSyllabusCalendarQuery(userId string, syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select " + calendarColumns + " from syllabi s")
+	qb.Concat("inner join courses c on c.id = s.course_id")
+	qb.Concat("inner join syllabus_details d on d.syllabus_id = s.id")
+	qb.Concat("where s.id = $%d", syllabusUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+
+	return qb.Result(), nil
+}
+
+func (c *pgCalendarRepository) ListTermCalendars(ctx context.Context, userId string, termId string) ([]CalendarSchema, error) {
+	result, err := c.listTermCalendarsQuery(userId, termId)
+	if err != nil {
+		return []CalendarSchema{}, err
+	}
+
+	rows, err := c.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled list term calendars query error", logger.Err(err))
+		return []CalendarSchema{}, util.ErrInternal
+	}
+	defer rows.Close()
+
+	calendars := []CalendarSchema{}
+	for rows.Next() {
+		calendar := CalendarSchema{}
+		if err := scanCalendar(rows, &calendar); err != nil {
+			c.log.Error("scan term calendar error", logger.Err(err))
+			return []CalendarSchema{}, util.ErrInternal
+		}
+
+		calendars = append(calendars, calendar)
+	}
+
+	return calendars, nil
+}
+
+func (c *pgCalendarRepository) listTermCalendarsQuery(userId string, termId string) (util.SqlBuilderResult, error) {
+	// Sections of a course share assessments, only the most complete syllabus of each course is used
+	qb := util.NewSqlBuilder("select distinct on (s.course_id) " + calendarColumns + " from user_courses uc")
+	qb.Concat("left join terms ut on ut.id = uc.term_id")
+	qb.Concat("inner join syllabi s on s.course_id = uc.course_id")
+	qb.Concat("inner join terms st on st.id = s.term_id")
+	qb.Concat("inner join courses c on c.id = s.course_id")
+	qb.Concat("inner join syllabus_details d on d.syllabus_id = s.id")
+	qb.Concat("cross join terms t")
+	qb.Concat("where uc.user_id = $%d", userId)
+	if err := concatTermId(qb, "t.id", termId); err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	// Courses whose semester taken has no term fall back to matching the term's semester
+	qb.Concat("and (ut.date_start <= t.date_end and ut.date_end >= t.date_start or ut.id is null and uc.semester_taken = t.semester)")
+	qb.Concat("and st.date_start <= t.date_end and st.date_end >= t.date_start")
+	qb.Concat("and s.status = $%d", SyllabusPublished)
+	qb.Concat("order by s.course_id, d.edited desc, d.confidence desc, s.date_added desc")
+
+	return qb.Result(), nil
+}
+
+func (c *pgCalendarRepository) SetCalendarToken(ctx context.Context, userId string, tokenHash string) error {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("insert into user_calendar_tokens (user_id, token_hash)")
+	qb.Concat("values ($%d, $%d)", userUuid, tokenHash)
+	qb.Concat("on conflict (user_id) do update set token_hash = excluded.token_hash, date_added = now()")
+	result := qb.Result()
+
+	_, err = c.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			return util.ErrNotFound
+		}
+
+		c.log.Error("un-handled set calendar token query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	c.log.Info(fmt.Sprintf("user %s calendar token set", userId))
+	return nil
+}
+
+func (c *pgCalendarRepository) DeleteCalendarToken(ctx context.Context, userId string) error {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from user_calendar_tokens")
+	qb.Concat("where user_id = $%d", userUuid)
+	result := qb.Result()
+
+	tag, err := c.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled delete calendar token query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	c.log.Info(fmt.Sprintf("user %s calendar token deleted", userId))
+	return nil
+}
+
+func (c *pgCalendarRepository) VerifyCalendarToken(ctx context.Context, userId string, tokenHash string) error {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("select exists (select 1 from user_calendar_tokens")
+	qb.Concat("where user_id = $%d and token_hash = $%d)", userUuid, tokenHash)
+	result := qb.Result()
+
+	var exists bool
+	err = c.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&exists)
+	if err != nil {
+		c.log.Error("un-handled verify calendar token query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if !exists {
+		return util.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Approval status values, matching the approval_status database enum.
+const (
+	ApprovalPending  = "Pending"
+	ApprovalApproved = "Approved"
+	ApprovalRejected = "Rejected"
+)
+
+type InstructorClaimSchema struct {
+	Id           string
+	InstructorId string
+	Instructor   string
+	UserId       string
+	UserName     string
+	UserEmail    string
+	Message      sql.NullString
+	Status       string
+	ReviewedBy   sql.NullString
+	ReviewReason sql.NullString
+	DateReviewed sql.NullTime
+	DateAdded    time.Time
+}
+
+type ClaimRepository interface {
+	// ListInstructorClaims lists claims oldest first, claims of every status are listed when status is empty.
+	ListInstructorClaims(ctx context.Context, status string, paginate util.Paginate) ([]InstructorClaimSchema, error)
+	// CreateInstructorClaim claims an instructor profile for the user pending admin approval.
+	// Returns [util.ErrConflict] if the instructor or user is already verified, or the user has a pending claim for the instructor.
+	CreateInstructorClaim(ctx context.Context, userId string, instructorId string, message *string) (InstructorClaimSchema, error)
+	// ReviewInstructorClaim approves or rejects a pending claim.
+	// Approving verifies the user as the instructor and rejects the instructor's other pending claims.
+	ReviewInstructorClaim(ctx context.Context, claimId string, reviewerId string, status string, reason string) (InstructorClaimSchema, error)
+}
+
+type pgClaimRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgClaimRepository(db *database.PostgresDb, log logger.Logger) *pgClaimRepository {
+	return &pgClaimRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// selectInstructorClaimsQuery starts a query of claims as ic.
+func selectInstructorClaimsQuery() *util.SqlBuilder {
+	return util.NewSqlBuilder(
+		"select ic.id, ic.instructor_id, i.name, ic.user_id, u.full_name, u.email, ic.message, ic.status,",
+		"ic.reviewed_by, ic.review_reason, ic.date_reviewed, ic.date_added",
+		"from instructor_claims ic",
+		"inner join instructors i on i.id = ic.instructor_id",
+		"inner join users u on u.id = ic.user_id",
+	)
+}
+
+func scanInstructorClaim(row pgx.Row, claim *InstructorClaimSchema) error {
+	return row.Scan(
+		&claim.Id,
+		&claim.InstructorId,
+		&claim.Instructor,
+		&claim.UserId,
+		&claim.UserName,
+		&claim.UserEmail,
+		&claim.Message,
+		&claim.Status,
+		&claim.ReviewedBy,
+		&claim.ReviewReason,
+		&claim.DateReviewed,
+		&claim.DateAdded,
+	)
+}
+
+func (c *pgClaimRepository) ListInstructorClaims(ctx context.Context, status string, paginate util.Paginate) ([]InstructorClaimSchema, error) {
+	qb := selectInstructorClaimsQuery()
+	if status != "" {
+		qb.Concat("where ic.status = $%d", status)
+	}
+	qb.Concat("order by ic.date_added, ic.id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := c.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		c.log.Error("un-handled list instructor claims query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	claims := []InstructorClaimSchema{}
+	for rows.Next() {
+		var claim InstructorClaimSchema
+		if err := scanInstructorClaim(rows, &claim); err != nil {
+			c.log.Error("failed to scan instructor claim row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		claims = append(claims, claim)
+	}
+
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		c.log.Error("list instructor claims rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return claims, nil
+}
+
+func (c *pgClaimRepository) CreateInstructorClaim(ctx context.Context, userId string, instructorId string, message *string) (InstructorClaimSchema, error) {
+	instructorUuid, err := database.ParsePgUuid(instructorId)
+	if err != nil {
+		return InstructorClaimSchema{}, err
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder("select exists (select 1 from instructors")
+	qb.Concat("where verified_user_id = $%d or (id = $%d and verified_user_id is not null))", userId, instructorUuid)
+	verifiedResult := qb.Result()
+
+	var verified bool
+	if err := tx.QueryRow(ctx, verifiedResult.Query, verifiedResult.Args...).Scan(&verified); err != nil {
+		c.log.Error("un-handled instructor verified query error", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+	if verified {
+		return InstructorClaimSchema{}, util.ErrConflict
+	}
+
+	qb = util.NewSqlBuilder("insert into instructor_claims (instructor_id, user_id, message)")
+	qb.Concat("values ($%d, $%d, $%d)", instructorUuid, userId, message)
+	qb.Concat("returning id")
+	insertResult := qb.Result()
+
+	var claimUuid pgtype.UUID
+	err = tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&claimUuid)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgFKeyViolationErrCode {
+				return InstructorClaimSchema{}, util.ErrNotFound
+			} else if pgErr.Code == database.PgConflictErrCode {
+				return InstructorClaimSchema{}, util.ErrConflict
+			}
+		}
+
+		c.log.Error("un-handled create instructor claim query error", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+
+	qb = selectInstructorClaimsQuery()
+	qb.Concat("where ic.id = $%d", claimUuid)
+	getResult := qb.Result()
+
+	created := InstructorClaimSchema{}
+	if err := scanInstructorClaim(tx.QueryRow(ctx, getResult.Query, getResult.Args...), &created); err != nil {
+		c.log.Error("un-handled get instructor claim query error", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+
+	c.log.Info(fmt.Sprintf("user %s claimed instructor %s", userId, instructorId))
+	return created, nil
+}
+
+func (c *pgClaimRepository) ReviewInstructorClaim(ctx context.Context, claimId string, reviewerId string, status string, reason string) (InstructorClaimSchema, error) {
+	if status != ApprovalApproved && status != ApprovalRejected {
+		return InstructorClaimSchema{}, util.ErrMalformed
+	}
+
+	claimUuid, err := database.ParsePgUuid(claimId)
+	if err != nil {
+		return InstructorClaimSchema{}, err
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := selectInstructorClaimsQuery()
+	qb.Concat("where ic.id = $%d", claimUuid)
+	qb.Concat("for update of ic")
+	lockResult := qb.Result()
+
+	claim := InstructorClaimSchema{}
+	err = scanInstructorClaim(tx.QueryRow(ctx, lockResult.Query, lockResult.Args...), &claim)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return InstructorClaimSchema{}, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled lock instructor claim query error", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+	if claim.Status != ApprovalPending {
+		return InstructorClaimSchema{}, util.ErrConflict
+	}
+
+	var reviewReason *string
+	if reason != "" {
+		reviewReason = &reason
+	}
+
+	if status == ApprovalApproved {
+		qb = util.NewSqlBuilder()
+		qb.Concat("update instructors set verified_user_id = $%d, date_verified = now()", claim.UserId)
+		qb.Concat("where id = $%d and verified_user_id is null", claim.InstructorId)
+		verifyResult := qb.Result()
+
+		tag, err := tx.Exec(ctx, verifyResult.Query, verifyResult.Args...)
+		if err != nil {
+			// The user was verified as another instructor after claiming this one
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+				return InstructorClaimSchema{}, util.ErrConflict
+			}
+
+			c.log.Error("un-handled verify instructor query error", logger.Err(err))
+			return InstructorClaimSchema{}, util.ErrInternal
+		}
+		if tag.RowsAffected() == 0 {
+			return InstructorClaimSchema{}, util.ErrConflict
+		}
+
+		qb = util.NewSqlBuilder()
+		qb.Concat("update instructor_claims set status = $%d, reviewed_by = $%d, review_reason = $%d, date_reviewed = now()",
+			ApprovalRejected, reviewerId, "The instructor profile was verified for another user.")
+		qb.Concat("where instructor_id = $%d and status = $%d and id <> $%d", claim.InstructorId, ApprovalPending, claimUuid)
+		rejectResult := qb.Result()
+
+		if _, err := tx.Exec(ctx, rejectResult.Query, rejectResult.Args...); err != nil {
+			c.log.Error("un-handled reject instructor claims query error", logger.Err(err))
+			return InstructorClaimSchema{}, util.ErrInternal
+		}
+	}
+
+	qb = util.NewSqlBuilder()
+	qb.Concat("update instructor_claims set status = $%d, reviewed_by = $%d, review_reason = $%d, date_reviewed = now()", status, reviewerId, reviewReason)
+	qb.Concat("where id = $%d", claimUuid)
+	qb.Concat("returning reviewed_by, date_reviewed")
+	reviewResult := qb.Result()
+
+	err = tx.QueryRow(ctx, reviewResult.Query, reviewResult.Args...).Scan(&claim.ReviewedBy, &claim.DateReviewed)
+	if err != nil {
+		c.log.Error("un-handled review instructor claim query error", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return InstructorClaimSchema{}, util.ErrInternal
+	}
+
+	claim.Status = status
+	claim.ReviewReason = sql.NullString{String: reason, Valid: reviewReason != nil}
+	c.log.Info(fmt.Sprintf("instructor claim %s %s by %s", claimId, status, reviewerId))
+	return claim, nil
+}
@@ -0,0 +1,619 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oapi-codegen/nullable"
+)
+
+// Collection visibility values, matching the collection_visibility database enum.
+const (
+	CollectionPrivate = "Private"
+	CollectionShared  = "Shared"
+)
+
+// DefaultCollectionName names the collection populated by bookmarks, it is created on the user's first bookmark.
+const DefaultCollectionName = "Saved"
+
+type CollectionSchema struct {
+	Id            string
+	UserId        string
+	Name          string
+	Description   sql.NullString
+	Visibility    string
+	ShareToken    string
+	IsDefault     bool
+	SyllabusCount int
+	DateAdded     time.Time
+	DateModified  time.Time
+}
+
+type InsertCollection struct {
+	Name        string
+	Description *string
+	Visibility  string
+}
+
+type UpdateCollection struct {
+	Name        nullable.Nullable[string]
+	Description nullable.Nullable[string]
+	Visibility  nullable.Nullable[string]
+}
+
+type CollectionRepository interface {
+	ListUserCollections(ctx context.Context, userId string) ([]CollectionSchema, error)
+	GetUserCollection(ctx context.Context, userId string, collectionId string) (CollectionSchema, error)
+	// GetSharedCollection reads a collection by its share token, returning [util.ErrNotFound] unless it is shared.
+	GetSharedCollection(ctx context.Context, shareToken string) (CollectionSchema, error)
+	// ListCollectionSyllabi lists the syllabi of a collection visible to the viewer in the collection's order.
+	ListCollectionSyllabi(ctx context.Context, viewerId string, collectionId string) ([]SyllabusSchema, error)
+	CreateCollection(ctx context.Context, userId string, entity InsertCollection) (CollectionSchema, error)
+	// UpdateCollection updates the user's collection, returning [util.ErrForbidden] when renaming the default collection.
+	// Making a collection private replaces its share token, so links shared before stop working.
+	UpdateCollection(ctx context.Context, userId string, collectionId string, entity UpdateCollection) (CollectionSchema, error)
+	// DeleteCollection deletes the user's collection, returning [util.ErrForbidden] for the default collection.
+	DeleteCollection(ctx context.Context, userId string, collectionId string) error
+	// AddCollectionSyllabus appends a syllabus visible to the user to their collection, returning [util.ErrConflict] if it is already added.
+	AddCollectionSyllabus(ctx context.Context, userId string, collectionId string, syllabusId string) error
+	RemoveCollectionSyllabus(ctx context.Context, userId string, collectionId string, syllabusId string) error
+	// ReorderCollectionSyllabi orders the user's collection, returning [util.ErrMalformed] unless every syllabus in the collection
+	// visible to the user is given once. Syllabi no longer visible keep their order after the given syllabi.
+	ReorderCollectionSyllabi(ctx context.Context, userId string, collectionId string, syllabusIds []string) error
+	// BookmarkSyllabus adds a syllabus to the user's default collection, creating the collection if needed.
+	// Bookmarking a bookmarked syllabus has no effect.
+	BookmarkSyllabus(ctx context.Context, userId string, syllabusId string) error
+	// RemoveBookmark removes a syllabus from the user's default collection.
+	RemoveBookmark(ctx context.Context, userId string, syllabusId string) error
+}
+
+type pgCollectionRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgCollectionRepository(db *database.PostgresDb, log logger.Logger) *pgCollectionRepository {
+	return &pgCollectionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// collectionColumns selects a [CollectionSchema], its placeholder takes [SyllabusPublished].
+// Syllabi are counted when they are visible to the collection's owner, matching what they can list and reorder.
+const collectionColumns = "c.id, c.user_id, c.name, c.description, c.visibility, c.share_token, c.is_default, " +
+	"(select count(*) from collection_syllabi cs inner join syllabi s on s.id = cs.syllabus_id " +
+	"where cs.collection_id = c.id and (s.status = $%d or s.user_id = c.user_id)), c.date_added, c.date_modified"
+
+func scanCollection(row pgx.Row, collection *CollectionSchema) error {
+	return row.Scan(
+		&collection.Id,
+		&collection.UserId,
+		&collection.Name,
+		&collection.Description,
+		&collection.Visibility,
+		&collection.ShareToken,
+		&collection.IsDefault,
+		&collection.SyllabusCount,
+		&collection.DateAdded,
+		&collection.DateModified,
+	)
+}
+
+func (c *pgCollectionRepository) ListUserCollections(ctx context.Context, userId string) ([]CollectionSchema, error) {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+collectionColumns+" from collections c", SyllabusPublished)
+	qb.Concat("where c.user_id = $%d", userId)
+	qb.Concat("order by c.is_default desc, c.date_added")
+	result := qb.Result()
+
+	rows, err := c.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled list collections query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	collections := []CollectionSchema{}
+	for rows.Next() {
+		var collection CollectionSchema
+		if err := scanCollection(rows, &collection); err != nil {
+			c.log.Error("failed to scan collection row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		c.log.Error("list collections rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return collections, nil
+}
+
+func (c *pgCollectionRepository) GetUserCollection(ctx context.Context, userId string, collectionId string) (CollectionSchema, error) {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return CollectionSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+collectionColumns+" from collections c", SyllabusPublished)
+	qb.Concat("where c.id = $%d and c.user_id = $%d", collectionUuid, userId)
+
+	return c.getCollection(ctx, qb.Result())
+}
+
+func (c *pgCollectionRepository) GetSharedCollection(ctx context.Context, shareToken string) (CollectionSchema, error) {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+collectionColumns+" from collections c", SyllabusPublished)
+	qb.Concat("where c.share_token = $%d and c.visibility = $%d", shareToken, CollectionShared)
+
+	return c.getCollection(ctx, qb.Result())
+}
+
+func (c *pgCollectionRepository) getCollection(ctx context.Context, result util.SqlBuilderResult) (CollectionSchema, error) {
+	collection := CollectionSchema{}
+	err := scanCollection(c.db.Pool.QueryRow(ctx, result.Query, result.Args...), &collection)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CollectionSchema{}, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled get collection query error", logger.Err(err))
+		return CollectionSchema{}, util.ErrInternal
+	}
+
+	return collection, nil
+}
+
+func (c *pgCollectionRepository) ListCollectionSyllabi(ctx context.Context, viewerId string, collectionId string) ([]SyllabusSchema, error) {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syllabi are wrapped so the columns match syllabusColumns without colliding with the collection's columns
+	qb := util.NewSqlBuilder(
+		"select "+syllabusColumns+" from (",
+		"select s.*, cs.position from collection_syllabi cs",
+		"inner join syllabi s on s.id = cs.syllabus_id",
+	)
+	qb.Concat("where cs.collection_id = $%d", collectionUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, viewerId)
+	qb.Concat(") s order by position")
+	result := qb.Result()
+
+	rows, err := c.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled list collection syllabi query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	syllabi := []SyllabusSchema{}
+	for rows.Next() {
+		var syllabus SyllabusSchema
+		if err := scanSyllabus(rows, &syllabus); err != nil {
+			c.log.Error("failed to scan collection syllabus row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		syllabi = append(syllabi, syllabus)
+	}
+
+	if err := rows.Err(); err != nil {
+		c.log.Error("list collection syllabi rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return syllabi, nil
+}
+
+func (c *pgCollectionRepository) CreateCollection(ctx context.Context, userId string, entity InsertCollection) (CollectionSchema, error) {
+	qb := util.NewSqlBuilder("insert into collections as c (user_id, name, description, visibility)")
+	qb.Concat("values ($%d, $%d, $%d, $%d)", userId, entity.Name, entity.Description, entity.Visibility)
+	qb.Concat("returning "+collectionColumns, SyllabusPublished)
+	result := qb.Result()
+
+	collection := CollectionSchema{}
+	err := scanCollection(c.db.Pool.QueryRow(ctx, result.Query, result.Args...), &collection)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode) {
+			return CollectionSchema{}, util.ErrMalformed
+		}
+
+		c.log.Error("un-handled create collection query error", logger.Err(err))
+		return CollectionSchema{}, util.ErrInternal
+	}
+
+	return collection, nil
+}
+
+func (c *pgCollectionRepository) UpdateCollection(ctx context.Context, userId string, collectionId string, entity UpdateCollection) (CollectionSchema, error) {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return CollectionSchema{}, err
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return CollectionSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	isDefault, err := c.lockUserCollection(ctx, tx, userId, collectionUuid)
+	if err != nil {
+		return CollectionSchema{}, err
+	}
+	if isDefault && entity.Name.IsSpecified() {
+		return CollectionSchema{}, util.ErrForbidden
+	}
+
+	qb := util.NewSqlBuilder("update collections c set id = id")
+	if entity.Name.IsSpecified() {
+		name, err := entity.Name.Get()
+		if err != nil {
+			return CollectionSchema{}, util.ErrMalformed
+		}
+		qb.Concat(",name = $%d", name)
+	}
+	if entity.Description.IsSpecified() {
+		description, err := entity.Description.Get()
+		if err != nil {
+			qb.Concat(",description = null")
+		} else {
+			qb.Concat(",description = $%d", description)
+		}
+	}
+	if entity.Visibility.IsSpecified() {
+		visibility, err := entity.Visibility.Get()
+		if err != nil || visibility == CollectionPrivate {
+			// A new token revokes links shared before, once the collection is shared again
+			qb.Concat(",visibility = $%d, share_token = default", CollectionPrivate)
+		} else {
+			qb.Concat(",visibility = $%d", visibility)
+		}
+	}
+	qb.Concat("where c.id = $%d", collectionUuid)
+	qb.Concat("returning "+collectionColumns, SyllabusPublished)
+	result := qb.Result()
+
+	updated := CollectionSchema{}
+	err = scanCollection(tx.QueryRow(ctx, result.Query, result.Args...), &updated)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode) {
+			return CollectionSchema{}, util.ErrMalformed
+		}
+
+		c.log.Error("un-handled update collection query error", logger.Err(err))
+		return CollectionSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return CollectionSchema{}, util.ErrInternal
+	}
+
+	return updated, nil
+}
+
+func (c *pgCollectionRepository) DeleteCollection(ctx context.Context, userId string, collectionId string) error {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from collections")
+	qb.Concat("where id = $%d and user_id = $%d", collectionUuid, userId)
+	qb.Concat("returning is_default")
+	result := qb.Result()
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	var isDefault bool
+	err = tx.QueryRow(ctx, result.Query, result.Args...).Scan(&isDefault)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		c.log.Error("un-handled delete collection query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	// Rolled back, the default collection is kept for bookmarks
+	if isDefault {
+		return util.ErrForbidden
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (c *pgCollectionRepository) AddCollectionSyllabus(ctx context.Context, userId string, collectionId string, syllabusId string) error {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return err
+	}
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := c.lockUserCollection(ctx, tx, userId, collectionUuid); err != nil {
+		return err
+	}
+
+	added, err := c.addCollectionSyllabus(ctx, tx, userId, collectionUuid, syllabusUuid)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return util.ErrConflict
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+// addCollectionSyllabus appends a syllabus visible to the user to a collection locked by the transaction.
+// Returns [util.ErrNotFound] if the syllabus is not visible, and whether the syllabus was added.
+func (c *pgCollectionRepository) addCollectionSyllabus(ctx context.Context, tx pgx.Tx, userId string, collectionUuid pgtype.UUID, syllabusUuid pgtype.UUID) (bool, error) {
+	qb := util.NewSqlBuilder("insert into collection_syllabi (collection_id, syllabus_id, position)")
+	qb.Concat("select $%d::uuid, s.id,", collectionUuid)
+	qb.Concat("(select coalesce(max(position), 0) + 1 from collection_syllabi where collection_id = $%d)", collectionUuid)
+	qb.Concat("from syllabi s where s.id = $%d", syllabusUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+	qb.Concat("on conflict (collection_id, syllabus_id) do nothing")
+	qb.Concat("returning 1")
+	result := qb.Result()
+
+	var added int
+	err := tx.QueryRow(ctx, result.Query, result.Args...).Scan(&added)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.log.Error("un-handled add collection syllabus query error", logger.Err(err))
+		return false, util.ErrInternal
+	}
+
+	// Nothing is inserted when the syllabus is not visible or already in the collection
+	qb = util.NewSqlBuilder("select exists (select 1 from collection_syllabi")
+	qb.Concat("where collection_id = $%d and syllabus_id = $%d)", collectionUuid, syllabusUuid)
+	existsResult := qb.Result()
+
+	var exists bool
+	if err := tx.QueryRow(ctx, existsResult.Query, existsResult.Args...).Scan(&exists); err != nil {
+		c.log.Error("un-handled collection syllabus exists query error", logger.Err(err))
+		return false, util.ErrInternal
+	}
+	if !exists {
+		return false, util.ErrNotFound
+	}
+
+	return false, nil
+}
+
+func (c *pgCollectionRepository) RemoveCollectionSyllabus(ctx context.Context, userId string, collectionId string, syllabusId string) error {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return err
+	}
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from collection_syllabi cs using collections c")
+	qb.Concat("where c.id = cs.collection_id and c.id = $%d and c.user_id = $%d", collectionUuid, userId)
+	qb.Concat("and cs.syllabus_id = $%d", syllabusUuid)
+
+	return c.removeCollectionSyllabus(ctx, qb.Result())
+}
+
+func (c *pgCollectionRepository) removeCollectionSyllabus(ctx context.Context, result util.SqlBuilderResult) error {
+	tag, err := c.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled remove collection syllabus query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	return nil
+}
+
+func (c *pgCollectionRepository) ReorderCollectionSyllabi(ctx context.Context, userId string, collectionId string, syllabusIds []string) error {
+	collectionUuid, err := database.ParsePgUuid(collectionId)
+	if err != nil {
+		return err
+	}
+
+	syllabusUuids := make([]pgtype.UUID, 0, len(syllabusIds))
+	seen := map[string]bool{}
+	for _, syllabusId := range syllabusIds {
+		syllabusUuid, err := database.ParsePgUuid(syllabusId)
+		if err != nil {
+			return err
+		}
+		if seen[syllabusId] {
+			return util.ErrMalformed
+		}
+		seen[syllabusId] = true
+		syllabusUuids = append(syllabusUuids, syllabusUuid)
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := c.lockUserCollection(ctx, tx, userId, collectionUuid); err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("update collection_syllabi cs set position = o.position")
+	qb.Concat("from unnest($%d::uuid[]) with ordinality as o (syllabus_id, position), syllabi s", syllabusUuids)
+	qb.Concat("where cs.collection_id = $%d and cs.syllabus_id = o.syllabus_id", collectionUuid)
+	qb.Concat("and s.id = cs.syllabus_id and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+	result := qb.Result()
+
+	tag, err := tx.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled reorder collection query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder("select count(*) from collection_syllabi cs")
+	qb.Concat("inner join syllabi s on s.id = cs.syllabus_id")
+	qb.Concat("where cs.collection_id = $%d", collectionUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+	countResult := qb.Result()
+
+	var count int64
+	if err := tx.QueryRow(ctx, countResult.Query, countResult.Args...).Scan(&count); err != nil {
+		c.log.Error("un-handled count collection syllabi query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	// Every visible syllabus must be given a position, and only visible syllabi in the collection
+	if tag.RowsAffected() != count || count != int64(len(syllabusUuids)) {
+		return util.ErrMalformed
+	}
+
+	// Hidden syllabi are kept in the collection in case they are published again
+	qb = util.NewSqlBuilder("update collection_syllabi cs set position = h.position")
+	qb.Concat("from (select syllabus_id, $%d + row_number() over (order by position) as position", len(syllabusUuids))
+	qb.Concat("from collection_syllabi where collection_id = $%d and syllabus_id <> all($%d::uuid[])) h", collectionUuid, syllabusUuids)
+	qb.Concat("where cs.collection_id = $%d and cs.syllabus_id = h.syllabus_id", collectionUuid)
+	hiddenResult := qb.Result()
+
+	if _, err := tx.Exec(ctx, hiddenResult.Query, hiddenResult.Args...); err != nil {
+		c.log.Error("un-handled reorder hidden collection syllabi query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+// lockUserCollection locks the user's collection for changes to its syllabi, returning whether it is the default collection.
+func (c *pgCollectionRepository) lockUserCollection(ctx context.Context, tx pgx.Tx, userId string, collectionUuid pgtype.UUID) (bool, error) {
+	qb := util.NewSqlBuilder("select is_default from collections")
+	qb.Concat("where id = $%d and user_id = $%d for update", collectionUuid, userId)
+	result := qb.Result()
+
+	var isDefault bool
+	err := tx.QueryRow(ctx, result.Query, result.Args...).Scan(&isDefault)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled lock collection query error", logger.Err(err))
+		return false, util.ErrInternal
+	}
+
+	return isDefault, nil
+}
+
+func (c *pgCollectionRepository) BookmarkSyllabus(ctx context.Context, userId string, syllabusId string) error {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder("insert into collections (user_id, name, is_default)")
+	qb.Concat("values ($%d, $%d, true)", userId, DefaultCollectionName)
+	qb.Concat("on conflict (user_id) where is_default do nothing")
+	createResult := qb.Result()
+
+	if _, err := tx.Exec(ctx, createResult.Query, createResult.Args...); err != nil {
+		c.log.Error("un-handled create default collection query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder("select id from collections")
+	qb.Concat("where user_id = $%d and is_default for update", userId)
+	defaultResult := qb.Result()
+
+	var collectionUuid pgtype.UUID
+	if err := tx.QueryRow(ctx, defaultResult.Query, defaultResult.Args...).Scan(&collectionUuid); err != nil {
+		c.log.Error("un-handled get default collection query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if _, err := c.addCollectionSyllabus(ctx, tx, userId, collectionUuid, syllabusUuid); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	c.log.Info(fmt.Sprintf("user %s bookmarked syllabus %s", userId, syllabusId))
+	return nil
+}
+
+func (c *pgCollectionRepository) RemoveBookmark(ctx context.Context, userId string, syllabusId string) error {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from collection_syllabi cs using collections c")
+	qb.Concat("where c.id = cs.collection_id and c.user_id = $%d and c.is_default", userId)
+	qb.Concat("and cs.syllabus_id = $%d", syllabusUuid)
+
+	return c.removeCollectionSyllabus(ctx, qb.Result())
+}
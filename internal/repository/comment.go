@@ -0,0 +1,452 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CommentSchema struct {
+	Id          string
+	SyllabusId  string
+	UserId      sql.NullString // Null once the author's account is deleted
+	ThreadId    sql.NullString // Top level comment of the thread, null for top level comments
+	ParentId    sql.NullString
+	Content     string
+	ReplyCount  int // Replies in the thread, including deleted and removed replies
+	DateEdited  sql.NullTime
+	DateDeleted sql.NullTime // Set when the author deletes the comment
+	DateRemoved sql.NullTime // Set when a moderator removes the comment
+	DateAdded   time.Time
+}
+
+// IsHidden reports whether the comment was deleted or removed, hidden comments are only shown as placeholders.
+func (c CommentSchema) IsHidden() bool {
+	return c.DateDeleted.Valid || c.DateRemoved.Valid
+}
+
+// CommentRecipient is a user to notify of a new comment.
+type CommentRecipient struct {
+	UserId string
+	Name   string
+	Email  string
+}
+
+// CommentNotice describes who should be notified of a new comment.
+type CommentNotice struct {
+	Course string
+	// Owner uploaded the commented syllabus.
+	Owner CommentRecipient
+	// Parent wrote the comment being replied to, nil for top level comments or when the parent's author was deleted.
+	Parent *CommentRecipient
+}
+
+type CommentRepository interface {
+	// ListComments lists the top level comments of a syllabus visible to the user, newest first.
+	// Deleted and removed comments are only listed while they have replies.
+	ListComments(ctx context.Context, userId string, syllabusId string, paginate util.Paginate) ([]CommentSchema, error)
+	// ListCommentReplies lists the replies in a comment's thread, oldest first.
+	ListCommentReplies(ctx context.Context, userId string, syllabusId string, commentId string, paginate util.Paginate) ([]CommentSchema, error)
+	// CreateComment comments on a published syllabus, replying to the parent comment when a parent ID is given.
+	// Returns [util.ErrConflict] if the syllabus is not published or the parent was deleted or removed.
+	CreateComment(ctx context.Context, userId string, syllabusId string, parentId string, content string) (CommentSchema, CommentNotice, error)
+	// UpdateComment edits the user's comment, returning [util.ErrConflict] if it was deleted or removed.
+	UpdateComment(ctx context.Context, userId string, syllabusId string, commentId string, content string) (CommentSchema, error)
+	// DeleteComment soft deletes the user's comment.
+	DeleteComment(ctx context.Context, userId string, syllabusId string, commentId string) error
+	// RemoveComment hides a comment by moderation, returning [util.ErrConflict] if it was already removed.
+	RemoveComment(ctx context.Context, commentId string, removedBy string, reason string) error
+}
+
+type pgCommentRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgCommentRepository(db *database.PostgresDb, log logger.Logger) *pgCommentRepository {
+	return &pgCommentRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const commentColumns = "c.id, c.syllabus_id, c.user_id, c.thread_id, c.parent_id, c.content, " +
+	"(select count(*) from syllabus_comments r where r.thread_id = c.id), " +
+	"c.date_edited, c.date_deleted, c.date_removed, c.date_added"
+
+func scanComment(row pgx.Row, comment *CommentSchema) error {
+	return row.Scan(
+		&comment.Id,
+		&comment.SyllabusId,
+		&comment.UserId,
+		&comment.ThreadId,
+		&comment.ParentId,
+		&comment.Content,
+		&comment.ReplyCount,
+		&comment.DateEdited,
+		&comment.DateDeleted,
+		&comment.DateRemoved,
+		&comment.DateAdded,
+	)
+}
+
+func (c *pgCommentRepository) ListComments(ctx context.Context, userId string, syllabusId string, paginate util.Paginate) ([]CommentSchema, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkSyllabusVisible(ctx, userId, syllabusUuid); err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder("select " + commentColumns + " from syllabus_comments c")
+	qb.Concat("where c.syllabus_id = $%d and c.thread_id is null", syllabusUuid)
+	qb.Concat("and ((c.date_deleted is null and c.date_removed is null)")
+	qb.Concat("or exists (select 1 from syllabus_comments r where r.thread_id = c.id))")
+	qb.Concat("order by c.date_added desc, c.id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+
+	return c.listComments(ctx, qb.Result())
+}
+
+func (c *pgCommentRepository) ListCommentReplies(ctx context.Context, userId string, syllabusId string, commentId string, paginate util.Paginate) ([]CommentSchema, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return nil, err
+	}
+	commentUuid, err := database.ParsePgUuid(commentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkSyllabusVisible(ctx, userId, syllabusUuid); err != nil {
+		return nil, err
+	}
+
+	// Replies are listed by the thread of the given comment, so a reply's ID lists its whole thread
+	qb := util.NewSqlBuilder("select " + commentColumns + " from syllabus_comments c")
+	qb.Concat("inner join syllabus_comments t on t.id = $%d and t.syllabus_id = $%d", commentUuid, syllabusUuid)
+	qb.Concat("where c.thread_id = coalesce(t.thread_id, t.id)")
+	qb.Concat("order by c.date_added, c.id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+
+	return c.listComments(ctx, qb.Result())
+}
+
+func (c *pgCommentRepository) listComments(ctx context.Context, result util.SqlBuilderResult) ([]CommentSchema, error) {
+	rows, err := c.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled list comments query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	comments := []CommentSchema{}
+	for rows.Next() {
+		var comment CommentSchema
+		if err := scanComment(rows, &comment); err != nil {
+			c.log.Error("failed to scan comment row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		c.log.Error("list comments rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return comments, nil
+}
+
+// checkSyllabusVisible returns [util.ErrNotFound] unless the syllabus is published or owned by the user.
+func (c *pgCommentRepository) checkSyllabusVisible(ctx context.Context, userId string, syllabusUuid pgtype.UUID) error {
+	qb := util.NewSqlBuilder("select 1 from syllabi")
+	qb.Concat("where id = $%d", syllabusUuid)
+	qb.Concat("and (status = $%d or user_id = $%d)", SyllabusPublished, userId)
+	result := qb.Result()
+
+	var exists int
+	err := c.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		c.log.Error("un-handled syllabus visibility query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (c *pgCommentRepository) CreateComment(ctx context.Context, userId string, syllabusId string, parentId string, content string) (CommentSchema, CommentNotice, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return CommentSchema{}, CommentNotice{}, err
+	}
+
+	var parentUuid pgtype.UUID
+	if parentId != "" {
+		parentUuid, err = database.ParsePgUuid(parentId)
+		if err != nil {
+			return CommentSchema{}, CommentNotice{}, err
+		}
+	}
+
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return CommentSchema{}, CommentNotice{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder(
+		"select s.status, u.id, u.full_name, u.email, c.course from syllabi s",
+		"inner join users u on u.id = s.user_id",
+		"inner join courses c on c.id = s.course_id",
+	)
+	qb.Concat("where s.id = $%d", syllabusUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+	syllabusResult := qb.Result()
+
+	var status string
+	var notice CommentNotice
+	err = tx.QueryRow(ctx, syllabusResult.Query, syllabusResult.Args...).Scan(
+		&status, &notice.Owner.UserId, &notice.Owner.Name, &notice.Owner.Email, &notice.Course,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CommentSchema{}, CommentNotice{}, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled get commented syllabus query error", logger.Err(err))
+		return CommentSchema{}, CommentNotice{}, util.ErrInternal
+	}
+
+	if status != SyllabusPublished {
+		return CommentSchema{}, CommentNotice{}, util.ErrConflict
+	}
+
+	var threadUuid pgtype.UUID
+	if parentId != "" {
+		qb := util.NewSqlBuilder(
+			"select coalesce(p.thread_id, p.id), p.date_deleted is not null or p.date_removed is not null,",
+			"u.id, u.full_name, u.email",
+			"from syllabus_comments p",
+			"left join users u on u.id = p.user_id",
+		)
+		qb.Concat("where p.id = $%d and p.syllabus_id = $%d", parentUuid, syllabusUuid)
+		qb.Concat("for share of p")
+		parentResult := qb.Result()
+
+		var hidden bool
+		var parentUserId, parentName, parentEmail sql.NullString
+		err = tx.QueryRow(ctx, parentResult.Query, parentResult.Args...).Scan(
+			&threadUuid, &hidden, &parentUserId, &parentName, &parentEmail,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return CommentSchema{}, CommentNotice{}, util.ErrNotFound
+			}
+
+			c.log.Error("un-handled get parent comment query error", logger.Err(err))
+			return CommentSchema{}, CommentNotice{}, util.ErrInternal
+		}
+
+		if hidden {
+			return CommentSchema{}, CommentNotice{}, util.ErrConflict
+		}
+
+		if parentUserId.Valid {
+			notice.Parent = &CommentRecipient{
+				UserId: parentUserId.String,
+				Name:   parentName.String,
+				Email:  parentEmail.String,
+			}
+		}
+	}
+
+	qb = util.NewSqlBuilder("insert into syllabus_comments as c (syllabus_id, user_id, thread_id, parent_id, content)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, $%d)", syllabusUuid, userId, threadUuid, parentUuid, content)
+	qb.Concat("returning " + commentColumns)
+	insertResult := qb.Result()
+
+	comment := CommentSchema{}
+	if err := scanComment(tx.QueryRow(ctx, insertResult.Query, insertResult.Args...), &comment); err != nil {
+		c.log.Error("un-handled insert comment query error", logger.Err(err))
+		return CommentSchema{}, CommentNotice{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return CommentSchema{}, CommentNotice{}, util.ErrInternal
+	}
+
+	c.log.Info(fmt.Sprintf("user %s commented %s on syllabus %s", userId, comment.Id, syllabusId))
+	return comment, notice, nil
+}
+
+func (c *pgCommentRepository) UpdateComment(ctx context.Context, userId string, syllabusId string, commentId string, content string) (CommentSchema, error) {
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return CommentSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	commentUuid, err := c.lockAuthorComment(ctx, tx, userId, syllabusId, commentId)
+	if err != nil {
+		return CommentSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("update syllabus_comments c set content = $%d, date_edited = now()", content)
+	qb.Concat("where c.id = $%d", commentUuid)
+	qb.Concat("returning " + commentColumns)
+	result := qb.Result()
+
+	updated := CommentSchema{}
+	if err := scanComment(tx.QueryRow(ctx, result.Query, result.Args...), &updated); err != nil {
+		c.log.Error("un-handled update comment query error", logger.Err(err))
+		return CommentSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return CommentSchema{}, util.ErrInternal
+	}
+
+	return updated, nil
+}
+
+func (c *pgCommentRepository) DeleteComment(ctx context.Context, userId string, syllabusId string, commentId string) error {
+	tx, err := c.db.Pool.Begin(ctx)
+	if err != nil {
+		c.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	commentUuid, err := c.lockAuthorComment(ctx, tx, userId, syllabusId, commentId)
+	if err != nil && !errors.Is(err, util.ErrConflict) {
+		return err
+	}
+
+	// Removed comments may still be deleted by their author
+	qb := util.NewSqlBuilder()
+	qb.Concat("update syllabus_comments set date_deleted = now()")
+	qb.Concat("where id = $%d and date_deleted is null", commentUuid)
+	result := qb.Result()
+
+	tag, err := tx.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled delete comment query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	c.log.Info(fmt.Sprintf("user %s deleted comment %s", userId, commentId))
+	return nil
+}
+
+// lockAuthorComment locks a comment on the syllabus for an update by its author.
+// Returns [util.ErrForbidden] for other users and [util.ErrConflict] if the comment was deleted or removed, along with the comment's ID.
+func (c *pgCommentRepository) lockAuthorComment(ctx context.Context, tx pgx.Tx, userId string, syllabusId string, commentId string) (pgtype.UUID, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	commentUuid, err := database.ParsePgUuid(commentId)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	qb := util.NewSqlBuilder("select user_id, date_deleted is not null or date_removed is not null from syllabus_comments")
+	qb.Concat("where id = $%d and syllabus_id = $%d for update", commentUuid, syllabusUuid)
+	result := qb.Result()
+
+	var authorId sql.NullString
+	var hidden bool
+	err = tx.QueryRow(ctx, result.Query, result.Args...).Scan(&authorId, &hidden)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, util.ErrNotFound
+		}
+
+		c.log.Error("un-handled get comment author query error", logger.Err(err))
+		return pgtype.UUID{}, util.ErrInternal
+	}
+
+	if authorId.String != userId {
+		c.log.Info(fmt.Sprintf("user %s attempted to modify comment %s of user %s", userId, commentId, authorId.String))
+		return pgtype.UUID{}, util.ErrForbidden
+	}
+
+	if hidden {
+		return commentUuid, util.ErrConflict
+	}
+
+	return commentUuid, nil
+}
+
+func (c *pgCommentRepository) RemoveComment(ctx context.Context, commentId string, removedBy string, reason string) error {
+	commentUuid, err := database.ParsePgUuid(commentId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("select date_removed is not null from syllabus_comments")
+	qb.Concat("where id = $%d", commentUuid)
+	result := qb.Result()
+
+	var removed bool
+	err = c.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&removed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		c.log.Error("un-handled get comment query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if removed {
+		return util.ErrConflict
+	}
+
+	qb = util.NewSqlBuilder()
+	qb.Concat("update syllabus_comments set removed_by = $%d, removed_reason = $%d, date_removed = now()", removedBy, reason)
+	qb.Concat("where id = $%d and date_removed is null", commentUuid)
+	result = qb.Result()
+
+	tag, err := c.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		c.log.Error("un-handled remove comment query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	// Removed by a concurrent request
+	if tag.RowsAffected() == 0 {
+		return util.ErrConflict
+	}
+
+	c.log.Info(fmt.Sprintf("user %s removed comment %s", removedBy, commentId))
+	return nil
+}
@@ -21,12 +21,46 @@ type CourseSchema struct {
 	Description sql.NullString
 	Uri         string
 	Course      string
+	Credits     float64
 	DateAdded   time.Time
+	Ratings     CourseRatingsSchema
+	// Requisites as published in the academic calendar
+	Prerequisites  sql.NullString
+	Corequisites   sql.NullString
+	Antirequisites sql.NullString
+}
+
+// CourseRatingsSchema aggregates the ratings of a course's reviews, averages are null for courses without reviews.
+type CourseRatingsSchema struct {
+	ReviewCount int
+	Difficulty  sql.NullFloat64
+	Workload    sql.NullFloat64
+	Usefulness  sql.NullFloat64
+}
+
+// Course sort values.
+const (
+	CourseSortDifficulty = "difficulty"
+	CourseSortWorkload   = "workload"
+	CourseSortUsefulness = "usefulness"
+	CourseSortReviews    = "reviews"
+)
+
+// courseSortColumns maps sort values to the columns of [courseColumns] they order by.
+var courseSortColumns = map[string]string{
+	CourseSortDifficulty: "r.difficulty",
+	CourseSortWorkload:   "r.workload",
+	CourseSortUsefulness: "r.usefulness",
+	CourseSortReviews:    "coalesce(r.review_count, 0)",
 }
 
 type CourseFilters struct {
 	Search     string
 	CategoryId string
+	// Sort orders courses by an aggregate rating, courses are ordered by code when empty.
+	Sort string
+	// Descending reverses the sort order, courses without reviews are always listed last.
+	Descending bool
 }
 
 type CourseRepository interface {
@@ -46,6 +80,25 @@ func NewPgCourseRepository(db *database.PostgresDb, log logger.Logger) *pgCourse
 	}
 }
 
+const courseColumns = "c.id, c.category_id, c.title, c.description, c.uri, c.course, c.credits::float8, c.date_added, " +
+	"coalesce(r.review_count, 0), r.difficulty, r.workload, r.usefulness, c.prerequisites, c.corequisites, c.antirequisites"
+
+// courseRatingsJoin joins the aggregate ratings of each course as r.
+const courseRatingsJoin = "left join (" +
+	"select course_id, count(*) as review_count, avg(difficulty)::float8 as difficulty, " +
+	"avg(workload)::float8 as workload, avg(usefulness)::float8 as usefulness " +
+	"from course_reviews group by course_id" +
+	") r on r.course_id = c.id"
+
+func scanCourse(row pgx.Row, course *CourseSchema) error {
+	return row.Scan(
+		&course.Id, &course.CategoryId, &course.Title, &course.Description, &course.Uri,
+		&course.Course, &course.Credits, &course.DateAdded, &course.Ratings.ReviewCount, &course.Ratings.Difficulty,
+		&course.Ratings.Workload, &course.Ratings.Usefulness, &course.Prerequisites, &course.Corequisites,
+		&course.Antirequisites,
+	)
+}
+
 func (c *pgCourseRepository) GetCourse(ctx context.Context, courseId string) (CourseSchema, error) {
 	var course CourseSchema
 
@@ -54,10 +107,7 @@ func (c *pgCourseRepository) GetCourse(ctx context.Context, courseId string) (Co
 		return course, err
 	}
 
-	err = c.db.Pool.QueryRow(context.TODO(), result.Query, result.Args...).Scan(
-		&course.Id, &course.CategoryId, &course.Title, &course.Description, &course.Uri,
-		&course.Course, &course.DateAdded,
-	)
+	err = scanCourse(c.db.Pool.QueryRow(context.TODO(), result.Query, result.Args...), &course)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return course, util.ErrNotFound
@@ -88,10 +138,7 @@ func (c *pgCourseRepository) ListCourses(ctx context.Context, filters CourseFilt
 
 	for rows.Next() {
 		course := CourseSchema{}
-		err := rows.Scan(
-			&course.Id, &course.CategoryId, &course.Title, &course.Description, &course.Uri,
-			&course.Course, &course.DateAdded,
-		)
+		err := scanCourse(rows, &course)
 		if err != nil {
 			c.log.Error("scan course error", logger.Err(err))
 			return courses, util.ErrInternal
@@ -110,31 +157,49 @@ func (c *pgCourseRepository) getCourseQuery(courseId string) (util.SqlBuilderRes
 	}
 
 	qb := util.NewSqlBuilder(
-		"select id, category_id, title, description, uri, course, date_added",
-		"from courses",
+		"select "+courseColumns,
+		"from courses c",
+		courseRatingsJoin,
 	)
-	qb = qb.Concat("where id = $%d", courseUuid)
+	qb = qb.Concat("where c.id = $%d", courseUuid)
 
 	return qb.Result(), nil
 }
 
 func (c *pgCourseRepository) listCoursesQuery(filters CourseFilters, paginate util.Paginate) (util.SqlBuilderResult, error) {
 	qb := util.NewSqlBuilder(
-		"select id, category_id, title, description, uri, course, date_added",
-		"from courses",
+		"select "+courseColumns,
+		"from courses c",
+		courseRatingsJoin,
 		"where 1 = 1",
 	)
 
 	if filters.CategoryId != "" {
-		qb.Concat("and category_id = $%d", filters.CategoryId)
+		qb.Concat("and c.category_id = $%d", filters.CategoryId)
 	}
 	if filters.Search != "" {
-		qb.Concat("and course ilike $%d or title ilike $%d", "%"+filters.Search+"%", "%"+filters.Search+"%")
+		qb.Concat("and (c.course ilike $%d or c.title ilike $%d)", "%"+filters.Search+"%", "%"+filters.Search+"%")
+	}
+
+	if filters.Sort != "" {
+		column, ok := courseSortColumns[filters.Sort]
+		if !ok {
+			c.log.Info("invalid course sort " + filters.Sort)
+			return util.SqlBuilderResult{}, util.ErrMalformed
+		}
+
+		direction := "asc"
+		if filters.Descending {
+			direction = "desc"
+		}
+		qb.Concat("order by " + column + " " + direction + " nulls last, c.course")
+	} else {
+		qb.Concat("order by c.course")
 	}
 
 	qb.Concat("limit $%d", paginate.Size)
 	offset := (paginate.Page - 1) * paginate.Size
 	qb.Concat("offset $%d", offset)
 
-	return qb.Result(), nil // TODO: remove non-existent errors from API
+	return qb.Result(), nil
 }
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/extractor"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type SyllabusDetailsSchema struct {
+	SyllabusId   string
+	Revision     int16 // Revision the details were read from
+	Details      extractor.SyllabusDetails
+	Confidence   float32
+	Edited       bool // Edited details were corrected by the uploader
+	DateAdded    time.Time
+	DateModified time.Time
+}
+
+type DetailsRepository interface {
+	// GetSyllabusDetails reads the details of a syllabus visible to the user.
+	GetSyllabusDetails(ctx context.Context, userId string, syllabusId string) (SyllabusDetailsSchema, error)
+	// SaveParsedSyllabusDetails stores details parsed from a revision's text, keeping details edited by the uploader for the same revision.
+	SaveParsedSyllabusDetails(ctx context.Context, syllabusId string, revision int16, details extractor.SyllabusDetails, confidence float64) error
+	// UpdateSyllabusDetails replaces the details of the user's syllabus with their corrections.
+	UpdateSyllabusDetails(ctx context.Context, userId string, syllabusId string, details extractor.SyllabusDetails) (SyllabusDetailsSchema, error)
+}
+
+type pgDetailsRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgDetailsRepository(db *database.PostgresDb, log logger.Logger) *pgDetailsRepository {
+	return &pgDetailsRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const syllabusDetailsColumns = "d.syllabus_id, d.revision, d.details, d.confidence, d.edited, d.date_added, d.date_modified"
+
+func scanSyllabusDetails(row pgx.Row, details *SyllabusDetailsSchema) error {
+	return row.Scan(
+		&details.SyllabusId,
+		&details.Revision,
+		&details.Details,
+		&details.Confidence,
+		&details.Edited,
+		&details.DateAdded,
+		&details.DateModified,
+	)
+}
+
+func (d *pgDetailsRepository) GetSyllabusDetails(ctx context.Context, userId string, syllabusId string) (SyllabusDetailsSchema, error) {
+	result, err := d.getSyllabusDetailsQuery(userId, syllabusId)
+	if err != nil {
+		return SyllabusDetailsSchema{}, err
+	}
+
+	details := SyllabusDetailsSchema{}
+	err = scanSyllabusDetails(d.db.Pool.QueryRow(ctx, result.Query, result.Args...), &details)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusDetailsSchema{}, util.ErrNotFound
+		}
+
+		d.log.Error("un-handled get syllabus details query error", logger.Err(err))
+		return SyllabusDetailsSchema{}, util.ErrInternal
+	}
+
+	return details, nil
+}
+
+func (d *pgDetailsRepository) getSyllabusDetailsQuery(userId string, syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select " + syllabusDetailsColumns + " from syllabus_details d")
+	qb.Concat("inner join syllabi s on s.id = d.syllabus_id")
+	qb.Concat("where d.syllabus_id = $%d", syllabusUuid)
+	qb.Concat("and (s.status = $%d or s.user_id = $%d)", SyllabusPublished, userId)
+
+	return qb.Result(), nil
+}
+
+func (d *pgDetailsRepository) SaveParsedSyllabusDetails(ctx context.Context, syllabusId string, revision int16, details extractor.SyllabusDetails, confidence float64) error {
+	result, err := d.saveParsedSyllabusDetailsQuery(syllabusId, revision, details, confidence)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			return util.ErrNotFound
+		}
+
+		d.log.Error("un-handled save syllabus details query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	d.log.Info(fmt.Sprintf("syllabus %s revision %d details parsed with confidence %.2f", syllabusId, revision, confidence))
+	return nil
+}
+
+func (d *pgDetailsRepository) saveParsedSyllabusDetailsQuery(syllabusId string, revision int16, details extractor.SyllabusDetails, confidence float64) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	// A new revision replaces corrections made to the previous file
+	qb := util.NewSqlBuilder("insert into syllabus_details (syllabus_id, revision, details, confidence)")
+	qb.Concat("values ($%d, $%d, $%d, $%d)", syllabusUuid, revision, details, confidence)
+	qb.Concat("on conflict (syllabus_id) do update")
+	qb.Concat("set revision = excluded.revision, details = excluded.details, confidence = excluded.confidence, edited = false, date_modified = now()")
+	qb.Concat("where syllabus_details.revision < excluded.revision")
+	qb.Concat("or (syllabus_details.revision = excluded.revision and not syllabus_details.edited)")
+
+	return qb.Result(), nil
+}
+
+func (d *pgDetailsRepository) UpdateSyllabusDetails(ctx context.Context, userId string, syllabusId string, details extractor.SyllabusDetails) (SyllabusDetailsSchema, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return SyllabusDetailsSchema{}, err
+	}
+
+	tx, err := d.db.Pool.Begin(ctx)
+	if err != nil {
+		d.log.Error("failed to begin transaction", logger.Err(err))
+		return SyllabusDetailsSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder("select user_id, revision from syllabi")
+	qb.Concat("where id = $%d for update", syllabusUuid)
+	ownerResult := qb.Result()
+
+	var createUserId string
+	var revision int16
+	err = tx.QueryRow(ctx, ownerResult.Query, ownerResult.Args...).Scan(&createUserId, &revision)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusDetailsSchema{}, util.ErrNotFound
+		}
+
+		d.log.Error("un-handled get syllabus owner query error", logger.Err(err))
+		return SyllabusDetailsSchema{}, util.ErrInternal
+	}
+
+	if createUserId != userId {
+		d.log.Info(fmt.Sprintf("user %s attempted to update user %s syllabus %s details", userId, createUserId, syllabusId))
+		return SyllabusDetailsSchema{}, util.ErrForbidden
+	}
+
+	result := d.updateSyllabusDetailsQuery(syllabusId, revision, details)
+	updated := SyllabusDetailsSchema{}
+	err = scanSyllabusDetails(tx.QueryRow(ctx, result.Query, result.Args...), &updated)
+	if err != nil {
+		d.log.Error("un-handled update syllabus details query error", logger.Err(err))
+		return SyllabusDetailsSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		d.log.Error("failed to commit transaction", logger.Err(err))
+		return SyllabusDetailsSchema{}, util.ErrInternal
+	}
+
+	d.log.Info(fmt.Sprintf("syllabus %s details edited", syllabusId))
+	return updated, nil
+}
+
+func (d *pgDetailsRepository) updateSyllabusDetailsQuery(syllabusId string, revision int16, details extractor.SyllabusDetails) util.SqlBuilderResult {
+	// Corrections are fully confident
+	qb := util.NewSqlBuilder("insert into syllabus_details as d (syllabus_id, revision, details, confidence, edited)")
+	qb.Concat("values ($%d, $%d, $%d, 1, true)", syllabusId, revision, details)
+	qb.Concat("on conflict (syllabus_id) do update")
+	qb.Concat("set revision = excluded.revision, details = excluded.details, confidence = 1, edited = true, date_modified = now()")
+	qb.Concat("returning " + syllabusDetailsColumns)
+
+	return qb.Result()
+}
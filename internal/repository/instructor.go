@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type InstructorSchema struct {
+	Id             string
+	Name           string
+	VerifiedUserId sql.NullString // User whose claim to the profile was approved
+	SyllabusCount  int            // Published syllabi of the instructor
+	DateAdded      time.Time
+}
+
+// InstructorCourseSchema is a course an instructor has published syllabi for.
+type InstructorCourseSchema struct {
+	CourseId      string
+	Course        string
+	Title         string
+	SyllabusCount int
+}
+
+type InstructorFilters struct {
+	// Name matches instructors with similar names, most similar first.
+	Name     string
+	CourseId string
+}
+
+type InstructorRepository interface {
+	ListInstructors(ctx context.Context, filters InstructorFilters, paginate util.Paginate) ([]InstructorSchema, error)
+	GetInstructor(ctx context.Context, instructorId string) (InstructorSchema, error)
+	// ListInstructorCourses lists the courses an instructor has published syllabi for.
+	ListInstructorCourses(ctx context.Context, instructorId string) ([]InstructorCourseSchema, error)
+	// CreateInstructor adds an instructor, returning [util.ErrConflict] if an instructor has the same name ignoring case.
+	CreateInstructor(ctx context.Context, userId string, name string) (InstructorSchema, error)
+}
+
+type pgInstructorRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgInstructorRepository(db *database.PostgresDb, log logger.Logger) *pgInstructorRepository {
+	return &pgInstructorRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const instructorColumns = "i.id, i.name, i.verified_user_id, " +
+	"(select count(*) from syllabi s where s.instructor_id = i.id and s.status = $%d), i.date_added"
+
+func scanInstructor(row pgx.Row, instructor *InstructorSchema) error {
+	return row.Scan(
+		&instructor.Id,
+		&instructor.Name,
+		&instructor.VerifiedUserId,
+		&instructor.SyllabusCount,
+		&instructor.DateAdded,
+	)
+}
+
+func (i *pgInstructorRepository) ListInstructors(ctx context.Context, filters InstructorFilters, paginate util.Paginate) ([]InstructorSchema, error) {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+instructorColumns+" from instructors i", SyllabusPublished)
+	qb.Concat("where 1 = 1")
+
+	if filters.Name != "" {
+		// Trigram similarity catches misspellings, the substring match catches partially typed names
+		qb.Concat("and (i.name %% $%d or i.name ilike $%d)", filters.Name, util.ContainsPattern(filters.Name))
+	}
+	if filters.CourseId != "" {
+		courseUuid, err := database.ParsePgUuid(filters.CourseId)
+		if err != nil {
+			return nil, err
+		}
+		qb.Concat("and exists (select 1 from syllabi s where s.instructor_id = i.id and s.course_id = $%d and s.status = $%d)", courseUuid, SyllabusPublished)
+	}
+
+	if filters.Name != "" {
+		qb.Concat("order by similarity(i.name, $%d) desc, i.name", filters.Name)
+	} else {
+		qb.Concat("order by i.name")
+	}
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := i.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		i.log.Error("un-handled list instructors query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	instructors := []InstructorSchema{}
+	for rows.Next() {
+		var instructor InstructorSchema
+		if err := scanInstructor(rows, &instructor); err != nil {
+			i.log.Error("failed to scan instructor row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		instructors = append(instructors, instructor)
+	}
+
+	if err := rows.Err(); err != nil {
+		i.log.Error("list instructors rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return instructors, nil
+}
+
+func (i *pgInstructorRepository) GetInstructor(ctx context.Context, instructorId string) (InstructorSchema, error) {
+	instructorUuid, err := database.ParsePgUuid(instructorId)
+	if err != nil {
+		return InstructorSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+instructorColumns+" from instructors i", SyllabusPublished)
+	qb.Concat("where i.id = $%d", instructorUuid)
+	result := qb.Result()
+
+	instructor := InstructorSchema{}
+	err = scanInstructor(i.db.Pool.QueryRow(ctx, result.Query, result.Args...), &instructor)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return InstructorSchema{}, util.ErrNotFound
+		}
+
+		i.log.Error("un-handled get instructor query error", logger.Err(err))
+		return InstructorSchema{}, util.ErrInternal
+	}
+
+	return instructor, nil
+}
+
+func (i *pgInstructorRepository) ListInstructorCourses(ctx context.Context, instructorId string) ([]InstructorCourseSchema, error) {
+	instructorUuid, err := database.ParsePgUuid(instructorId)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder("select c.id, c.course, c.title, count(*) from syllabi s")
+	qb.Concat("inner join courses c on c.id = s.course_id")
+	qb.Concat("where s.instructor_id = $%d and s.status = $%d", instructorUuid, SyllabusPublished)
+	qb.Concat("group by c.id, c.course, c.title")
+	qb.Concat("order by c.course")
+	result := qb.Result()
+
+	rows, err := i.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		i.log.Error("un-handled list instructor courses query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	courses := []InstructorCourseSchema{}
+	for rows.Next() {
+		var course InstructorCourseSchema
+		if err := rows.Scan(&course.CourseId, &course.Course, &course.Title, &course.SyllabusCount); err != nil {
+			i.log.Error("failed to scan instructor course row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		i.log.Error("list instructor courses rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return courses, nil
+}
+
+func (i *pgInstructorRepository) CreateInstructor(ctx context.Context, userId string, name string) (InstructorSchema, error) {
+	qb := util.NewSqlBuilder("insert into instructors as i (name, user_id)")
+	qb.Concat("values ($%d, $%d)", name, userId)
+	qb.Concat("returning "+instructorColumns, SyllabusPublished)
+	result := qb.Result()
+
+	created := InstructorSchema{}
+	err := scanInstructor(i.db.Pool.QueryRow(ctx, result.Query, result.Args...), &created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgConflictErrCode {
+				return InstructorSchema{}, util.ErrConflict
+			} else if pgErr.Code == database.PgCheckErrCode {
+				return InstructorSchema{}, util.ErrMalformed
+			}
+		}
+
+		i.log.Error("un-handled create instructor query error", logger.Err(err))
+		return InstructorSchema{}, util.ErrInternal
+	}
+
+	i.log.Info(fmt.Sprintf("user %s added instructor %s", userId, created.Id))
+	return created, nil
+}
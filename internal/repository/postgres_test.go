@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/JackieLi565/syllabye/internal/config"
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testPostgresDb connects to the migrated database configured by the POSTGRES_* variables,
+// skipping the test when none is configured. Tests remove the rows they add.
+func testPostgresDb(t *testing.T) *database.PostgresDb {
+	t.Helper()
+	if os.Getenv(config.PostgresHost) == "" {
+		t.Skip("no database configured")
+	}
+
+	dbUrl := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+		os.Getenv(config.PostgresUser), os.Getenv(config.PostgresPassword), os.Getenv(config.PostgresHost), os.Getenv(config.PostgresPort), os.Getenv(config.PostgresDatabase))
+	pool, err := pgxpool.New(context.Background(), dbUrl)
+	if err != nil {
+		t.Fatalf("failed to create a database connection pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err := pool.Ping(context.Background()); err != nil {
+		t.Fatalf("failed to connect to the database: %v", err)
+	}
+
+	return &database.PostgresDb{Pool: pool}
+}
+
+// createTestUser adds a user, removed with its user courses and syllabi when the test ends.
+func createTestUser(t *testing.T, db *database.PostgresDb) string {
+	t.Helper()
+
+	var userId string
+	err := db.Pool.QueryRow(context.Background(),
+		"insert into users (full_name, email) values ('Test User', gen_random_uuid()::text || '@example.com') returning id").Scan(&userId)
+	if err != nil {
+		t.Fatalf("failed to create a test user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Pool.Exec(context.Background(), "delete from users where id = $1", userId)
+	})
+
+	return userId
+}
+
+// createTestCourse adds a course in its own category, removed with anything referencing it when the test ends.
+func createTestCourse(t *testing.T, db *database.PostgresDb) string {
+	t.Helper()
+
+	var courseId string
+	err := db.Pool.QueryRow(context.Background(),
+		"with category as (insert into course_categories (name) values (gen_random_uuid()::text) returning id) "+
+			"insert into courses (category_id, title, uri, course) select id, 'Test Course', 'https://example.com', 'TST100' from category returning id").Scan(&courseId)
+	if err != nil {
+		t.Fatalf("failed to create a test course: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx := context.Background()
+		db.Pool.Exec(ctx, "delete from user_courses where course_id = $1", courseId)
+		db.Pool.Exec(ctx, "delete from syllabi where course_id = $1", courseId)
+		var categoryId string
+		if err := db.Pool.QueryRow(ctx, "delete from courses where id = $1 returning category_id", courseId).Scan(&categoryId); err == nil {
+			db.Pool.Exec(ctx, "delete from course_categories where id = $1", categoryId)
+		}
+	})
+
+	return courseId
+}
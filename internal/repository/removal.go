@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RemovalRequestReason is the status reason of syllabi removed through an approved removal request.
+const RemovalRequestReason = "Removed at the request of the course instructor."
+
+type RemovalRequestSchema struct {
+	Id           string
+	SyllabusId   string
+	UserId       sql.NullString // Verified instructor who requested the removal
+	Reason       string
+	Status       string
+	ReviewedBy   sql.NullString
+	ReviewReason sql.NullString
+	DateReviewed sql.NullTime
+	DateAdded    time.Time
+}
+
+type RemovalRepository interface {
+	// ListRemovalRequests lists removal requests oldest first, requests of every status are listed when status is empty.
+	ListRemovalRequests(ctx context.Context, status string, paginate util.Paginate) ([]RemovalRequestSchema, error)
+	// CreateRemovalRequest requests the removal of a published syllabus uploaded by another user.
+	// Returns [util.ErrForbidden] unless the user is the verified instructor of the syllabus, and [util.ErrConflict]
+	// if the syllabus is not published, was uploaded by the user or already has a pending request.
+	CreateRemovalRequest(ctx context.Context, userId string, syllabusId string, reason string) (RemovalRequestSchema, error)
+	// ReviewRemovalRequest approves or rejects a pending removal request. Approving leaves the syllabus as is,
+	// callers remove it through [SyllabusRepository.TransitionSyllabus].
+	ReviewRemovalRequest(ctx context.Context, requestId string, reviewerId string, status string, reason string) (RemovalRequestSchema, error)
+}
+
+type pgRemovalRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgRemovalRepository(db *database.PostgresDb, log logger.Logger) *pgRemovalRepository {
+	return &pgRemovalRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const removalRequestColumns = "id, syllabus_id, user_id, reason, status, reviewed_by, review_reason, date_reviewed, date_added"
+
+func scanRemovalRequest(row pgx.Row, request *RemovalRequestSchema) error {
+	return row.Scan(
+		&request.Id,
+		&request.SyllabusId,
+		&request.UserId,
+		&request.Reason,
+		&request.Status,
+		&request.ReviewedBy,
+		&request.ReviewReason,
+		&request.DateReviewed,
+		&request.DateAdded,
+	)
+}
+
+func (rr *pgRemovalRepository) ListRemovalRequests(ctx context.Context, status string, paginate util.Paginate) ([]RemovalRequestSchema, error) {
+	qb := util.NewSqlBuilder("select " + removalRequestColumns + " from syllabus_removal_requests")
+	if status != "" {
+		qb.Concat("where status = $%d", status)
+	}
+	qb.Concat("order by date_added, id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := rr.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		rr.log.Error("un-handled list removal requests query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	requests := []RemovalRequestSchema{}
+	for rows.Next() {
+		var request RemovalRequestSchema
+		if err := scanRemovalRequest(rows, &request); err != nil {
+			rr.log.Error("failed to scan removal request row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		rr.log.Error("list removal requests rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return requests, nil
+}
+
+func (rr *pgRemovalRepository) CreateRemovalRequest(ctx context.Context, userId string, syllabusId string, reason string) (RemovalRequestSchema, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return RemovalRequestSchema{}, err
+	}
+
+	tx, err := rr.db.Pool.Begin(ctx)
+	if err != nil {
+		rr.log.Error("failed to begin transaction", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder(
+		"select s.user_id, s.status, i.verified_user_id from syllabi s",
+		"left join instructors i on i.id = s.instructor_id",
+	)
+	qb.Concat("where s.id = $%d", syllabusUuid)
+	qb.Concat("for update of s")
+	lockResult := qb.Result()
+
+	var uploaderId, status string
+	var instructorUserId sql.NullString
+	err = tx.QueryRow(ctx, lockResult.Query, lockResult.Args...).Scan(&uploaderId, &status, &instructorUserId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RemovalRequestSchema{}, util.ErrNotFound
+		}
+
+		rr.log.Error("un-handled lock syllabus query error", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+
+	if !instructorUserId.Valid || instructorUserId.String != userId {
+		rr.log.Info(fmt.Sprintf("user %s attempted to request removal of syllabus %s without being its instructor", userId, syllabusId))
+		return RemovalRequestSchema{}, util.ErrForbidden
+	}
+	// Instructors delete their own uploads directly
+	if uploaderId == userId || status != SyllabusPublished {
+		return RemovalRequestSchema{}, util.ErrConflict
+	}
+
+	qb = util.NewSqlBuilder("insert into syllabus_removal_requests (syllabus_id, user_id, reason)")
+	qb.Concat("values ($%d, $%d, $%d)", syllabusUuid, userId, reason)
+	qb.Concat("returning " + removalRequestColumns)
+	insertResult := qb.Result()
+
+	created := RemovalRequestSchema{}
+	err = scanRemovalRequest(tx.QueryRow(ctx, insertResult.Query, insertResult.Args...), &created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			return RemovalRequestSchema{}, util.ErrConflict
+		}
+
+		rr.log.Error("un-handled create removal request query error", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		rr.log.Error("failed to commit transaction", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+
+	rr.log.Info(fmt.Sprintf("instructor %s requested removal of syllabus %s", userId, syllabusId))
+	return created, nil
+}
+
+func (rr *pgRemovalRepository) ReviewRemovalRequest(ctx context.Context, requestId string, reviewerId string, status string, reason string) (RemovalRequestSchema, error) {
+	if status != ApprovalApproved && status != ApprovalRejected {
+		return RemovalRequestSchema{}, util.ErrMalformed
+	}
+
+	requestUuid, err := database.ParsePgUuid(requestId)
+	if err != nil {
+		return RemovalRequestSchema{}, err
+	}
+
+	var reviewReason *string
+	if reason != "" {
+		reviewReason = &reason
+	}
+
+	tx, err := rr.db.Pool.Begin(ctx)
+	if err != nil {
+		rr.log.Error("failed to begin transaction", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("update syllabus_removal_requests set status = $%d, reviewed_by = $%d, review_reason = $%d, date_reviewed = now()", status, reviewerId, reviewReason)
+	qb.Concat("where id = $%d and status = $%d", requestUuid, ApprovalPending)
+	qb.Concat("returning " + removalRequestColumns)
+	reviewResult := qb.Result()
+
+	reviewed := RemovalRequestSchema{}
+	err = scanRemovalRequest(tx.QueryRow(ctx, reviewResult.Query, reviewResult.Args...), &reviewed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RemovalRequestSchema{}, rr.missingRemovalRequestErr(ctx, tx, requestId)
+		}
+
+		rr.log.Error("un-handled review removal request query error", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		rr.log.Error("failed to commit transaction", logger.Err(err))
+		return RemovalRequestSchema{}, util.ErrInternal
+	}
+
+	rr.log.Info(fmt.Sprintf("removal request %s %s by %s", requestId, status, reviewerId))
+	return reviewed, nil
+}
+
+// missingRemovalRequestErr distinguishes a reviewed removal request from one which does not exist.
+func (rr *pgRemovalRepository) missingRemovalRequestErr(ctx context.Context, tx pgx.Tx, requestId string) error {
+	qb := util.NewSqlBuilder("select exists (select 1 from syllabus_removal_requests")
+	qb.Concat("where id = $%d)", requestId)
+	result := qb.Result()
+
+	var exists bool
+	if err := tx.QueryRow(ctx, result.Query, result.Args...).Scan(&exists); err != nil {
+		rr.log.Error("un-handled removal request exists query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if exists {
+		return util.ErrConflict
+	}
+
+	return util.ErrNotFound
+}
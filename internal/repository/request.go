@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Syllabus request status values, matching the syllabus_request_status database enum.
+const (
+	SyllabusRequestOpen      = "Open"
+	SyllabusRequestFulfilled = "Fulfilled"
+)
+
+type SyllabusRequestSchema struct {
+	Id            string
+	CourseId      string
+	Course        string
+	Year          sql.NullInt16 // Null for requests of any term
+	Semester      sql.NullString
+	Status        string
+	SyllabusId    sql.NullString // Syllabus which fulfilled the request
+	Votes         int
+	Voted         bool // Whether the viewing user voted for the request
+	DateFulfilled sql.NullTime
+	DateAdded     time.Time
+}
+
+// InsertSyllabusRequest holds the optional term of a request, the year and semester are given together.
+type InsertSyllabusRequest struct {
+	Year     *int16
+	Semester *string
+}
+
+type SyllabusRequestFilters struct {
+	CourseId string
+	Status   string
+}
+
+// RequestRecipient is a user to notify of a fulfilled request.
+type RequestRecipient struct {
+	Name  string
+	Email string
+}
+
+type SyllabusRequestRepository interface {
+	// ListSyllabusRequests lists requests with the most votes first, oldest first among equal votes.
+	ListSyllabusRequests(ctx context.Context, userId string, filters SyllabusRequestFilters, paginate util.Paginate) ([]SyllabusRequestSchema, error)
+	// CreateSyllabusRequest requests a syllabus for a course, voting for the open request of the same course and term instead if there is one.
+	// Returns whether a request was created, or [util.ErrConflict] if a published syllabus already satisfies the request.
+	CreateSyllabusRequest(ctx context.Context, userId string, courseId string, entity InsertSyllabusRequest) (SyllabusRequestSchema, bool, error)
+	// VoteSyllabusRequest votes for an open request, returning [util.ErrConflict] if it was fulfilled. Voting twice has no effect.
+	VoteSyllabusRequest(ctx context.Context, userId string, requestId string) error
+	RemoveSyllabusRequestVote(ctx context.Context, userId string, requestId string) error
+	// FulfilSyllabusRequests marks the open requests a published syllabus satisfies as fulfilled.
+	// Returns the users who voted for them, other than the uploader.
+	FulfilSyllabusRequests(ctx context.Context, syllabusId string) ([]RequestRecipient, error)
+}
+
+type pgSyllabusRequestRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgSyllabusRequestRepository(db *database.PostgresDb, log logger.Logger) *pgSyllabusRequestRepository {
+	return &pgSyllabusRequestRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const syllabusRequestVotes = "(select count(*) from syllabus_request_votes v where v.request_id = r.id)"
+
+// selectSyllabusRequestsQuery starts a query of requests as r, viewed by the user.
+func selectSyllabusRequestsQuery(userId string) *util.SqlBuilder {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select r.id, r.course_id, c.course, r.year, r.semester, r.status, r.syllabus_id, " + syllabusRequestVotes + ",")
+	qb.Concat("exists (select 1 from syllabus_request_votes v where v.request_id = r.id and v.user_id = $%d),", userId)
+	qb.Concat("r.date_fulfilled, r.date_added")
+	qb.Concat("from syllabus_requests r")
+	qb.Concat("inner join courses c on c.id = r.course_id")
+
+	return qb
+}
+
+func scanSyllabusRequest(row pgx.Row, request *SyllabusRequestSchema) error {
+	return row.Scan(
+		&request.Id,
+		&request.CourseId,
+		&request.Course,
+		&request.Year,
+		&request.Semester,
+		&request.Status,
+		&request.SyllabusId,
+		&request.Votes,
+		&request.Voted,
+		&request.DateFulfilled,
+		&request.DateAdded,
+	)
+}
+
+func (s *pgSyllabusRequestRepository) ListSyllabusRequests(ctx context.Context, userId string, filters SyllabusRequestFilters, paginate util.Paginate) ([]SyllabusRequestSchema, error) {
+	qb := selectSyllabusRequestsQuery(userId)
+	qb.Concat("where 1 = 1")
+
+	if filters.CourseId != "" {
+		courseUuid, err := database.ParsePgUuid(filters.CourseId)
+		if err != nil {
+			return nil, err
+		}
+		qb.Concat("and r.course_id = $%d", courseUuid)
+	}
+	if filters.Status != "" {
+		qb.Concat("and r.status = $%d", filters.Status)
+	}
+
+	qb.Concat("order by " + syllabusRequestVotes + " desc, r.date_added, r.id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		s.log.Error("un-handled list syllabus requests query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	requests := []SyllabusRequestSchema{}
+	for rows.Next() {
+		var request SyllabusRequestSchema
+		if err := scanSyllabusRequest(rows, &request); err != nil {
+			s.log.Error("failed to scan syllabus request row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		s.log.Error("list syllabus requests rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return requests, nil
+}
+
+func (s *pgSyllabusRequestRepository) CreateSyllabusRequest(ctx context.Context, userId string, courseId string, entity InsertSyllabusRequest) (SyllabusRequestSchema, bool, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return SyllabusRequestSchema{}, false, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder("select exists (select 1 from syllabi")
+	qb.Concat("where course_id = $%d and status = $%d", courseUuid, SyllabusPublished)
+	if entity.Year != nil {
+		qb.Concat("and year = $%d and semester = $%d", *entity.Year, *entity.Semester)
+	}
+	qb.Concat(")")
+	availableResult := qb.Result()
+
+	var available bool
+	err = tx.QueryRow(ctx, availableResult.Query, availableResult.Args...).Scan(&available)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return SyllabusRequestSchema{}, false, util.ErrMalformed
+		}
+
+		s.log.Error("un-handled syllabus available query error", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+	if available {
+		return SyllabusRequestSchema{}, false, util.ErrConflict
+	}
+
+	// Nothing is inserted when the course and term already have an open request
+	qb = util.NewSqlBuilder("insert into syllabus_requests (course_id, year, semester, user_id)")
+	qb.Concat("values ($%d, $%d, $%d, $%d)", courseUuid, entity.Year, entity.Semester, userId)
+	qb.Concat("on conflict do nothing")
+	qb.Concat("returning id")
+	insertResult := qb.Result()
+
+	created := true
+	var requestUuid pgtype.UUID
+	err = tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&requestUuid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		created = false
+
+		qb = util.NewSqlBuilder("select id from syllabus_requests")
+		qb.Concat("where course_id = $%d and status = $%d", courseUuid, SyllabusRequestOpen)
+		qb.Concat("and year is not distinct from $%d and semester is not distinct from $%d", entity.Year, entity.Semester)
+		existingResult := qb.Result()
+
+		err = tx.QueryRow(ctx, existingResult.Query, existingResult.Args...).Scan(&requestUuid)
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgFKeyViolationErrCode {
+				return SyllabusRequestSchema{}, false, util.ErrNotFound
+			} else if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+				return SyllabusRequestSchema{}, false, util.ErrMalformed
+			}
+		}
+
+		s.log.Error("un-handled create syllabus request query error", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder("insert into syllabus_request_votes (request_id, user_id)")
+	qb.Concat("values ($%d, $%d)", requestUuid, userId)
+	qb.Concat("on conflict do nothing")
+	voteResult := qb.Result()
+
+	if _, err := tx.Exec(ctx, voteResult.Query, voteResult.Args...); err != nil {
+		s.log.Error("un-handled syllabus request vote query error", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+
+	qb = selectSyllabusRequestsQuery(userId)
+	qb.Concat("where r.id = $%d", requestUuid)
+	getResult := qb.Result()
+
+	request := SyllabusRequestSchema{}
+	if err := scanSyllabusRequest(tx.QueryRow(ctx, getResult.Query, getResult.Args...), &request); err != nil {
+		s.log.Error("un-handled get syllabus request query error", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return SyllabusRequestSchema{}, false, util.ErrInternal
+	}
+
+	if created {
+		s.log.Info(fmt.Sprintf("user %s requested a syllabus for course %s", userId, courseId))
+	}
+	return request, created, nil
+}
+
+func (s *pgSyllabusRequestRepository) VoteSyllabusRequest(ctx context.Context, userId string, requestId string) error {
+	requestUuid, err := database.ParsePgUuid(requestId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("select status from syllabus_requests")
+	qb.Concat("where id = $%d", requestUuid)
+	statusResult := qb.Result()
+
+	var status string
+	err = s.db.Pool.QueryRow(ctx, statusResult.Query, statusResult.Args...).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		s.log.Error("un-handled get syllabus request status query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if status != SyllabusRequestOpen {
+		return util.ErrConflict
+	}
+
+	qb = util.NewSqlBuilder("insert into syllabus_request_votes (request_id, user_id)")
+	qb.Concat("values ($%d, $%d)", requestUuid, userId)
+	qb.Concat("on conflict do nothing")
+	voteResult := qb.Result()
+
+	if _, err := s.db.Pool.Exec(ctx, voteResult.Query, voteResult.Args...); err != nil {
+		s.log.Error("un-handled syllabus request vote query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *pgSyllabusRequestRepository) RemoveSyllabusRequestVote(ctx context.Context, userId string, requestId string) error {
+	requestUuid, err := database.ParsePgUuid(requestId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from syllabus_request_votes")
+	qb.Concat("where request_id = $%d and user_id = $%d", requestUuid, userId)
+	result := qb.Result()
+
+	tag, err := s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled remove syllabus request vote query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *pgSyllabusRequestRepository) FulfilSyllabusRequests(ctx context.Context, syllabusId string) ([]RequestRecipient, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder("with fulfilled as (")
+	qb.Concat("update syllabus_requests r set status = $%d, syllabus_id = s.id, date_fulfilled = now()", SyllabusRequestFulfilled)
+	qb.Concat("from syllabi s")
+	qb.Concat("where s.id = $%d and s.status = $%d", syllabusUuid, SyllabusPublished)
+	qb.Concat("and r.course_id = s.course_id and r.status = $%d", SyllabusRequestOpen)
+	qb.Concat("and (r.year is null or (r.year = s.year and r.semester = s.semester))")
+	qb.Concat("returning r.id, s.user_id as uploader_id")
+	qb.Concat(")")
+	qb.Concat("select distinct u.full_name, u.email from fulfilled f")
+	qb.Concat("inner join syllabus_request_votes v on v.request_id = f.id")
+	qb.Concat("inner join users u on u.id = v.user_id")
+	qb.Concat("where u.id <> f.uploader_id")
+	result := qb.Result()
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled fulfil syllabus requests query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	recipients := []RequestRecipient{}
+	for rows.Next() {
+		var recipient RequestRecipient
+		if err := rows.Scan(&recipient.Name, &recipient.Email); err != nil {
+			s.log.Error("failed to scan request recipient row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Error("fulfil syllabus requests rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return recipients, nil
+}
@@ -0,0 +1,483 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Program requirement types, matching the program_requirement_type database enum.
+const (
+	RequirementRequired = "Required" // Every listed course
+	RequirementElective = "Elective" // Credits from the listed courses and categories
+	RequirementCredits  = "Credits"  // Credits from the listed courses and categories, or any course
+)
+
+// Requirement progress statuses.
+const (
+	ProgressCompleted      = "Completed"
+	ProgressInProgress     = "InProgress"
+	ProgressMissing        = "Missing"
+	ProgressUnknown        = "Unknown"        // Courses without a term, which may or may not be completed
+	ProgressNoRequirements = "NoRequirements" // The program has no requirements to evaluate
+)
+
+// progressPriority orders course statuses by how courses are claimed, and requirement statuses by how they combine into an overall status.
+var progressPriority = map[string]int{
+	ProgressCompleted:  0,
+	ProgressInProgress: 1,
+	ProgressUnknown:    2,
+	ProgressMissing:    3,
+}
+
+type RequirementCourseSchema struct {
+	CourseId string
+	Course   string
+	Title    string
+	Credits  float64
+}
+
+type ProgramRequirementSchema struct {
+	Id          string
+	ProgramId   string
+	Name        string
+	Description sql.NullString
+	Type        string
+	Credits     sql.NullFloat64 // Null for Required requirements
+	Courses     []RequirementCourseSchema
+	CategoryIds []string
+}
+
+// counts reports whether a course counts towards the requirement.
+func (r ProgramRequirementSchema) counts(course ProgressCourseSchema) bool {
+	if r.Type == RequirementCredits && len(r.Courses) == 0 && len(r.CategoryIds) == 0 {
+		return true
+	}
+
+	for _, required := range r.Courses {
+		if required.CourseId == course.CourseId {
+			return true
+		}
+	}
+	for _, categoryId := range r.CategoryIds {
+		if categoryId == course.CategoryId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProgressCourseSchema is a user course evaluated against program requirements.
+type ProgressCourseSchema struct {
+	CourseId   string
+	Course     string
+	Title      string
+	CategoryId string
+	Credits    float64
+	Status     string // Completed, InProgress if its term has not ended, or Unknown without a term
+}
+
+type InsertProgramRequirement struct {
+	Name        string
+	Description *string
+	Type        string
+	Credits     *float64
+	CourseIds   []string
+	CategoryIds []string
+}
+
+type RequirementProgressSchema struct {
+	Requirement       ProgramRequirementSchema
+	Status            string
+	CreditsCompleted  float64
+	CreditsInProgress float64
+	CreditsUnknown    float64
+	Completed         []ProgressCourseSchema
+	InProgress        []ProgressCourseSchema
+	Unknown           []ProgressCourseSchema
+	Missing           []RequirementCourseSchema // Required courses which were not taken
+}
+
+// requirementPriority orders how requirements claim courses, specific requirements claim courses before electives.
+var requirementPriority = map[string]int{
+	RequirementRequired: 0,
+	RequirementElective: 1,
+	RequirementCredits:  2,
+}
+
+// EvaluateProgramProgress evaluates user courses against a program's requirements, in the order of the requirements.
+// A course counts towards at most one Required or Elective requirement, Credits requirements count every course.
+// Requirements which are only met by counting courses without a term are Unknown.
+func EvaluateProgramProgress(requirements []ProgramRequirementSchema, courses []ProgressCourseSchema) []RequirementProgressSchema {
+	// Completed courses are claimed before courses in progress, then courses without a term
+	ordered := append([]ProgressCourseSchema{}, courses...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return progressPriority[ordered[i].Status] < progressPriority[ordered[j].Status]
+	})
+
+	indexes := make([]int, len(requirements))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return requirementPriority[requirements[indexes[i]].Type] < requirementPriority[requirements[indexes[j]].Type]
+	})
+
+	claimed := map[string]bool{}
+	progress := make([]RequirementProgressSchema, len(requirements))
+	for _, i := range indexes {
+		requirement := requirements[i]
+		result := RequirementProgressSchema{
+			Requirement: requirement,
+			Completed:   []ProgressCourseSchema{},
+			InProgress:  []ProgressCourseSchema{},
+			Unknown:     []ProgressCourseSchema{},
+			Missing:     []RequirementCourseSchema{},
+		}
+		add := func(course ProgressCourseSchema) {
+			switch course.Status {
+			case ProgressCompleted:
+				result.Completed = append(result.Completed, course)
+				result.CreditsCompleted += course.Credits
+			case ProgressInProgress:
+				result.InProgress = append(result.InProgress, course)
+				result.CreditsInProgress += course.Credits
+			default:
+				result.Unknown = append(result.Unknown, course)
+				result.CreditsUnknown += course.Credits
+			}
+		}
+
+		if requirement.Type == RequirementRequired {
+			for _, required := range requirement.Courses {
+				found := false
+				for _, course := range ordered {
+					if course.CourseId == required.CourseId {
+						add(course)
+						claimed[course.CourseId] = true
+						found = true
+						break
+					}
+				}
+				if !found {
+					result.Missing = append(result.Missing, required)
+				}
+			}
+
+			if len(result.Missing) > 0 {
+				result.Status = ProgressMissing
+			} else if len(result.Unknown) > 0 {
+				result.Status = ProgressUnknown
+			} else if len(result.InProgress) > 0 {
+				result.Status = ProgressInProgress
+			} else {
+				result.Status = ProgressCompleted
+			}
+		} else {
+			target := requirement.Credits.Float64
+			for _, course := range ordered {
+				if !requirement.counts(course) {
+					continue
+				}
+				if requirement.Type == RequirementElective {
+					if claimed[course.CourseId] || result.CreditsCompleted+result.CreditsInProgress+result.CreditsUnknown >= target {
+						continue
+					}
+					claimed[course.CourseId] = true
+				}
+				add(course)
+			}
+
+			if result.CreditsCompleted >= target {
+				result.Status = ProgressCompleted
+			} else if result.CreditsCompleted+result.CreditsInProgress >= target {
+				result.Status = ProgressInProgress
+			} else if result.CreditsCompleted+result.CreditsInProgress+result.CreditsUnknown >= target {
+				result.Status = ProgressUnknown
+			} else {
+				result.Status = ProgressMissing
+			}
+		}
+
+		progress[i] = result
+	}
+
+	return progress
+}
+
+// ProgressOutranks reports whether a requirement status takes precedence over another when combining statuses,
+// from Completed through InProgress and Unknown to Missing.
+func ProgressOutranks(status string, other string) bool {
+	return progressPriority[status] > progressPriority[other]
+}
+
+type RequirementRepository interface {
+	// ListProgramRequirements lists a program's requirements in their defined order.
+	ListProgramRequirements(ctx context.Context, programId string) ([]ProgramRequirementSchema, error)
+	// SetProgramRequirements replaces a program's requirements, keeping the order of the slice.
+	// Returns [util.ErrNotFound] if the program or a listed course or category does not exist.
+	SetProgramRequirements(ctx context.Context, programId string, requirements []InsertProgramRequirement) error
+	// ListProgressCourses lists a user's user courses with the details needed to evaluate requirements.
+	ListProgressCourses(ctx context.Context, userId string) ([]ProgressCourseSchema, error)
+}
+
+type pgRequirementRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgRequirementRepository(db *database.PostgresDb, log logger.Logger) *pgRequirementRepository {
+	return &pgRequirementRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (rq *pgRequirementRepository) ListProgramRequirements(ctx context.Context, programId string) ([]ProgramRequirementSchema, error) {
+	programUuid, err := database.ParsePgUuid(programId)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder("select exists (select 1 from programs")
+	qb.Concat("where id = $%d)", programUuid)
+	existsResult := qb.Result()
+
+	var exists bool
+	if err := rq.db.Pool.QueryRow(ctx, existsResult.Query, existsResult.Args...).Scan(&exists); err != nil {
+		rq.log.Error("un-handled program exists query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	if !exists {
+		return nil, util.ErrNotFound
+	}
+
+	qb = util.NewSqlBuilder("select id, program_id, name, description, type, credits::float8 from program_requirements")
+	qb.Concat("where program_id = $%d", programUuid)
+	qb.Concat("order by position, date_added")
+	requirementsResult := qb.Result()
+
+	rows, err := rq.db.Pool.Query(ctx, requirementsResult.Query, requirementsResult.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list program requirements query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	requirements := []ProgramRequirementSchema{}
+	positions := map[string]int{}
+	for rows.Next() {
+		requirement := ProgramRequirementSchema{
+			Courses:     []RequirementCourseSchema{},
+			CategoryIds: []string{},
+		}
+		err := rows.Scan(&requirement.Id, &requirement.ProgramId, &requirement.Name, &requirement.Description,
+			&requirement.Type, &requirement.Credits)
+		if err != nil {
+			rq.log.Error("failed to scan program requirement row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		positions[requirement.Id] = len(requirements)
+		requirements = append(requirements, requirement)
+	}
+	if err := rows.Err(); err != nil {
+		rq.log.Error("list program requirements rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder(
+		"select rc.requirement_id, c.id, c.course, c.title, c.credits::float8 from program_requirement_courses rc",
+		"inner join program_requirements pr on pr.id = rc.requirement_id",
+		"inner join courses c on c.id = rc.course_id",
+	)
+	qb.Concat("where pr.program_id = $%d", programUuid)
+	qb.Concat("order by c.course")
+	coursesResult := qb.Result()
+
+	courseRows, err := rq.db.Pool.Query(ctx, coursesResult.Query, coursesResult.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list requirement courses query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer courseRows.Close()
+
+	for courseRows.Next() {
+		var requirementId string
+		var course RequirementCourseSchema
+		if err := courseRows.Scan(&requirementId, &course.CourseId, &course.Course, &course.Title, &course.Credits); err != nil {
+			rq.log.Error("failed to scan requirement course row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		i := positions[requirementId]
+		requirements[i].Courses = append(requirements[i].Courses, course)
+	}
+	if err := courseRows.Err(); err != nil {
+		rq.log.Error("list requirement courses rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder(
+		"select rc.requirement_id, rc.category_id from program_requirement_categories rc",
+		"inner join program_requirements pr on pr.id = rc.requirement_id",
+	)
+	qb.Concat("where pr.program_id = $%d", programUuid)
+	categoriesResult := qb.Result()
+
+	categoryRows, err := rq.db.Pool.Query(ctx, categoriesResult.Query, categoriesResult.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list requirement categories query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer categoryRows.Close()
+
+	for categoryRows.Next() {
+		var requirementId, categoryId string
+		if err := categoryRows.Scan(&requirementId, &categoryId); err != nil {
+			rq.log.Error("failed to scan requirement category row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		i := positions[requirementId]
+		requirements[i].CategoryIds = append(requirements[i].CategoryIds, categoryId)
+	}
+	if err := categoryRows.Err(); err != nil {
+		rq.log.Error("list requirement categories rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return requirements, nil
+}
+
+func (rq *pgRequirementRepository) SetProgramRequirements(ctx context.Context, programId string, requirements []InsertProgramRequirement) error {
+	programUuid, err := database.ParsePgUuid(programId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := rq.db.Pool.Begin(ctx)
+	if err != nil {
+		rq.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("delete from program_requirements where program_id = $%d", programUuid)
+	deleteResult := qb.Result()
+
+	if _, err := tx.Exec(ctx, deleteResult.Query, deleteResult.Args...); err != nil {
+		rq.log.Error("un-handled delete program requirements query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	for position, requirement := range requirements {
+		qb = util.NewSqlBuilder("insert into program_requirements (program_id, name, description, type, credits, position)")
+		qb.Concat("values ($%d, $%d, $%d, $%d, $%d, $%d)", programUuid, requirement.Name, requirement.Description, requirement.Type, requirement.Credits, position)
+		qb.Concat("returning id")
+		insertResult := qb.Result()
+
+		var requirementId string
+		if err := tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&requirementId); err != nil {
+			return rq.requirementErr(err)
+		}
+
+		for _, courseId := range requirement.CourseIds {
+			if err := rq.insertRequirementMember(ctx, tx, "program_requirement_courses (requirement_id, course_id)", requirementId, courseId); err != nil {
+				return err
+			}
+		}
+		for _, categoryId := range requirement.CategoryIds {
+			if err := rq.insertRequirementMember(ctx, tx, "program_requirement_categories (requirement_id, category_id)", requirementId, categoryId); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		rq.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	rq.log.Info(fmt.Sprintf("program %s requirements set", programId))
+	return nil
+}
+
+// insertRequirementMember inserts a course or category of a requirement into table.
+func (rq *pgRequirementRepository) insertRequirementMember(ctx context.Context, tx pgx.Tx, table string, requirementId string, memberId string) error {
+	memberUuid, err := database.ParsePgUuid(memberId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("insert into " + table)
+	qb.Concat("values ($%d, $%d)", requirementId, memberUuid)
+	qb.Concat("on conflict do nothing")
+	result := qb.Result()
+
+	if _, err := tx.Exec(ctx, result.Query, result.Args...); err != nil {
+		return rq.requirementErr(err)
+	}
+
+	return nil
+}
+
+// requirementErr maps an error inserting program requirements.
+func (rq *pgRequirementRepository) requirementErr(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == database.PgFKeyViolationErrCode {
+			return util.ErrNotFound
+		} else if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+			return util.ErrMalformed
+		}
+	}
+
+	rq.log.Error("un-handled insert program requirement query error", logger.Err(err))
+	return util.ErrInternal
+}
+
+func (rq *pgRequirementRepository) ListProgressCourses(ctx context.Context, userId string) ([]ProgressCourseSchema, error) {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select c.id, c.course, c.title, c.category_id, c.credits::float8,")
+	qb.Concat("case when t.id is null then $%d when "+userCourseCompletedColumn+" then $%d else $%d end",
+		ProgressUnknown, ProgressCompleted, ProgressInProgress)
+	qb.Concat("from user_courses uc")
+	qb.Concat("inner join courses c on c.id = uc.course_id")
+	qb.Concat("left join terms t on t.id = uc.term_id")
+	qb.Concat("where uc.user_id = $%d", userId)
+	qb.Concat("order by c.course")
+	result := qb.Result()
+
+	rows, err := rq.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list progress courses query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	courses := []ProgressCourseSchema{}
+	for rows.Next() {
+		var course ProgressCourseSchema
+		err := rows.Scan(&course.CourseId, &course.Course, &course.Title, &course.CategoryId, &course.Credits, &course.Status)
+		if err != nil {
+			rq.log.Error("failed to scan progress course row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		rq.log.Error("list progress courses rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return courses, nil
+}
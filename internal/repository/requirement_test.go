@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+)
+
+func progressCourse(courseId string, categoryId string, credits float64, status string) ProgressCourseSchema {
+	return ProgressCourseSchema{CourseId: courseId, CategoryId: categoryId, Credits: credits, Status: status}
+}
+
+func programRequirement(requirementType string, credits float64, courseIds []string, categoryIds ...string) ProgramRequirementSchema {
+	requirement := ProgramRequirementSchema{Type: requirementType, CategoryIds: categoryIds}
+	if requirementType != RequirementRequired {
+		requirement.Credits = sql.NullFloat64{Float64: credits, Valid: true}
+	}
+	for _, courseId := range courseIds {
+		requirement.Courses = append(requirement.Courses, RequirementCourseSchema{CourseId: courseId})
+	}
+
+	return requirement
+}
+
+// requirementProgress summarizes a requirement's progress by course ids.
+type requirementProgress struct {
+	status     string
+	completed  []string
+	inProgress []string
+	unknown    []string
+	missing    []string
+}
+
+func summarizeProgress(progress RequirementProgressSchema) requirementProgress {
+	summary := requirementProgress{status: progress.Status}
+	for _, course := range progress.Completed {
+		summary.completed = append(summary.completed, course.CourseId)
+	}
+	for _, course := range progress.InProgress {
+		summary.inProgress = append(summary.inProgress, course.CourseId)
+	}
+	for _, course := range progress.Unknown {
+		summary.unknown = append(summary.unknown, course.CourseId)
+	}
+	for _, course := range progress.Missing {
+		summary.missing = append(summary.missing, course.CourseId)
+	}
+
+	return summary
+}
+
+func TestEvaluateProgramProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements []ProgramRequirementSchema
+		courses      []ProgressCourseSchema
+		want         []requirementProgress
+	}{
+		{
+			name: "required courses",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementRequired, 0, []string{"a", "b", "c"}),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "", 1, ProgressCompleted),
+				progressCourse("b", "", 1, ProgressInProgress),
+			},
+			want: []requirementProgress{
+				{status: ProgressMissing, completed: []string{"a"}, inProgress: []string{"b"}, missing: []string{"c"}},
+			},
+		},
+		{
+			name: "required course in progress",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementRequired, 0, []string{"a", "b"}),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "", 1, ProgressCompleted),
+				progressCourse("b", "", 1, ProgressInProgress),
+			},
+			want: []requirementProgress{
+				{status: ProgressInProgress, completed: []string{"a"}, inProgress: []string{"b"}},
+			},
+		},
+		{
+			name: "required course without a term",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementRequired, 0, []string{"a", "b"}),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "", 1, ProgressCompleted),
+				progressCourse("b", "", 1, ProgressUnknown),
+			},
+			want: []requirementProgress{
+				{status: ProgressUnknown, completed: []string{"a"}, unknown: []string{"b"}},
+			},
+		},
+		{
+			name: "required courses are not claimed by electives",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 2, nil, "cs"),
+				programRequirement(RequirementRequired, 0, []string{"a"}),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressCompleted),
+				progressCourse("b", "cs", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressMissing, completed: []string{"b"}},
+				{status: ProgressCompleted, completed: []string{"a"}},
+			},
+		},
+		{
+			name: "overlapping electives claim a course once",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 1, []string{"a", "b"}),
+				programRequirement(RequirementElective, 1, []string{"a"}, "cs"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressCompleted, completed: []string{"a"}},
+				{status: ProgressMissing},
+			},
+		},
+		{
+			name: "credits count every course",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementRequired, 0, []string{"a"}),
+				programRequirement(RequirementElective, 1, nil, "cs"),
+				programRequirement(RequirementCredits, 4, nil),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressCompleted),
+				progressCourse("b", "cs", 1, ProgressCompleted),
+				progressCourse("c", "math", 1, ProgressCompleted),
+				progressCourse("d", "math", 1, ProgressInProgress),
+			},
+			want: []requirementProgress{
+				{status: ProgressCompleted, completed: []string{"a"}},
+				{status: ProgressCompleted, completed: []string{"b"}},
+				{status: ProgressInProgress, completed: []string{"a", "b", "c"}, inProgress: []string{"d"}},
+			},
+		},
+		{
+			name: "credits limited to listed categories",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementCredits, 2, nil, "math"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressCompleted),
+				progressCourse("b", "math", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressMissing, completed: []string{"b"}},
+			},
+		},
+		{
+			name: "completed courses are claimed before courses in progress",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 1, nil, "cs"),
+				programRequirement(RequirementElective, 1, nil, "cs"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressInProgress),
+				progressCourse("b", "cs", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressCompleted, completed: []string{"b"}},
+				{status: ProgressInProgress, inProgress: []string{"a"}},
+			},
+		},
+		{
+			name: "courses without a term are claimed last",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 1, nil, "cs"),
+				programRequirement(RequirementElective, 1, nil, "cs"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressUnknown),
+				progressCourse("b", "cs", 1, ProgressInProgress),
+			},
+			want: []requirementProgress{
+				{status: ProgressInProgress, inProgress: []string{"b"}},
+				{status: ProgressUnknown, unknown: []string{"a"}},
+			},
+		},
+		{
+			name: "exact credit target",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 1.5, nil, "cs"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 0.5, ProgressCompleted),
+				progressCourse("b", "cs", 1, ProgressCompleted),
+				progressCourse("c", "cs", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressCompleted, completed: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "credit target short",
+			requirements: []ProgramRequirementSchema{
+				programRequirement(RequirementElective, 1.5, nil, "cs"),
+			},
+			courses: []ProgressCourseSchema{
+				progressCourse("a", "cs", 1, ProgressCompleted),
+			},
+			want: []requirementProgress{
+				{status: ProgressMissing, completed: []string{"a"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			progress := EvaluateProgramProgress(test.requirements, test.courses)
+			if len(progress) != len(test.want) {
+				t.Fatalf("EvaluateProgramProgress() returned %d requirements, want %d", len(progress), len(test.want))
+			}
+
+			for i, want := range test.want {
+				if got := summarizeProgress(progress[i]); !reflect.DeepEqual(got, want) {
+					t.Errorf("requirement %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestListProgressCourses(t *testing.T) {
+	db := testPostgresDb(t)
+	userRepo := NewPgUserRepository(db, logger.NewTextLogger())
+	requirementRepo := NewPgRequirementRepository(db, logger.NewTextLogger())
+	userId := createTestUser(t, db)
+
+	var pastTermId string
+	err := db.Pool.QueryRow(context.Background(),
+		"select id from terms where institution = 'Toronto Metropolitan University' and year = 2020 and semester = 'Fall'").Scan(&pastTermId)
+	if err != nil {
+		t.Fatalf("failed to read the Fall 2020 term: %v", err)
+	}
+	currentTermId := CurrentTerm
+
+	completedId := createTestCourse(t, db)
+	inProgressId := createTestCourse(t, db)
+	unknownId := createTestCourse(t, db)
+	for _, course := range []InsertUserCourse{
+		{CourseId: completedId, TermId: &pastTermId},
+		{CourseId: inProgressId, TermId: &currentTermId},
+		{CourseId: unknownId},
+	} {
+		if err := userRepo.AddUserCourse(context.Background(), userId, course); err != nil {
+			t.Fatalf("AddUserCourse() error = %v", err)
+		}
+	}
+
+	courses, err := requirementRepo.ListProgressCourses(context.Background(), userId)
+	if err != nil {
+		t.Fatalf("ListProgressCourses() error = %v", err)
+	}
+
+	statuses := map[string]string{}
+	for _, course := range courses {
+		statuses[course.CourseId] = course.Status
+	}
+	want := map[string]string{completedId: ProgressCompleted, inProgressId: ProgressInProgress, unknownId: ProgressUnknown}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("ListProgressCourses() statuses = %v, want %v", statuses, want)
+	}
+}
@@ -0,0 +1,493 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Requisite type values, matching the requisite_type database enum.
+const (
+	RequisitePrerequisite  = "Prerequisite"
+	RequisiteCorequisite   = "Corequisite"
+	RequisiteAntirequisite = "Antirequisite"
+)
+
+// Requisite group operators, matching the requisite_operator database enum.
+const (
+	RequisiteAll = "All"
+	RequisiteAny = "Any" // One of
+)
+
+type RequisiteCourseSchema struct {
+	CourseId string
+	Course   string
+	Title    string
+}
+
+// RequisiteGroupSchema is a node of a course's requisite tree, combining its courses and nested groups with its operator.
+type RequisiteGroupSchema struct {
+	Id       string
+	Type     string
+	Operator string
+	Courses  []RequisiteCourseSchema
+	Groups   []RequisiteGroupSchema
+}
+
+// Satisfied evaluates the group against a set of taken course ids.
+// Empty groups require nothing and are satisfied, except that an empty nested group cannot satisfy an Any group.
+func (g RequisiteGroupSchema) Satisfied(taken map[string]bool) bool {
+	if g.empty() {
+		return true
+	}
+
+	anyOf := g.Operator == RequisiteAny
+	for _, course := range g.Courses {
+		if taken[course.CourseId] == anyOf {
+			return anyOf
+		}
+	}
+	for _, group := range g.Groups {
+		if anyOf && group.empty() {
+			continue
+		}
+		if group.Satisfied(taken) == anyOf {
+			return anyOf
+		}
+	}
+
+	return !anyOf
+}
+
+func (g RequisiteGroupSchema) empty() bool {
+	return len(g.Courses) == 0 && len(g.Groups) == 0
+}
+
+// TakenCourses lists the group's courses, at any depth, which are in a set of taken course ids.
+func (g RequisiteGroupSchema) TakenCourses(taken map[string]bool) []RequisiteCourseSchema {
+	courses := []RequisiteCourseSchema{}
+	for _, course := range g.Courses {
+		if taken[course.CourseId] {
+			courses = append(courses, course)
+		}
+	}
+	for _, group := range g.Groups {
+		courses = append(courses, group.TakenCourses(taken)...)
+	}
+
+	return courses
+}
+
+// CourseRequisitesSchema holds the root requisite group of each type, nil for types the course has none of.
+type CourseRequisitesSchema struct {
+	Prerequisites  *RequisiteGroupSchema
+	Corequisites   *RequisiteGroupSchema
+	Antirequisites *RequisiteGroupSchema
+}
+
+// UnlockedCourseSchema is a course which lists another course in its prerequisites or corequisites.
+type UnlockedCourseSchema struct {
+	CourseId string
+	Course   string
+	Title    string
+	Type     string
+}
+
+// TakenCoursesSchema holds the sets of course ids in a user's user courses.
+type TakenCoursesSchema struct {
+	Taken     map[string]bool // Every user course, including those in progress
+	Completed map[string]bool // User courses whose term has ended
+	Unknown   map[string]bool // User courses without a term, which may or may not be completed
+}
+
+// EligibilitySchema is the outcome of evaluating a course's requisites against a user's courses.
+type EligibilitySchema struct {
+	PrerequisitesMet bool // Prerequisites are met by completed courses
+	// PrerequisitesUnknown is set when prerequisites are only met by also counting courses without a term.
+	PrerequisitesUnknown bool
+	CorequisitesMet      bool                    // Corequisites are met by courses taken at any time
+	Antirequisites       []RequisiteCourseSchema // Antirequisites the user has completed or is taking
+}
+
+// Eligible reports whether the user meets the prerequisites and has none of the antirequisites.
+func (e EligibilitySchema) Eligible() bool {
+	return e.PrerequisitesMet && len(e.Antirequisites) == 0
+}
+
+// EvaluateEligibility evaluates a course's requisites against a user's courses.
+// Prerequisites must be completed, while corequisites and antirequisites count courses in progress.
+func EvaluateEligibility(requisites CourseRequisitesSchema, taken TakenCoursesSchema) EligibilitySchema {
+	eligibility := EligibilitySchema{
+		PrerequisitesMet: true,
+		CorequisitesMet:  true,
+		Antirequisites:   []RequisiteCourseSchema{},
+	}
+
+	if requisites.Prerequisites != nil && !requisites.Prerequisites.Satisfied(taken.Completed) {
+		eligibility.PrerequisitesMet = false
+
+		completedOrUnknown := make(map[string]bool, len(taken.Completed)+len(taken.Unknown))
+		for courseId := range taken.Completed {
+			completedOrUnknown[courseId] = true
+		}
+		for courseId := range taken.Unknown {
+			completedOrUnknown[courseId] = true
+		}
+		eligibility.PrerequisitesUnknown = requisites.Prerequisites.Satisfied(completedOrUnknown)
+	}
+	if requisites.Corequisites != nil {
+		eligibility.CorequisitesMet = requisites.Corequisites.Satisfied(taken.Taken)
+	}
+	if requisites.Antirequisites != nil {
+		eligibility.Antirequisites = requisites.Antirequisites.TakenCourses(taken.Taken)
+	}
+
+	return eligibility
+}
+
+// userCourseCompletedColumn selects whether a user course joined with its term as t is completed.
+// Courses without a term are not known to be completed.
+const userCourseCompletedColumn = "coalesce(t.date_end < current_date, false)"
+
+type InsertRequisiteGroup struct {
+	Operator  string
+	CourseIds []string
+	Groups    []InsertRequisiteGroup
+}
+
+type RequisiteRepository interface {
+	GetCourseRequisites(ctx context.Context, courseId string) (CourseRequisitesSchema, error)
+	// ListUnlockedCourses lists the courses which require a course as a prerequisite or corequisite.
+	ListUnlockedCourses(ctx context.Context, courseId string, paginate util.Paginate) ([]UnlockedCourseSchema, error)
+	// SetCourseRequisites replaces a course's requisites of a type, a nil group removes them.
+	// Returns [util.ErrNotFound] if the course or a required course does not exist.
+	SetCourseRequisites(ctx context.Context, courseId string, requisiteType string, group *InsertRequisiteGroup) error
+	// ListTakenCourses returns the sets of courses a user has taken, completed or taken without a term.
+	ListTakenCourses(ctx context.Context, userId string) (TakenCoursesSchema, error)
+}
+
+type pgRequisiteRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgRequisiteRepository(db *database.PostgresDb, log logger.Logger) *pgRequisiteRepository {
+	return &pgRequisiteRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// requisiteGroupRow is a requisite group read before the tree is assembled.
+type requisiteGroupRow struct {
+	group    RequisiteGroupSchema
+	parentId sql.NullString
+}
+
+func (rq *pgRequisiteRepository) GetCourseRequisites(ctx context.Context, courseId string) (CourseRequisitesSchema, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return CourseRequisitesSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder("select exists (select 1 from courses")
+	qb.Concat("where id = $%d)", courseUuid)
+	existsResult := qb.Result()
+
+	var exists bool
+	if err := rq.db.Pool.QueryRow(ctx, existsResult.Query, existsResult.Args...).Scan(&exists); err != nil {
+		rq.log.Error("un-handled course exists query error", logger.Err(err))
+		return CourseRequisitesSchema{}, util.ErrInternal
+	}
+	if !exists {
+		return CourseRequisitesSchema{}, util.ErrNotFound
+	}
+
+	qb = util.NewSqlBuilder("select id, parent_id, type, operator from course_requisite_groups")
+	qb.Concat("where course_id = $%d", courseUuid)
+	qb.Concat("order by date_added, id")
+	groupsResult := qb.Result()
+
+	rows, err := rq.db.Pool.Query(ctx, groupsResult.Query, groupsResult.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list requisite groups query error", logger.Err(err))
+		return CourseRequisitesSchema{}, util.ErrInternal
+	}
+	defer rows.Close()
+
+	groups := []requisiteGroupRow{}
+	for rows.Next() {
+		var row requisiteGroupRow
+		if err := rows.Scan(&row.group.Id, &row.parentId, &row.group.Type, &row.group.Operator); err != nil {
+			rq.log.Error("failed to scan requisite group row", logger.Err(err))
+			return CourseRequisitesSchema{}, util.ErrInternal
+		}
+		groups = append(groups, row)
+	}
+	if err := rows.Err(); err != nil {
+		rq.log.Error("list requisite groups rows error", logger.Err(err))
+		return CourseRequisitesSchema{}, util.ErrInternal
+	}
+
+	qb = util.NewSqlBuilder(
+		"select r.group_id, c.id, c.course, c.title from course_requisites r",
+		"inner join course_requisite_groups g on g.id = r.group_id",
+		"inner join courses c on c.id = r.course_id",
+	)
+	qb.Concat("where g.course_id = $%d", courseUuid)
+	qb.Concat("order by c.course")
+	coursesResult := qb.Result()
+
+	courseRows, err := rq.db.Pool.Query(ctx, coursesResult.Query, coursesResult.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list requisite courses query error", logger.Err(err))
+		return CourseRequisitesSchema{}, util.ErrInternal
+	}
+	defer courseRows.Close()
+
+	groupCourses := map[string][]RequisiteCourseSchema{}
+	for courseRows.Next() {
+		var groupId string
+		var course RequisiteCourseSchema
+		if err := courseRows.Scan(&groupId, &course.CourseId, &course.Course, &course.Title); err != nil {
+			rq.log.Error("failed to scan requisite course row", logger.Err(err))
+			return CourseRequisitesSchema{}, util.ErrInternal
+		}
+		groupCourses[groupId] = append(groupCourses[groupId], course)
+	}
+	if err := courseRows.Err(); err != nil {
+		rq.log.Error("list requisite courses rows error", logger.Err(err))
+		return CourseRequisitesSchema{}, util.ErrInternal
+	}
+
+	return buildCourseRequisites(groups, groupCourses), nil
+}
+
+// buildCourseRequisites assembles requisite trees from their groups and each group's courses.
+func buildCourseRequisites(groups []requisiteGroupRow, groupCourses map[string][]RequisiteCourseSchema) CourseRequisitesSchema {
+	children := map[string][]RequisiteGroupSchema{}
+	var build func(group RequisiteGroupSchema) RequisiteGroupSchema
+	build = func(group RequisiteGroupSchema) RequisiteGroupSchema {
+		group.Courses = groupCourses[group.Id]
+		for _, child := range children[group.Id] {
+			group.Groups = append(group.Groups, build(child))
+		}
+		return group
+	}
+
+	roots := []RequisiteGroupSchema{}
+	for _, row := range groups {
+		if row.parentId.Valid {
+			children[row.parentId.String] = append(children[row.parentId.String], row.group)
+		} else {
+			roots = append(roots, row.group)
+		}
+	}
+
+	requisites := CourseRequisitesSchema{}
+	for _, root := range roots {
+		tree := build(root)
+		switch tree.Type {
+		case RequisitePrerequisite:
+			requisites.Prerequisites = &tree
+		case RequisiteCorequisite:
+			requisites.Corequisites = &tree
+		case RequisiteAntirequisite:
+			requisites.Antirequisites = &tree
+		}
+	}
+
+	return requisites
+}
+
+func (rq *pgRequisiteRepository) ListUnlockedCourses(ctx context.Context, courseId string, paginate util.Paginate) ([]UnlockedCourseSchema, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"select distinct c.id, c.course, c.title, g.type from course_requisites r",
+		"inner join course_requisite_groups g on g.id = r.group_id",
+		"inner join courses c on c.id = g.course_id",
+	)
+	qb.Concat("where r.course_id = $%d and g.type <> $%d", courseUuid, RequisiteAntirequisite)
+	qb.Concat("order by c.course, g.type")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := rq.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list unlocked courses query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	courses := []UnlockedCourseSchema{}
+	for rows.Next() {
+		var course UnlockedCourseSchema
+		if err := rows.Scan(&course.CourseId, &course.Course, &course.Title, &course.Type); err != nil {
+			rq.log.Error("failed to scan unlocked course row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		courses = append(courses, course)
+	}
+
+	if err := rows.Err(); err != nil {
+		rq.log.Error("list unlocked courses rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return courses, nil
+}
+
+func (rq *pgRequisiteRepository) SetCourseRequisites(ctx context.Context, courseId string, requisiteType string, group *InsertRequisiteGroup) error {
+	if requisiteType != RequisitePrerequisite && requisiteType != RequisiteCorequisite && requisiteType != RequisiteAntirequisite {
+		return util.ErrMalformed
+	}
+
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := rq.db.Pool.Begin(ctx)
+	if err != nil {
+		rq.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	// Nested groups and their courses are removed with the root
+	qb := util.NewSqlBuilder()
+	qb.Concat("delete from course_requisite_groups where course_id = $%d and type = $%d and parent_id is null", courseUuid, requisiteType)
+	deleteResult := qb.Result()
+
+	if _, err := tx.Exec(ctx, deleteResult.Query, deleteResult.Args...); err != nil {
+		rq.log.Error("un-handled delete requisite groups query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if group != nil {
+		if err := rq.insertRequisiteGroup(ctx, tx, courseUuid, requisiteType, "", *group); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		rq.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	rq.log.Info(fmt.Sprintf("course %s %s requisites set", courseId, requisiteType))
+	return nil
+}
+
+// insertRequisiteGroup inserts a group with its courses and nested groups, parentId is empty for the root group.
+func (rq *pgRequisiteRepository) insertRequisiteGroup(ctx context.Context, tx pgx.Tx, courseId pgtype.UUID, requisiteType string, parentId string, group InsertRequisiteGroup) error {
+	operator := group.Operator
+	if operator == "" {
+		operator = RequisiteAll
+	}
+
+	qb := util.NewSqlBuilder("insert into course_requisite_groups (course_id, parent_id, type, operator)")
+	qb.Concat("values ($%d, nullif($%d, '')::uuid, $%d, $%d)", courseId, parentId, requisiteType, operator)
+	qb.Concat("returning id")
+	groupResult := qb.Result()
+
+	var groupId string
+	if err := tx.QueryRow(ctx, groupResult.Query, groupResult.Args...).Scan(&groupId); err != nil {
+		return rq.requisiteErr(err)
+	}
+
+	for _, requiredId := range group.CourseIds {
+		requiredUuid, err := database.ParsePgUuid(requiredId)
+		if err != nil {
+			return err
+		}
+
+		qb = util.NewSqlBuilder("insert into course_requisites (group_id, course_id)")
+		qb.Concat("values ($%d, $%d)", groupId, requiredUuid)
+		qb.Concat("on conflict do nothing")
+		courseResult := qb.Result()
+
+		if _, err := tx.Exec(ctx, courseResult.Query, courseResult.Args...); err != nil {
+			return rq.requisiteErr(err)
+		}
+	}
+
+	for _, child := range group.Groups {
+		if err := rq.insertRequisiteGroup(ctx, tx, courseId, requisiteType, groupId, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// requisiteErr maps an error inserting requisites.
+func (rq *pgRequisiteRepository) requisiteErr(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == database.PgFKeyViolationErrCode {
+			return util.ErrNotFound
+		} else if pgErr.Code == database.PgInvalidTextRepErrCode {
+			return util.ErrMalformed
+		}
+	}
+
+	rq.log.Error("un-handled insert requisite query error", logger.Err(err))
+	return util.ErrInternal
+}
+
+func (rq *pgRequisiteRepository) ListTakenCourses(ctx context.Context, userId string) (TakenCoursesSchema, error) {
+	qb := util.NewSqlBuilder(
+		"select uc.course_id, t.id is null, "+userCourseCompletedColumn+" from user_courses uc",
+		"left join terms t on t.id = uc.term_id",
+	)
+	qb.Concat("where uc.user_id = $%d", userId)
+	result := qb.Result()
+
+	rows, err := rq.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		rq.log.Error("un-handled list taken courses query error", logger.Err(err))
+		return TakenCoursesSchema{}, util.ErrInternal
+	}
+	defer rows.Close()
+
+	taken := TakenCoursesSchema{
+		Taken:     map[string]bool{},
+		Completed: map[string]bool{},
+		Unknown:   map[string]bool{},
+	}
+	for rows.Next() {
+		var courseId string
+		var unknown, completed bool
+		if err := rows.Scan(&courseId, &unknown, &completed); err != nil {
+			rq.log.Error("failed to scan taken course row", logger.Err(err))
+			return TakenCoursesSchema{}, util.ErrInternal
+		}
+		taken.Taken[courseId] = true
+		if unknown {
+			taken.Unknown[courseId] = true
+		} else if completed {
+			taken.Completed[courseId] = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		rq.log.Error("list taken courses rows error", logger.Err(err))
+		return TakenCoursesSchema{}, util.ErrInternal
+	}
+
+	return taken, nil
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+)
+
+func requisiteGroup(operator string, courseIds []string, groups ...RequisiteGroupSchema) RequisiteGroupSchema {
+	group := RequisiteGroupSchema{Operator: operator, Groups: groups}
+	for _, courseId := range courseIds {
+		group.Courses = append(group.Courses, RequisiteCourseSchema{CourseId: courseId})
+	}
+
+	return group
+}
+
+func TestRequisiteGroupSatisfied(t *testing.T) {
+	tests := []struct {
+		name  string
+		group RequisiteGroupSchema
+		taken []string
+		want  bool
+	}{
+		{
+			name:  "empty root",
+			group: requisiteGroup(RequisiteAll, nil),
+			want:  true,
+		},
+		{
+			name:  "all taken",
+			group: requisiteGroup(RequisiteAll, []string{"a", "b"}),
+			taken: []string{"a", "b"},
+			want:  true,
+		},
+		{
+			name:  "all missing one",
+			group: requisiteGroup(RequisiteAll, []string{"a", "b"}),
+			taken: []string{"a"},
+			want:  false,
+		},
+		{
+			name:  "any taken",
+			group: requisiteGroup(RequisiteAny, []string{"a", "b"}),
+			taken: []string{"b"},
+			want:  true,
+		},
+		{
+			name:  "any none taken",
+			group: requisiteGroup(RequisiteAny, []string{"a", "b"}),
+			taken: []string{"c"},
+			want:  false,
+		},
+		{
+			name:  "nested any within all",
+			group: requisiteGroup(RequisiteAll, []string{"a"}, requisiteGroup(RequisiteAny, []string{"b", "c"})),
+			taken: []string{"a", "c"},
+			want:  true,
+		},
+		{
+			name:  "nested any within all unsatisfied",
+			group: requisiteGroup(RequisiteAll, []string{"a"}, requisiteGroup(RequisiteAny, []string{"b", "c"})),
+			taken: []string{"a"},
+			want:  false,
+		},
+		{
+			name:  "nested all within any",
+			group: requisiteGroup(RequisiteAny, []string{"a"}, requisiteGroup(RequisiteAll, []string{"b", "c"})),
+			taken: []string{"b", "c"},
+			want:  true,
+		},
+		{
+			name:  "empty nested group within any",
+			group: requisiteGroup(RequisiteAny, []string{"a"}, requisiteGroup(RequisiteAll, nil)),
+			want:  false,
+		},
+		{
+			name:  "only empty nested groups within any",
+			group: requisiteGroup(RequisiteAny, nil, requisiteGroup(RequisiteAll, nil), requisiteGroup(RequisiteAny, nil)),
+			want:  false,
+		},
+		{
+			name:  "empty nested group within all",
+			group: requisiteGroup(RequisiteAll, []string{"a"}, requisiteGroup(RequisiteAny, nil)),
+			taken: []string{"a"},
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taken := map[string]bool{}
+			for _, courseId := range test.taken {
+				taken[courseId] = true
+			}
+
+			if got := test.group.Satisfied(taken); got != test.want {
+				t.Errorf("Satisfied() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func takenCourses(completed []string, inProgress []string, unknown []string) TakenCoursesSchema {
+	taken := TakenCoursesSchema{Taken: map[string]bool{}, Completed: map[string]bool{}, Unknown: map[string]bool{}}
+	for _, courseId := range completed {
+		taken.Taken[courseId] = true
+		taken.Completed[courseId] = true
+	}
+	for _, courseId := range inProgress {
+		taken.Taken[courseId] = true
+	}
+	for _, courseId := range unknown {
+		taken.Taken[courseId] = true
+		taken.Unknown[courseId] = true
+	}
+
+	return taken
+}
+
+func TestEvaluateEligibility(t *testing.T) {
+	prerequisites := requisiteGroup(RequisiteAll, []string{"a"})
+	corequisites := requisiteGroup(RequisiteAll, []string{"b"})
+	antirequisites := requisiteGroup(RequisiteAny, []string{"c"})
+	requisites := CourseRequisitesSchema{Prerequisites: &prerequisites, Corequisites: &corequisites, Antirequisites: &antirequisites}
+
+	tests := []struct {
+		name                 string
+		taken                TakenCoursesSchema
+		prerequisitesMet     bool
+		prerequisitesUnknown bool
+		corequisitesMet      bool
+		antirequisites       int
+		eligible             bool
+	}{
+		{
+			name:             "prerequisite completed",
+			taken:            takenCourses([]string{"a"}, nil, nil),
+			prerequisitesMet: true,
+			eligible:         true,
+		},
+		{
+			name:  "prerequisite in progress",
+			taken: takenCourses(nil, []string{"a"}, nil),
+		},
+		{
+			name:                 "prerequisite without a term",
+			taken:                takenCourses(nil, nil, []string{"a"}),
+			prerequisitesUnknown: true,
+		},
+		{
+			name:             "corequisite in progress",
+			taken:            takenCourses([]string{"a"}, []string{"b"}, nil),
+			prerequisitesMet: true,
+			corequisitesMet:  true,
+			eligible:         true,
+		},
+		{
+			name:             "antirequisite completed",
+			taken:            takenCourses([]string{"a", "c"}, nil, nil),
+			prerequisitesMet: true,
+			antirequisites:   1,
+		},
+		{
+			name:             "antirequisite in progress",
+			taken:            takenCourses([]string{"a"}, []string{"c"}, nil),
+			prerequisitesMet: true,
+			antirequisites:   1,
+		},
+		{
+			name:             "antirequisite without a term",
+			taken:            takenCourses([]string{"a"}, nil, []string{"c"}),
+			prerequisitesMet: true,
+			antirequisites:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eligibility := EvaluateEligibility(requisites, test.taken)
+			if eligibility.PrerequisitesMet != test.prerequisitesMet {
+				t.Errorf("PrerequisitesMet = %t, want %t", eligibility.PrerequisitesMet, test.prerequisitesMet)
+			}
+			if eligibility.PrerequisitesUnknown != test.prerequisitesUnknown {
+				t.Errorf("PrerequisitesUnknown = %t, want %t", eligibility.PrerequisitesUnknown, test.prerequisitesUnknown)
+			}
+			if eligibility.CorequisitesMet != test.corequisitesMet {
+				t.Errorf("CorequisitesMet = %t, want %t", eligibility.CorequisitesMet, test.corequisitesMet)
+			}
+			if len(eligibility.Antirequisites) != test.antirequisites {
+				t.Errorf("Antirequisites = %d courses, want %d", len(eligibility.Antirequisites), test.antirequisites)
+			}
+			if eligibility.Eligible() != test.eligible {
+				t.Errorf("Eligible() = %t, want %t", eligibility.Eligible(), test.eligible)
+			}
+		})
+	}
+}
+
+func TestListTakenCourses(t *testing.T) {
+	db := testPostgresDb(t)
+	userRepo := NewPgUserRepository(db, logger.NewTextLogger())
+	requisiteRepo := NewPgRequisiteRepository(db, logger.NewTextLogger())
+	userId := createTestUser(t, db)
+
+	var pastTermId string
+	err := db.Pool.QueryRow(context.Background(),
+		"select id from terms where institution = 'Toronto Metropolitan University' and year = 2020 and semester = 'Fall'").Scan(&pastTermId)
+	if err != nil {
+		t.Fatalf("failed to read the Fall 2020 term: %v", err)
+	}
+	currentTermId := CurrentTerm
+
+	completedId := createTestCourse(t, db)
+	inProgressId := createTestCourse(t, db)
+	unknownId := createTestCourse(t, db)
+	for _, course := range []InsertUserCourse{
+		{CourseId: completedId, TermId: &pastTermId},
+		{CourseId: inProgressId, TermId: &currentTermId},
+		{CourseId: unknownId},
+	} {
+		if err := userRepo.AddUserCourse(context.Background(), userId, course); err != nil {
+			t.Fatalf("AddUserCourse() error = %v", err)
+		}
+	}
+
+	taken, err := requisiteRepo.ListTakenCourses(context.Background(), userId)
+	if err != nil {
+		t.Fatalf("ListTakenCourses() error = %v", err)
+	}
+
+	want := takenCourses([]string{completedId}, []string{inProgressId}, []string{unknownId})
+	if !reflect.DeepEqual(taken, want) {
+		t.Errorf("ListTakenCourses() = %+v, want %+v", taken, want)
+	}
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type CourseReviewSchema struct {
+	Id           string
+	UserId       string
+	CourseId     string
+	Difficulty   int16
+	Workload     int16
+	Usefulness   int16
+	Year         int16 // Year the course was taken
+	Semester     string
+	Content      sql.NullString
+	DateAdded    time.Time
+	DateModified time.Time
+}
+
+// InsertCourseReview holds the ratings from 1 to 5 and term of a review.
+type InsertCourseReview struct {
+	Difficulty int16
+	Workload   int16
+	Usefulness int16
+	Year       int16
+	Semester   string
+	Content    *string
+}
+
+type ReviewRepository interface {
+	// ListCourseReviews lists the reviews of a course, most recent first.
+	ListCourseReviews(ctx context.Context, courseId string, paginate util.Paginate) ([]CourseReviewSchema, error)
+	// CreateCourseReview reviews a course, returning [util.ErrForbidden] if the user has not recorded taking the course
+	// and [util.ErrConflict] if they have already reviewed it.
+	CreateCourseReview(ctx context.Context, userId string, courseId string, review InsertCourseReview) (CourseReviewSchema, error)
+	// UpdateCourseReview replaces the ratings and text of the user's review.
+	UpdateCourseReview(ctx context.Context, userId string, courseId string, reviewId string, review InsertCourseReview) (CourseReviewSchema, error)
+	DeleteCourseReview(ctx context.Context, userId string, courseId string, reviewId string) error
+}
+
+type pgReviewRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgReviewRepository(db *database.PostgresDb, log logger.Logger) *pgReviewRepository {
+	return &pgReviewRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const courseReviewColumns = "id, user_id, course_id, difficulty, workload, usefulness, year, semester, content, date_added, date_modified"
+
+func scanCourseReview(row pgx.Row, review *CourseReviewSchema) error {
+	return row.Scan(
+		&review.Id,
+		&review.UserId,
+		&review.CourseId,
+		&review.Difficulty,
+		&review.Workload,
+		&review.Usefulness,
+		&review.Year,
+		&review.Semester,
+		&review.Content,
+		&review.DateAdded,
+		&review.DateModified,
+	)
+}
+
+func (r *pgReviewRepository) ListCourseReviews(ctx context.Context, courseId string, paginate util.Paginate) ([]CourseReviewSchema, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return nil, err
+	}
+
+	qb := util.NewSqlBuilder("select " + courseReviewColumns + " from course_reviews")
+	qb.Concat("where course_id = $%d", courseUuid)
+	qb.Concat("order by date_added desc, id")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := r.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		r.log.Error("un-handled list course reviews query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	reviews := []CourseReviewSchema{}
+	for rows.Next() {
+		var review CourseReviewSchema
+		if err := scanCourseReview(rows, &review); err != nil {
+			r.log.Error("failed to scan course review row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error("list course reviews rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return reviews, nil
+}
+
+func (r *pgReviewRepository) CreateCourseReview(ctx context.Context, userId string, courseId string, review InsertCourseReview) (CourseReviewSchema, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return CourseReviewSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into course_reviews (user_id, course_id, difficulty, workload, usefulness, year, semester, content)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+		userId, courseUuid, review.Difficulty, review.Workload, review.Usefulness, review.Year, review.Semester, review.Content)
+	qb.Concat("returning " + courseReviewColumns)
+	result := qb.Result()
+
+	created := CourseReviewSchema{}
+	err = scanCourseReview(r.db.Pool.QueryRow(ctx, result.Query, result.Args...), &created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgFKeyViolationErrCode {
+				r.log.Info(fmt.Sprintf("user %s attempted to review course %s without taking it", userId, courseId))
+				return CourseReviewSchema{}, util.ErrForbidden
+			} else if pgErr.Code == database.PgConflictErrCode {
+				return CourseReviewSchema{}, util.ErrConflict
+			} else if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+				return CourseReviewSchema{}, util.ErrMalformed
+			}
+		}
+
+		r.log.Error("un-handled create course review query error", logger.Err(err))
+		return CourseReviewSchema{}, util.ErrInternal
+	}
+
+	r.log.Info(fmt.Sprintf("user %s reviewed course %s", userId, courseId))
+	return created, nil
+}
+
+func (r *pgReviewRepository) UpdateCourseReview(ctx context.Context, userId string, courseId string, reviewId string, review InsertCourseReview) (CourseReviewSchema, error) {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return CourseReviewSchema{}, err
+	}
+	reviewUuid, err := database.ParsePgUuid(reviewId)
+	if err != nil {
+		return CourseReviewSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("update course_reviews set difficulty = $%d, workload = $%d, usefulness = $%d,", review.Difficulty, review.Workload, review.Usefulness)
+	qb.Concat("year = $%d, semester = $%d, content = $%d", review.Year, review.Semester, review.Content)
+	qb.Concat("where id = $%d and course_id = $%d and user_id = $%d", reviewUuid, courseUuid, userId)
+	qb.Concat("returning " + courseReviewColumns)
+	result := qb.Result()
+
+	updated := CourseReviewSchema{}
+	err = scanCourseReview(r.db.Pool.QueryRow(ctx, result.Query, result.Args...), &updated)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CourseReviewSchema{}, util.ErrNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode) {
+			return CourseReviewSchema{}, util.ErrMalformed
+		}
+
+		r.log.Error("un-handled update course review query error", logger.Err(err))
+		return CourseReviewSchema{}, util.ErrInternal
+	}
+
+	return updated, nil
+}
+
+func (r *pgReviewRepository) DeleteCourseReview(ctx context.Context, userId string, courseId string, reviewId string) error {
+	courseUuid, err := database.ParsePgUuid(courseId)
+	if err != nil {
+		return err
+	}
+	reviewUuid, err := database.ParsePgUuid(reviewId)
+	if err != nil {
+		return err
+	}
+
+	qb := util.NewSqlBuilder("delete from course_reviews")
+	qb.Concat("where id = $%d and course_id = $%d and user_id = $%d", reviewUuid, courseUuid, userId)
+	result := qb.Result()
+
+	tag, err := r.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		r.log.Error("un-handled delete course review query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	r.log.Info(fmt.Sprintf("user %s deleted review %s", userId, reviewId))
+	return nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Search matches within snippets are wrapped in these control characters, which are never present in extracted text,
+// so snippets can be HTML escaped before the matches are marked up.
+const (
+	SnippetStartSel = "\u0002"
+	SnippetStopSel  = "\u0003"
+)
+
+// snippetOptions configures ts_headline.
+const snippetOptions = "MaxFragments=2, MaxWords=25, MinWords=10, FragmentDelimiter=\" ... \", StartSel=\"" + SnippetStartSel + "\", StopSel=\"" + SnippetStopSel + "\""
+
+type SyllabusSearchSchema struct {
+	Syllabus SyllabusSchema
+	// Snippet holds the matching excerpts of the syllabus text, with matches wrapped in [SnippetStartSel] and [SnippetStopSel].
+	Snippet string
+}
+
+type SearchRepository interface {
+	// GetSyllabusSource reads the syllabus whose current file is extracted, regardless of its visibility.
+	GetSyllabusSource(ctx context.Context, syllabusId string) (SyllabusSchema, error)
+	// SaveSyllabusText stores the text extracted from a syllabus file, replacing text of earlier revisions.
+	SaveSyllabusText(ctx context.Context, syllabusId string, revision int16, content string) error
+	// SearchSyllabi finds published syllabi whose text matches a web search style query, best matches first.
+	SearchSyllabi(ctx context.Context, query string, paginate util.Paginate) ([]SyllabusSearchSchema, error)
+}
+
+type pgSearchRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgSearchRepository(db *database.PostgresDb, log logger.Logger) *pgSearchRepository {
+	return &pgSearchRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *pgSearchRepository) GetSyllabusSource(ctx context.Context, syllabusId string) (SyllabusSchema, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return SyllabusSchema{}, err
+	}
+
+	qb := util.NewSqlBuilder("select " + syllabusColumns + " from syllabi")
+	qb.Concat("where id = $%d", syllabusUuid)
+	result := qb.Result()
+
+	syllabus := SyllabusSchema{}
+	err = scanSyllabus(s.db.Pool.QueryRow(ctx, result.Query, result.Args...), &syllabus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled get syllabus source query error", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
+	return syllabus, nil
+}
+
+func (s *pgSearchRepository) SaveSyllabusText(ctx context.Context, syllabusId string, revision int16, content string) error {
+	result, err := s.saveSyllabusTextQuery(syllabusId, revision, content)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			return util.ErrNotFound
+		}
+
+		s.log.Error("un-handled save syllabus text query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus %s revision %d text saved", syllabusId, revision))
+	return nil
+}
+
+func (s *pgSearchRepository) saveSyllabusTextQuery(syllabusId string, revision int16, content string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	// Extractions can finish out of order, never replace the text of a newer revision
+	qb := util.NewSqlBuilder("insert into syllabus_texts (syllabus_id, revision, content)")
+	qb.Concat("values ($%d, $%d, $%d)", syllabusUuid, revision, content)
+	qb.Concat("on conflict (syllabus_id) do update")
+	qb.Concat("set revision = excluded.revision, content = excluded.content, date_added = now()")
+	qb.Concat("where syllabus_texts.revision <= excluded.revision")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSearchRepository) SearchSyllabi(ctx context.Context, query string, paginate util.Paginate) ([]SyllabusSearchSchema, error) {
+	result := s.searchSyllabiQuery(query, paginate)
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled search syllabi query error", logger.Err(err))
+		return []SyllabusSearchSchema{}, util.ErrInternal
+	}
+	defer rows.Close()
+
+	results := []SyllabusSearchSchema{}
+	for rows.Next() {
+		match := SyllabusSearchSchema{}
+		err := scanSyllabus(snippetRow{row: rows, snippet: &match.Snippet}, &match.Syllabus)
+		if err != nil {
+			s.log.Error("scan syllabus search error", logger.Err(err))
+			return []SyllabusSearchSchema{}, util.ErrInternal
+		}
+
+		results = append(results, match)
+	}
+
+	return results, nil
+}
+
+// snippetRow scans a trailing snippet column after the columns read by scanSyllabus.
+type snippetRow struct {
+	row     pgx.Row
+	snippet *string
+}
+
+func (r snippetRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.snippet)...)
+}
+
+func (s *pgSearchRepository) searchSyllabiQuery(query string, paginate util.Paginate) util.SqlBuilderResult {
+	// Snippets are only generated for the page of results since ts_headline re-parses the whole text
+	qb := util.NewSqlBuilder("select " + syllabusColumns + ", ts_headline('english', content, q, '" + snippetOptions + "')")
+	qb.Concat("from (")
+	qb.Concat("select s.*, t.content, q, ts_rank(t.document, q) as rank")
+	qb.Concat("from syllabi s")
+	qb.Concat("inner join syllabus_texts t on t.syllabus_id = s.id and t.revision = s.revision,")
+	qb.Concat("websearch_to_tsquery('english', $%d) q", query)
+	qb.Concat("where t.document @@ q and s.status = $%d", SyllabusPublished)
+	qb.Concat("order by rank desc, s.date_added desc")
+	qb.Concat("limit $%d", paginate.Size)
+	offset := (paginate.Page - 1) * paginate.Size
+	qb.Concat("offset $%d", offset)
+	qb.Concat(") r")
+	qb.Concat("order by rank desc, date_added desc")
+
+	return qb.Result()
+}
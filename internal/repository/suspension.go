@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// User role values, matching the user_role database enum.
+const (
+	RoleUser  = "User"
+	RoleAdmin = "Admin"
+)
+
+// activeSuspensionCond matches suspensions which have not been lifted and have not expired.
+const activeSuspensionCond = "date_lifted is null and (date_expires is null or date_expires > now())"
+
+type UserSuspensionSchema struct {
+	Id          string
+	UserId      string
+	SuspendedBy sql.NullString
+	Reason      string
+	DateExpires sql.NullTime // Null for permanent suspensions
+	LiftedBy    sql.NullString
+	DateLifted  sql.NullTime
+	DateAdded   time.Time
+}
+
+type InsertUserSuspension struct {
+	Reason        string
+	DurationHours *int // Nil for permanent suspensions
+}
+
+// UserAccessSchema describes what a user is currently allowed to do.
+type UserAccessSchema struct {
+	UserId   string
+	Role     string
+	IsActive bool
+	// Suspension is the user's active suspension, nil when the user is not suspended.
+	Suspension *UserSuspensionSchema
+}
+
+// IsSuspended reports whether the user is deactivated or currently suspended.
+func (a UserAccessSchema) IsSuspended() bool {
+	return !a.IsActive || a.Suspension != nil
+}
+
+type SuspensionRepository interface {
+	GetUserAccess(ctx context.Context, userId string) (UserAccessSchema, error)
+	// SuspendUser suspends a user, returning [util.ErrConflict] if the user is already suspended.
+	SuspendUser(ctx context.Context, userId string, suspendedBy string, entity InsertUserSuspension) (string, error)
+	// LiftUserSuspension lifts a user's active suspension, returning [util.ErrNotFound] if the user is not suspended.
+	LiftUserSuspension(ctx context.Context, userId string, liftedBy string) error
+	ListUserSuspensions(ctx context.Context, userId string) ([]UserSuspensionSchema, error)
+}
+
+type pgSuspensionRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgSuspensionRepository(db *database.PostgresDb, log logger.Logger) *pgSuspensionRepository {
+	return &pgSuspensionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *pgSuspensionRepository) GetUserAccess(ctx context.Context, userId string) (UserAccessSchema, error) {
+	result, err := s.getUserAccessQuery(userId)
+	if err != nil {
+		return UserAccessSchema{}, err
+	}
+
+	var access UserAccessSchema
+	var suspensionId, suspendedBy, reason sql.NullString
+	var dateExpires, dateAdded sql.NullTime
+	err = s.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(
+		&access.UserId, &access.Role, &access.IsActive,
+		&suspensionId, &suspendedBy, &reason, &dateExpires, &dateAdded,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserAccessSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("get user access query failed", logger.Err(err))
+		return UserAccessSchema{}, util.ErrInternal
+	}
+
+	if suspensionId.Valid {
+		access.Suspension = &UserSuspensionSchema{
+			Id:          suspensionId.String,
+			UserId:      access.UserId,
+			SuspendedBy: suspendedBy,
+			Reason:      reason.String,
+			DateExpires: dateExpires,
+			DateAdded:   dateAdded.Time,
+		}
+	}
+
+	return access, nil
+}
+
+func (s *pgSuspensionRepository) getUserAccessQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	// The longest running suspension takes precedence, permanent suspensions first.
+	qb := util.NewSqlBuilder(
+		"select u.id, u.role, u.is_active, s.id, s.suspended_by, s.reason, s.date_expires, s.date_added",
+		"from users u",
+		"left join lateral (",
+		"select id, suspended_by, reason, date_expires, date_added from user_suspensions",
+		"where user_id = u.id and "+activeSuspensionCond,
+		"order by date_expires desc nulls first limit 1",
+		") s on true",
+	)
+	qb.Concat("where u.id = $%d", userUuid)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSuspensionRepository) SuspendUser(ctx context.Context, userId string, suspendedBy string, entity InsertUserSuspension) (string, error) {
+	lockResult, err := s.lockUserQuery(userId)
+	if err != nil {
+		return "", err
+	}
+	countResult, _ := s.countActiveSuspensionsQuery(userId)
+	insertResult, err := s.suspendUserQuery(userId, suspendedBy, entity)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return "", util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the user row so concurrent requests cannot stack suspensions
+	err = tx.QueryRow(ctx, lockResult.Query, lockResult.Args...).Scan(new(interface{}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", util.ErrNotFound
+		}
+
+		s.log.Error("un-handled lock user query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, countResult.Query, countResult.Args...).Scan(&count); err != nil {
+		s.log.Error("un-handled count active suspensions query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+	if count > 0 {
+		s.log.Info(fmt.Sprintf("user %s is already suspended", userId))
+		return "", util.ErrConflict
+	}
+
+	var suspensionId string
+	err = tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&suspensionId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgCheckErrCode {
+			return "", util.ErrMalformed
+		}
+
+		s.log.Error("un-handled suspend user query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("user %s suspended by %s with suspension %s", userId, suspendedBy, suspensionId))
+	return suspensionId, nil
+}
+
+func (s *pgSuspensionRepository) lockUserQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select id from users")
+	qb.Concat("where id = $%d", userUuid)
+	qb.Concat("for update")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSuspensionRepository) countActiveSuspensionsQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select count(*) from user_suspensions")
+	qb.Concat("where user_id = $%d and "+activeSuspensionCond, userUuid)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSuspensionRepository) suspendUserQuery(userId string, suspendedBy string, entity InsertUserSuspension) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	suspendedByUuid, err := database.ParsePgUuid(suspendedBy)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into user_suspensions (user_id, suspended_by, reason, date_expires)")
+	if entity.DurationHours != nil {
+		qb.Concat("values ($%d, $%d, $%d, now() + make_interval(hours => $%d))", userUuid, suspendedByUuid, entity.Reason, *entity.DurationHours)
+	} else {
+		qb.Concat("values ($%d, $%d, $%d, null)", userUuid, suspendedByUuid, entity.Reason)
+	}
+	qb.Concat("returning id")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSuspensionRepository) LiftUserSuspension(ctx context.Context, userId string, liftedBy string) error {
+	result, err := s.liftUserSuspensionQuery(userId, liftedBy)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled lift user suspension query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	s.log.Info(fmt.Sprintf("user %s suspension lifted by %s", userId, liftedBy))
+	return nil
+}
+
+func (s *pgSuspensionRepository) liftUserSuspensionQuery(userId string, liftedBy string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	liftedByUuid, err := database.ParsePgUuid(liftedBy)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update user_suspensions")
+	qb.Concat("set lifted_by = $%d, date_lifted = now()", liftedByUuid)
+	qb.Concat("where user_id = $%d and "+activeSuspensionCond, userUuid)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSuspensionRepository) ListUserSuspensions(ctx context.Context, userId string) ([]UserSuspensionSchema, error) {
+	result, err := s.listUserSuspensionsQuery(userId)
+	if err != nil {
+		return []UserSuspensionSchema{}, err
+	}
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled list user suspensions query error", logger.Err(err))
+		return []UserSuspensionSchema{}, util.ErrInternal
+	}
+
+	suspensions := []UserSuspensionSchema{}
+	for rows.Next() {
+		suspension := UserSuspensionSchema{}
+		err := rows.Scan(
+			&suspension.Id, &suspension.UserId, &suspension.SuspendedBy, &suspension.Reason, &suspension.DateExpires,
+			&suspension.LiftedBy, &suspension.DateLifted, &suspension.DateAdded,
+		)
+		if err != nil {
+			s.log.Error("scan user suspension error", logger.Err(err))
+			return []UserSuspensionSchema{}, util.ErrInternal
+		}
+		suspensions = append(suspensions, suspension)
+	}
+
+	return suspensions, nil
+}
+
+func (s *pgSuspensionRepository) listUserSuspensionsQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"select id, user_id, suspended_by, reason, date_expires, lifted_by, date_lifted, date_added",
+		"from user_suspensions",
+	)
+	qb.Concat("where user_id = $%d", userUuid)
+	qb.Concat("order by date_added desc")
+
+	return qb.Result(), nil
+}
@@ -16,17 +16,195 @@ import (
 	"github.com/oapi-codegen/nullable"
 )
 
+// Syllabus status values, matching the syllabus_status database enum.
+const (
+	SyllabusUploading = "Uploading"
+	SyllabusVerifying = "Verifying"
+	SyllabusPublished = "Published"
+	SyllabusRejected  = "Rejected"
+	SyllabusExpired   = "Expired"
+	SyllabusRemoved   = "Removed"
+)
+
+// syllabusTransitions lists the statuses a syllabus may move to from its current status.
+var syllabusTransitions = map[string][]string{
+	SyllabusUploading: {SyllabusVerifying, SyllabusExpired},
+	SyllabusVerifying: {SyllabusPublished, SyllabusRejected},
+	SyllabusPublished: {SyllabusRemoved},
+	SyllabusRemoved:   {SyllabusPublished},
+}
+
+// syllabusStatusDates maps a status to the column recording when the syllabus entered it.
+var syllabusStatusDates = map[string]string{
+	SyllabusVerifying: "date_synced",
+	SyllabusPublished: "date_published",
+	SyllabusRejected:  "date_rejected",
+	SyllabusExpired:   "date_expired",
+	SyllabusRemoved:   "date_removed",
+}
+
+// DuplicateSyllabusError is returned when an upload matches the file of another syllabus in the same course and term.
+// It wraps [util.ErrConflict].
+type DuplicateSyllabusError struct {
+	// SyllabusId is the matching syllabus, empty when it could not be determined.
+	SyllabusId string
+}
+
+func (e *DuplicateSyllabusError) Error() string {
+	return fmt.Sprintf("duplicate of syllabus %s", e.SyllabusId)
+}
+
+func (e *DuplicateSyllabusError) Unwrap() error {
+	return util.ErrConflict
+}
+
+// DownloadLimit caps how many files a user may download within a window, to deter scraping.
+type DownloadLimit struct {
+	Count  int
+	Window time.Duration
+}
+
+// SyllabusDownloadLimits are checked from shortest to longest window, bursts are allowed while sustained downloading is not.
+var SyllabusDownloadLimits = []DownloadLimit{
+	{Count: 20, Window: 10 * time.Minute},
+	{Count: 200, Window: 24 * time.Hour},
+}
+
+// DownloadLimitError is returned when a user has reached a download limit. It wraps [util.ErrForbidden].
+type DownloadLimitError struct {
+	// RetryAfter is how long until the user may download again.
+	RetryAfter time.Duration
+}
+
+func (e *DownloadLimitError) Error() string {
+	return fmt.Sprintf("download limit reached, retry after %s", e.RetryAfter)
+}
+
+func (e *DownloadLimitError) Unwrap() error {
+	return util.ErrForbidden
+}
+
+// duplicateStatusList lists the statuses in which a syllabus blocks uploads of the same file, matching the syllabi_checksum_uq index.
+const duplicateStatusList = "('Uploading', 'Verifying', 'Published')"
+
+// CanTransitionSyllabus reports whether a syllabus may move between the given statuses.
+func CanTransitionSyllabus(from string, to string) bool {
+	for _, status := range syllabusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
 type SyllabusSchema struct {
-	Id          string
-	UserId      string
-	CourseId    string
-	File        string
-	FileSize    int
-	ContentType string
-	Year        int16
-	Semester    string
-	DateAdded   time.Time
-	DateSynced  sql.NullTime
+	Id            string
+	UserId        string
+	CourseId      string
+	File          string
+	FileSize      int
+	ContentType   string
+	Year          int16
+	Semester      string
+	TermId        string // Year and semester follow the term
+	InstructorId  sql.NullString
+	Revision      int16
+	Status        string
+	StatusReason  sql.NullString // Reason for a rejection or removal
+	DateAdded     time.Time
+	DateSynced    sql.NullTime
+	DatePublished sql.NullTime
+	DateRejected  sql.NullTime
+	DateExpired   sql.NullTime
+	DateRemoved   sql.NullTime
+	// Counts are maintained by triggers on syllabus_likes and syllabus_views
+	LikeCount    int
+	DislikeCount int
+	ViewCount    int
+	// IsOfficial is set for syllabi uploaded by their verified instructor
+	IsOfficial bool
+	// ThumbnailRevision is the revision whose thumbnail was rendered, see [SyllabusSchema.HasThumbnail]
+	ThumbnailRevision sql.NullInt16
+}
+
+// DateStatusChanged returns when the syllabus entered its current status.
+func (s SyllabusSchema) DateStatusChanged() time.Time {
+	var date sql.NullTime
+	switch s.Status {
+	case SyllabusVerifying:
+		date = s.DateSynced
+	case SyllabusPublished:
+		date = s.DatePublished
+	case SyllabusRejected:
+		date = s.DateRejected
+	case SyllabusExpired:
+		date = s.DateExpired
+	case SyllabusRemoved:
+		date = s.DateRemoved
+	}
+
+	if date.Valid {
+		return date.Time
+	}
+	return s.DateAdded
+}
+
+// HasThumbnail reports whether the thumbnail of the syllabus' current file was rendered.
+func (s SyllabusSchema) HasThumbnail() bool {
+	return s.ThumbnailRevision.Valid && s.ThumbnailRevision.Int16 == s.Revision
+}
+
+// ObjectKey returns the bucket key of the syllabus' current file.
+func (s SyllabusSchema) ObjectKey() string {
+	return SyllabusObjectKey(s.Id, s.Revision)
+}
+
+// SyllabusObjectKey returns the bucket key of a syllabus revision's file.
+// The first revision is stored under the syllabus id, which predates revisions.
+func SyllabusObjectKey(syllabusId string, revision int16) string {
+	if revision <= 1 {
+		return syllabusId
+	}
+
+	return fmt.Sprintf("%s/revisions/%d", syllabusId, revision)
+}
+
+const syllabusColumns = "id, user_id, course_id, file, file_size, content_type, year, semester, term_id, instructor_id, revision, status, status_reason, " +
+	"date_added, date_synced, date_published, date_rejected, date_expired, date_removed, like_count, dislike_count, view_count, " +
+	syllabusOfficialColumn + ", thumbnail_revision"
+
+// syllabusOfficialColumn selects whether a syllabus was uploaded by its verified instructor.
+const syllabusOfficialColumn = "coalesce((select i.verified_user_id from instructors i where i.id = instructor_id) = user_id, false)"
+
+// scanSyllabus scans a row selected with syllabusColumns.
+func scanSyllabus(row pgx.Row, syllabus *SyllabusSchema) error {
+	return row.Scan(
+		&syllabus.Id,
+		&syllabus.UserId,
+		&syllabus.CourseId,
+		&syllabus.File,
+		&syllabus.FileSize,
+		&syllabus.ContentType,
+		&syllabus.Year,
+		&syllabus.Semester,
+		&syllabus.TermId,
+		&syllabus.InstructorId,
+		&syllabus.Revision,
+		&syllabus.Status,
+		&syllabus.StatusReason,
+		&syllabus.DateAdded,
+		&syllabus.DateSynced,
+		&syllabus.DatePublished,
+		&syllabus.DateRejected,
+		&syllabus.DateExpired,
+		&syllabus.DateRemoved,
+		&syllabus.LikeCount,
+		&syllabus.DislikeCount,
+		&syllabus.ViewCount,
+		&syllabus.IsOfficial,
+		&syllabus.ThumbnailRevision,
+	)
 }
 
 type InsertSyllabus struct {
@@ -38,11 +216,17 @@ type InsertSyllabus struct {
 	Checksum    string
 	Year        int16
 	Semester    string
+	// TermId takes precedence over year and semester when given.
+	TermId string
+	// InstructorId defaults to the uploader's verified instructor profile when empty.
+	InstructorId string
 }
 
 type UpdateSyllabus struct {
-	Year     nullable.Nullable[int16]
-	Semester nullable.Nullable[string]
+	Year         nullable.Nullable[int16]
+	Semester     nullable.Nullable[string]
+	TermId       nullable.Nullable[string]
+	InstructorId nullable.Nullable[string]
 }
 
 type SyllabusFilters struct {
@@ -50,6 +234,13 @@ type SyllabusFilters struct {
 	CourseId string
 	Year     *int16
 	Semester string
+	// TermId lists syllabi of a term, [CurrentTerm] resolves to the current term.
+	TermId string
+	Status string
+	// InstructorId lists syllabi of an instructor.
+	InstructorId string
+	// SyncedOnly excludes syllabi that have not been published, including the requesting user's own.
+	SyncedOnly bool
 }
 
 type SyllabusLikeSchema struct {
@@ -59,26 +250,141 @@ type SyllabusLikeSchema struct {
 	DateAdded  time.Time
 }
 
+type SyllabusRevisionSchema struct {
+	SyllabusId    string
+	Revision      int16
+	ObjectKey     string
+	File          string
+	FileSize      int
+	ContentType   string
+	Checksum      sql.NullString // Client provided CRC32, missing for uploads which predate checksums
+	Sha256        sql.NullString // Computed once the upload is received
+	Status        string
+	StatusReason  sql.NullString
+	DateAdded     time.Time
+	DateSynced    sql.NullTime
+	DatePublished sql.NullTime
+	DateRejected  sql.NullTime
+	DateExpired   sql.NullTime
+}
+
+// DateStatusChanged returns when the revision entered its current status.
+func (r SyllabusRevisionSchema) DateStatusChanged() time.Time {
+	var date sql.NullTime
+	switch r.Status {
+	case SyllabusVerifying:
+		date = r.DateSynced
+	case SyllabusPublished:
+		date = r.DatePublished
+	case SyllabusRejected:
+		date = r.DateRejected
+	case SyllabusExpired:
+		date = r.DateExpired
+	}
+
+	if date.Valid {
+		return date.Time
+	}
+	return r.DateAdded
+}
+
+type InsertSyllabusRevision struct {
+	File        string
+	FileSize    int
+	ContentType string
+	Checksum    string
+}
+
+type SyllabusChecksumFilter struct {
+	Checksum string
+	CourseId string
+	Year     *int16
+	Semester string
+}
+
+// SyllabusMeta describes a syllabus or syllabus revision undergoing a status transition.
 type SyllabusMeta struct {
 	Id        string
 	Course    string
+	Revision  int16
+	ObjectKey string
+	FileSize  int
+	Status    string
+	UserId    string
+	UserName  string
+	UserEmail string
+	// BatchPending is set for syllabi whose outcome is notified through their batch summary email.
+	BatchPending bool
+}
+
+// SyllabusBatchItem is the outcome of creating one syllabus of a batch.
+type SyllabusBatchItem struct {
+	SyllabusId string
+	// Err is the reason the syllabus was not created, nil on success.
+	Err error
+}
+
+type SyllabusBatchOutcome struct {
+	SyllabusId   string
+	Course       string
+	Status       string
+	StatusReason sql.NullString
+}
+
+// SyllabusBatchSummary is the outcome of every syllabus in a verified batch.
+type SyllabusBatchSummary struct {
+	Id        string
 	UserId    string
 	UserName  string
 	UserEmail string
+	Syllabi   []SyllabusBatchOutcome
 }
 
 type SyllabusRepository interface {
 	GetAndViewSyllabus(ctx context.Context, userId string, syllabusId string) (SyllabusSchema, error)
+	// GetSyllabus reads a syllabus visible to the user without counting a view.
+	GetSyllabus(ctx context.Context, userId string, syllabusId string) (SyllabusSchema, error)
+	// DownloadSyllabus records a download of a syllabus visible to the user, returning a [DownloadLimitError] if they have reached a limit.
+	DownloadSyllabus(ctx context.Context, userId string, syllabusId string) (SyllabusSchema, error)
+	// CreateSyllabus creates a syllabus, returning a [DuplicateSyllabusError] if the checksum matches another syllabus in the same course and term.
 	CreateSyllabus(ctx context.Context, syllabus InsertSyllabus) (string, error)
+	// CreateSyllabusBatch creates several syllabi in one transaction, returning the outcome of each in order.
+	// Syllabi which fail are skipped, the batch ID is empty when none were created.
+	CreateSyllabusBatch(ctx context.Context, userId string, syllabi []InsertSyllabus) (string, []SyllabusBatchItem, error)
+	// VerifySyllabusBatch expires the batch's syllabi which were not uploaded and summarizes the outcome of each.
+	// Returns [util.ErrConflict] if the batch was already verified.
+	VerifySyllabusBatch(ctx context.Context, batchId string) (SyllabusBatchSummary, error)
+	// FindSyllabusByChecksum finds a syllabus visible to the user with a file matching the checksum.
+	FindSyllabusByChecksum(ctx context.Context, userId string, filter SyllabusChecksumFilter) (string, error)
+	// RecordSyllabusSha256 stores the server computed hash of an uploaded file,
+	// returning a [DuplicateSyllabusError] if it matches another syllabus in the same course and term.
+	RecordSyllabusSha256(ctx context.Context, syllabusId string, revision int16, sha256 string) error
+	// RecordSyllabusThumbnail marks the thumbnail of a syllabus revision as rendered,
+	// returning [util.ErrNotFound] if the revision is not the syllabus' current file.
+	RecordSyllabusThumbnail(ctx context.Context, syllabusId string, revision int16) error
 	ListSyllabi(ctx context.Context, userId string, filters SyllabusFilters, paginate util.Paginate) ([]SyllabusSchema, error)
 	DeleteSyllabus(ctx context.Context, userId string, syllabusId string) error
 	UpdateSyllabus(ctx context.Context, userId string, syllabusId string, syllabus UpdateSyllabus) error
-	// SyncSyllabus updates a syllabus with a valid date_synced value.
-	SyncSyllabus(ctx context.Context, syllabusId string) error
-	VerifySyllabus(ctx context.Context, syllabusId string) (bool, SyllabusMeta, error)
+	// TransitionSyllabus moves a syllabus to a new status, returning [util.ErrConflict] if the transition is not allowed.
+	// The reason is recorded for rejections and removals, and cleared otherwise.
+	TransitionSyllabus(ctx context.Context, syllabusId string, status string, reason string) (SyllabusMeta, error)
+
+	// CreateSyllabusRevision starts a new file upload for a published syllabus owned by the user.
+	// Returns [util.ErrConflict] if the syllabus is not published or already has a revision pending.
+	CreateSyllabusRevision(ctx context.Context, userId string, syllabusId string, entity InsertSyllabusRevision) (SyllabusRevisionSchema, error)
+	ListSyllabusRevisions(ctx context.Context, userId string, syllabusId string) ([]SyllabusRevisionSchema, error)
+	GetSyllabusRevision(ctx context.Context, userId string, syllabusId string, revision int16) (SyllabusRevisionSchema, error)
+	// TransitionSyllabusRevision moves a revision through the upload statuses, a published revision becomes the syllabus' current file.
+	TransitionSyllabusRevision(ctx context.Context, syllabusId string, revision int16, status string, reason string) (SyllabusMeta, error)
 	ListSyllabusLikes(ctx context.Context, syllabusId string) ([]SyllabusLikeSchema, error)
 	LikeSyllabus(ctx context.Context, userId string, syllabusId string, dislike bool) error
 	DeleteSyllabusLike(ctx context.Context, userId string, syllabusId string) error
+	// ListUserReactions maps each of the syllabi the user reacted to, to whether their reaction is a dislike.
+	ListUserReactions(ctx context.Context, userId string, syllabusIds []string) (map[string]bool, error)
+	// CountUserSyllabusLikes counts the likes received across all of a user's synced syllabi.
+	CountUserSyllabusLikes(ctx context.Context, userId string) (int, error)
+	// ListUnindexedSyllabusIds lists published syllabi whose current file has no extracted text, oldest first.
+	ListUnindexedSyllabusIds(ctx context.Context, limit uint) ([]string, error)
 }
 
 type pgSyllabusRepository struct {
@@ -107,19 +413,20 @@ func (s *pgSyllabusRepository) GetAndViewSyllabus(ctx context.Context, userId st
 	}
 	defer tx.Rollback(ctx)
 
+	// The view is recorded first so the returned count includes it, it is rolled back if the syllabus is not visible
+	if _, err := tx.Exec(ctx, viewResult.Query, viewResult.Args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			s.log.Info("syllabus not found")
+			return SyllabusSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled view syllabus error", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
 	syllabus := SyllabusSchema{}
-	err = tx.QueryRow(ctx, getResult.Query, getResult.Args...).Scan(
-		&syllabus.Id,
-		&syllabus.UserId,
-		&syllabus.CourseId,
-		&syllabus.File,
-		&syllabus.FileSize,
-		&syllabus.ContentType,
-		&syllabus.Year,
-		&syllabus.Semester,
-		&syllabus.DateAdded,
-		&syllabus.DateSynced,
-	)
+	err = scanSyllabus(tx.QueryRow(ctx, getResult.Query, getResult.Args...), &syllabus)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			s.log.Info("syllabus not found")
@@ -130,8 +437,85 @@ func (s *pgSyllabusRepository) GetAndViewSyllabus(ctx context.Context, userId st
 		return SyllabusSchema{}, util.ErrInternal
 	}
 
-	if _, err := tx.Exec(ctx, viewResult.Query, viewResult.Args...); err != nil {
-		s.log.Error("un-handled view syllabus error", logger.Err(err))
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
+	return syllabus, nil
+}
+
+func (s *pgSyllabusRepository) GetSyllabus(ctx context.Context, userId string, syllabusId string) (SyllabusSchema, error) {
+	result, err := s.getActiveSyllabusQuery(userId, syllabusId)
+	if err != nil {
+		return SyllabusSchema{}, err
+	}
+
+	syllabus := SyllabusSchema{}
+	err = scanSyllabus(s.db.Pool.QueryRow(ctx, result.Query, result.Args...), &syllabus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled get syllabus error", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
+	return syllabus, nil
+}
+
+func (s *pgSyllabusRepository) DownloadSyllabus(ctx context.Context, userId string, syllabusId string) (SyllabusSchema, error) {
+	getResult, err := s.getActiveSyllabusQuery(userId, syllabusId)
+	if err != nil {
+		return SyllabusSchema{}, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	syllabus := SyllabusSchema{}
+	err = scanSyllabus(tx.QueryRow(ctx, getResult.Query, getResult.Args...), &syllabus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled get syllabus error", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
+	// Serialize the user's downloads so concurrent requests cannot exceed a limit
+	lockResult := s.lockUserDownloadsQuery(userId)
+	if _, err := tx.Exec(ctx, lockResult.Query, lockResult.Args...); err != nil {
+		s.log.Error("un-handled lock user downloads error", logger.Err(err))
+		return SyllabusSchema{}, util.ErrInternal
+	}
+
+	for _, limit := range SyllabusDownloadLimits {
+		limitResult := s.downloadLimitQuery(userId, limit)
+
+		var retryAfterSecs float64
+		err := tx.QueryRow(ctx, limitResult.Query, limitResult.Args...).Scan(&retryAfterSecs)
+		if err == nil {
+			s.log.Info(fmt.Sprintf("user %s reached the limit of %d downloads per %s", userId, limit.Count, limit.Window))
+			return SyllabusSchema{}, &DownloadLimitError{RetryAfter: time.Duration(max(retryAfterSecs, 1) * float64(time.Second))}
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			s.log.Error("un-handled download limit query error", logger.Err(err))
+			return SyllabusSchema{}, util.ErrInternal
+		}
+	}
+
+	qb := util.NewSqlBuilder("insert into syllabus_downloads (syllabus_id, revision, user_id)")
+	qb.Concat("values ($%d, $%d, $%d)", syllabus.Id, syllabus.Revision, userId)
+	insertResult := qb.Result()
+	if _, err := tx.Exec(ctx, insertResult.Query, insertResult.Args...); err != nil {
+		s.log.Error("un-handled record syllabus download error", logger.Err(err))
 		return SyllabusSchema{}, util.ErrInternal
 	}
 
@@ -143,6 +527,26 @@ func (s *pgSyllabusRepository) GetAndViewSyllabus(ctx context.Context, userId st
 	return syllabus, nil
 }
 
+func (s *pgSyllabusRepository) lockUserDownloadsQuery(userId string) util.SqlBuilderResult {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select pg_advisory_xact_lock(hashtext('syllabus_downloads:' || $%d))", userId)
+
+	return qb.Result()
+}
+
+// downloadLimitQuery selects the seconds until the user's oldest download counting toward the limit leaves its window.
+// No row is selected while the user is under the limit.
+func (s *pgSyllabusRepository) downloadLimitQuery(userId string, limit DownloadLimit) util.SqlBuilderResult {
+	qb := util.NewSqlBuilder()
+	qb.Concat("select extract(epoch from date_added + make_interval(secs => $%d) - now())::float8", limit.Window.Seconds())
+	qb.Concat("from syllabus_downloads")
+	qb.Concat("where user_id = $%d and date_added > now() - make_interval(secs => $%d)", userId, limit.Window.Seconds())
+	qb.Concat("order by date_added desc")
+	qb.Concat("offset $%d limit 1", limit.Count-1)
+
+	return qb.Result()
+}
+
 func (s *pgSyllabusRepository) incrementSyllabusView(userId string, syllabusId string) (util.SqlBuilderResult, error) {
 	var syllabusUuid pgtype.UUID
 	if err := syllabusUuid.Scan(syllabusId); err != nil {
@@ -162,15 +566,15 @@ func (s *pgSyllabusRepository) getActiveSyllabusQuery(userId string, syllabusId
 		return util.SqlBuilderResult{}, util.ErrMalformed
 	}
 
-	qb := util.NewSqlBuilder("select id, user_id, course_id, file, file_size, content_type, year, semester, date_added, date_synced from syllabi")
+	qb := util.NewSqlBuilder("select " + syllabusColumns + " from syllabi")
 	qb.Concat("where id = $%d", syllabusId)
-	qb.Concat("and (date_synced is not null or user_id = $%d)", userId)
+	qb.Concat("and (status = $%d or user_id = $%d)", SyllabusPublished, userId)
 
 	return qb.Result(), nil
 }
 
 func (s *pgSyllabusRepository) CreateSyllabus(ctx context.Context, syllabus InsertSyllabus) (string, error) {
-	result := s.createSyllabusQuery(syllabus)
+	result := s.createSyllabusQuery(syllabus, "")
 
 	var syllabusId string
 	err := s.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&syllabusId)
@@ -180,6 +584,16 @@ func (s *pgSyllabusRepository) CreateSyllabus(ctx context.Context, syllabus Inse
 		if errors.As(err, &pgErr) && pgErr.Code == database.PgCheckErrCode {
 			s.log.Info("syllabus failed database check")
 			return "", util.ErrMalformed
+		} else if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return "", util.ErrMalformed
+		} else if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			return "", util.ErrNotFound
+		} else if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			s.log.Info(fmt.Sprintf("duplicate syllabus upload with checksum %s", syllabus.Checksum))
+			duplicateResult := s.findDuplicateSyllabusQuery(syllabus)
+			var duplicateId string
+			s.db.Pool.QueryRow(ctx, duplicateResult.Query, duplicateResult.Args...).Scan(&duplicateId)
+			return "", &DuplicateSyllabusError{SyllabusId: duplicateId}
 		}
 
 		s.log.Error("un-handled create syllabus query error", logger.Err(err))
@@ -190,99 +604,619 @@ func (s *pgSyllabusRepository) CreateSyllabus(ctx context.Context, syllabus Inse
 	return syllabusId, nil
 }
 
-func (s *pgSyllabusRepository) createSyllabusQuery(sy InsertSyllabus) util.SqlBuilderResult {
-	qb := util.NewSqlBuilder("insert into syllabi (user_id, course_id, file, file_size, content_type, year, semester)")
-	qb.Concat("values ($%d, $%d, $%d, $%d, $%d, $%d, $%d)", sy.UserId, sy.CourseId, sy.File, sy.FileSize, sy.ContentType, sy.Year, sy.Semester)
-	qb.Concat("returning id")
+// createSyllabusQuery inserts a syllabus with its first revision, batchId is empty for syllabi created on their own.
+func (s *pgSyllabusRepository) createSyllabusQuery(sy InsertSyllabus, batchId string) util.SqlBuilderResult {
+	qb := util.NewSqlBuilder("with s as (insert into syllabi (user_id, course_id, file, file_size, content_type, checksum, year, semester, term_id, instructor_id, batch_id)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, $%d, $%d,", sy.UserId, sy.CourseId, sy.File, sy.FileSize, sy.ContentType, sy.Checksum)
+	// The syllabus_term trigger fills the year and semester from the term, or the term from the year and semester
+	qb.Concat("nullif($%d, 0), nullif($%d, '')::semester_type, nullif($%d, '')::uuid,", sy.Year, sy.Semester, sy.TermId)
+	// Verified instructors' uploads default to their own instructor profile
+	qb.Concat("coalesce(nullif($%d, '')::uuid, (select id from instructors where verified_user_id = $%d::uuid)),", sy.InstructorId, sy.UserId)
+	qb.Concat("nullif($%d, '')::uuid)", batchId)
+	qb.Concat("returning id, file, file_size, content_type, checksum)")
+	qb.Concat("insert into syllabus_revisions (syllabus_id, revision, object_key, file, file_size, content_type, checksum)")
+	qb.Concat("select id, 1, id::text, file, file_size, content_type, checksum from s")
+	qb.Concat("returning syllabus_id")
 
 	return qb.Result()
 }
 
-func (s *pgSyllabusRepository) ListSyllabi(ctx context.Context, userId string, filters SyllabusFilters, paginate util.Paginate) ([]SyllabusSchema, error) {
-	result, err := s.listSyllabiQuery(userId, filters, paginate)
+// findDuplicateSyllabusQuery finds the syllabus blocking an insert through the syllabi_checksum_uq index.
+func (s *pgSyllabusRepository) findDuplicateSyllabusQuery(sy InsertSyllabus) util.SqlBuilderResult {
+	qb := util.NewSqlBuilder("select id from syllabi")
+	qb.Concat("where course_id = $%d and checksum = $%d", sy.CourseId, sy.Checksum)
+	if sy.TermId != "" {
+		qb.Concat("and term_id = $%d", sy.TermId)
+	} else {
+		qb.Concat("and year = $%d and semester = $%d", sy.Year, sy.Semester)
+	}
+	qb.Concat("and status in " + duplicateStatusList)
+	qb.Concat("limit 1")
+
+	return qb.Result()
+}
+
+func (s *pgSyllabusRepository) CreateSyllabusBatch(ctx context.Context, userId string, syllabi []InsertSyllabus) (string, []SyllabusBatchItem, error) {
+	batchResult, err := s.createSyllabusBatchQuery(userId)
 	if err != nil {
-		return []SyllabusSchema{}, err
+		return "", []SyllabusBatchItem{}, err
 	}
 
-	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	tx, err := s.db.Pool.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return []SyllabusSchema{}, nil
-		}
-		s.log.Error("un-handled list syllabi query error", logger.Err(err))
-		return []SyllabusSchema{}, util.ErrInternal
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return "", []SyllabusBatchItem{}, util.ErrInternal
 	}
+	defer tx.Rollback(ctx)
 
-	var syllabi []SyllabusSchema
-	for rows.Next() {
-		syllabus := SyllabusSchema{}
-		err := rows.Scan(
-			&syllabus.Id,
-			&syllabus.UserId,
-			&syllabus.CourseId,
-			&syllabus.File,
-			&syllabus.FileSize,
-			&syllabus.ContentType,
-			&syllabus.Year,
-			&syllabus.Semester,
-			&syllabus.DateAdded,
-			&syllabus.DateSynced,
-		)
+	var batchId string
+	if err := tx.QueryRow(ctx, batchResult.Query, batchResult.Args...).Scan(&batchId); err != nil {
+		s.log.Error("un-handled create syllabus batch query error", logger.Err(err))
+		return "", []SyllabusBatchItem{}, util.ErrInternal
+	}
+
+	created := 0
+	items := make([]SyllabusBatchItem, 0, len(syllabi))
+	for _, syllabus := range syllabi {
+		item, err := s.createBatchedSyllabus(ctx, tx, syllabus, batchId)
 		if err != nil {
-			s.log.Error("scan syllabus error", logger.Err(err))
-			return []SyllabusSchema{}, util.ErrInternal
+			return "", []SyllabusBatchItem{}, err
 		}
+		if item.Err == nil {
+			created++
+		}
+		items = append(items, item)
+	}
 
-		syllabi = append(syllabi, syllabus)
+	if created == 0 {
+		return "", items, nil
 	}
 
-	return syllabi, nil
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return "", []SyllabusBatchItem{}, util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus batch %s created with %d of %d syllabi", batchId, created, len(syllabi)))
+	return batchId, items, nil
 }
 
-func (s *pgSyllabusRepository) listSyllabiQuery(userId string, filters SyllabusFilters, paginate util.Paginate) (util.SqlBuilderResult, error) {
-	qb := util.NewSqlBuilder("select id, user_id, course_id, file, file_size, content_type, year, semester, date_added, date_synced from syllabi")
-	qb.Concat("where (date_synced is not null or user_id = $%d)", userId)
+// createBatchedSyllabus creates a syllabus within a savepoint so a failed syllabus does not abort the batch.
+// Only unexpected errors are returned, the syllabus' own failure is reported through the item.
+func (s *pgSyllabusRepository) createBatchedSyllabus(ctx context.Context, tx pgx.Tx, syllabus InsertSyllabus, batchId string) (SyllabusBatchItem, error) {
+	result := s.createSyllabusQuery(syllabus, batchId)
 
-	if filters.UserId != "" {
-		var userUuid pgtype.UUID
-		if err := userUuid.Scan(filters.UserId); err != nil {
-			s.log.Info("invalid user id")
-			return util.SqlBuilderResult{}, util.ErrMalformed
-		}
-		qb.Concat("and user_id = $%d", userUuid)
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin savepoint", logger.Err(err))
+		return SyllabusBatchItem{}, util.ErrInternal
 	}
+	defer savepoint.Rollback(ctx)
 
-	if filters.CourseId != "" {
-		var courseUuid pgtype.UUID
-		if err := courseUuid.Scan(filters.CourseId); err != nil {
-			s.log.Info("invalid course id")
-			return util.SqlBuilderResult{}, util.ErrMalformed
+	var syllabusId string
+	err = savepoint.QueryRow(ctx, result.Query, result.Args...).Scan(&syllabusId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			s.log.Error("un-handled create batched syllabus query error", logger.Err(err))
+			return SyllabusBatchItem{}, util.ErrInternal
+		}
+		if err := savepoint.Rollback(ctx); err != nil {
+			s.log.Error("failed to rollback savepoint", logger.Err(err))
+			return SyllabusBatchItem{}, util.ErrInternal
 		}
-		qb.Concat("and course_id = $%d", courseUuid)
-	}
 
-	if filters.Year != nil {
-		if *filters.Year <= 0 {
-			s.log.Info("year zero or less was passed as a syllabus query filter")
+		switch pgErr.Code {
+		case database.PgCheckErrCode, database.PgInvalidTextRepErrCode:
+			return SyllabusBatchItem{Err: util.ErrMalformed}, nil
+		case database.PgFKeyViolationErrCode:
+			return SyllabusBatchItem{Err: util.ErrNotFound}, nil
+		case database.PgConflictErrCode:
+			// The duplicate may be an earlier syllabus of the same batch
+			duplicateResult := s.findDuplicateSyllabusQuery(syllabus)
+			var duplicateId string
+			tx.QueryRow(ctx, duplicateResult.Query, duplicateResult.Args...).Scan(&duplicateId)
+			return SyllabusBatchItem{Err: &DuplicateSyllabusError{SyllabusId: duplicateId}}, nil
 		}
-		qb.Concat("and year = $%d", *filters.Year)
+
+		s.log.Error("un-handled create batched syllabus query error", logger.Err(err))
+		return SyllabusBatchItem{}, util.ErrInternal
 	}
 
-	if filters.Semester != "" {
-		qb.Concat("and semester = $%d", filters.Semester)
+	if err := savepoint.Commit(ctx); err != nil {
+		s.log.Error("failed to release savepoint", logger.Err(err))
+		return SyllabusBatchItem{}, util.ErrInternal
 	}
 
-	qb.Concat("limit $%d", paginate.Size)
-	offset := (paginate.Page - 1) * paginate.Size
-	qb.Concat("offset $%d", offset)
+	return SyllabusBatchItem{SyllabusId: syllabusId}, nil
+}
+
+func (s *pgSyllabusRepository) createSyllabusBatchQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into syllabus_batches (user_id)")
+	qb.Concat("values ($%d)", userUuid)
+	qb.Concat("returning id")
 
 	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) DeleteSyllabus(ctx context.Context, userId string, syllabusId string) error {
-	result, err := s.deleteSyllabusQuery(syllabusId)
+func (s *pgSyllabusRepository) VerifySyllabusBatch(ctx context.Context, batchId string) (SyllabusBatchSummary, error) {
+	lockResult, err := s.lockSyllabusBatchQuery(batchId)
 	if err != nil {
-		return err
+		return SyllabusBatchSummary{}, err
+	}
+	expireResult, _ := s.expireSyllabusBatchQuery(batchId)
+	expireRevisionsResult, _ := s.expireSyllabusBatchRevisionsQuery(batchId)
+	listResult, _ := s.listSyllabusBatchQuery(batchId)
+	verifyResult, _ := s.verifySyllabusBatchQuery(batchId)
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	summary := SyllabusBatchSummary{}
+	var verified bool
+	err = tx.QueryRow(ctx, lockResult.Query, lockResult.Args...).Scan(
+		&summary.Id, &summary.UserId, &summary.UserName, &summary.UserEmail, &verified,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusBatchSummary{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled lock syllabus batch query error", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+	if verified {
+		s.log.Info(fmt.Sprintf("syllabus batch %s already verified", batchId))
+		return SyllabusBatchSummary{}, util.ErrConflict
+	}
+
+	// Revisions first, the syllabi update changes which syllabi are still uploading
+	if _, err := tx.Exec(ctx, expireRevisionsResult.Query, expireRevisionsResult.Args...); err != nil {
+		s.log.Error("un-handled expire syllabus batch revisions query error", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+	if _, err := tx.Exec(ctx, expireResult.Query, expireResult.Args...); err != nil {
+		s.log.Error("un-handled expire syllabus batch query error", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+
+	rows, err := tx.Query(ctx, listResult.Query, listResult.Args...)
+	if err != nil {
+		s.log.Error("un-handled list syllabus batch query error", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+	summary.Syllabi = []SyllabusBatchOutcome{}
+	for rows.Next() {
+		outcome := SyllabusBatchOutcome{}
+		if err := rows.Scan(&outcome.SyllabusId, &outcome.Course, &outcome.Status, &outcome.StatusReason); err != nil {
+			rows.Close()
+			s.log.Error("scan syllabus batch error", logger.Err(err))
+			return SyllabusBatchSummary{}, util.ErrInternal
+		}
+		summary.Syllabi = append(summary.Syllabi, outcome)
+	}
+	rows.Close()
+
+	if _, err := tx.Exec(ctx, verifyResult.Query, verifyResult.Args...); err != nil {
+		s.log.Error("un-handled verify syllabus batch query error", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return SyllabusBatchSummary{}, util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus batch %s verified", batchId))
+	return summary, nil
+}
+
+func (s *pgSyllabusRepository) lockSyllabusBatchQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"select b.id, u.id, u.full_name, u.email, b.date_verified is not null",
+		"from syllabus_batches b",
+		"inner join users u on u.id = b.user_id",
+	)
+	qb.Concat("where b.id = $%d", batchUuid)
+	qb.Concat("for update of b")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) expireSyllabusBatchQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabi")
+	qb.Concat("set status = $%d, date_expired = now()", SyllabusExpired)
+	qb.Concat("where batch_id = $%d and status = $%d", batchUuid, SyllabusUploading)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) expireSyllabusBatchRevisionsQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_revisions r")
+	qb.Concat("set status = $%d, date_expired = now()", SyllabusExpired)
+	qb.Concat("from syllabi s")
+	qb.Concat("where s.id = r.syllabus_id and r.revision = 1 and s.batch_id = $%d and s.status = $%d", batchUuid, SyllabusUploading)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) listSyllabusBatchQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"select s.id, c.course, s.status, s.status_reason",
+		"from syllabi s",
+		"inner join courses c on c.id = s.course_id",
+	)
+	qb.Concat("where s.batch_id = $%d", batchUuid)
+	qb.Concat("order by s.date_added, c.course")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) verifySyllabusBatchQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_batches")
+	qb.Concat("set date_verified = now()")
+	qb.Concat("where id = $%d", batchUuid)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) FindSyllabusByChecksum(ctx context.Context, userId string, filter SyllabusChecksumFilter) (string, error) {
+	result, err := s.findSyllabusByChecksumQuery(userId, filter)
+	if err != nil {
+		return "", err
+	}
+
+	var syllabusId string
+	err = s.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&syllabusId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", util.ErrNotFound
+		}
+
+		s.log.Error("un-handled find syllabus by checksum query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	return syllabusId, nil
+}
+
+func (s *pgSyllabusRepository) findSyllabusByChecksumQuery(userId string, filter SyllabusChecksumFilter) (util.SqlBuilderResult, error) {
+	qb := util.NewSqlBuilder("select id from syllabi")
+	qb.Concat("where checksum = $%d", filter.Checksum)
+	qb.Concat("and (status = $%d or (user_id = $%d and status in "+duplicateStatusList+"))", SyllabusPublished, userId)
+
+	if filter.CourseId != "" {
+		courseUuid, err := database.ParsePgUuid(filter.CourseId)
+		if err != nil {
+			return util.SqlBuilderResult{}, err
+		}
+		qb.Concat("and course_id = $%d", courseUuid)
+	}
+
+	if filter.Year != nil {
+		qb.Concat("and year = $%d", *filter.Year)
+	}
+
+	if filter.Semester != "" {
+		qb.Concat("and semester = $%d", filter.Semester)
+	}
+
+	qb.Concat("order by date_added limit 1")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) RecordSyllabusSha256(ctx context.Context, syllabusId string, revision int16, sha256 string) error {
+	revisionResult, err := s.setRevisionSha256Query(syllabusId, revision, sha256)
+	if err != nil {
+		return err
+	}
+	duplicateResult, _ := s.findDuplicateSha256Query(syllabusId, revision, sha256)
+	currentResult, _ := s.setCurrentSha256Query(syllabusId, revision, sha256)
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, revisionResult.Query, revisionResult.Args...)
+	if err != nil {
+		s.log.Error("un-handled set revision sha256 query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	var duplicateId string
+	err = tx.QueryRow(ctx, duplicateResult.Query, duplicateResult.Args...).Scan(&duplicateId)
+	if err == nil {
+		s.log.Info(fmt.Sprintf("syllabus %s revision %d duplicates syllabus %s", syllabusId, revision, duplicateId))
+		return &DuplicateSyllabusError{SyllabusId: duplicateId}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		s.log.Error("un-handled find duplicate sha256 query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	// Only the first upload is current while verifying, later revisions copy the hash once published
+	if _, err := tx.Exec(ctx, currentResult.Query, currentResult.Args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			return &DuplicateSyllabusError{}
+		}
+
+		s.log.Error("un-handled set syllabus sha256 query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *pgSyllabusRepository) setRevisionSha256Query(syllabusId string, revision int16, sha256 string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_revisions")
+	qb.Concat("set sha256 = $%d", sha256)
+	qb.Concat("where syllabus_id = $%d and revision = $%d", syllabusUuid, revision)
+
+	return qb.Result(), nil
+}
+
+// findDuplicateSha256Query finds another syllabus in the same course and term whose current file matches the hash.
+// A revision identical to its own syllabus' current file is also a duplicate.
+func (s *pgSyllabusRepository) findDuplicateSha256Query(syllabusId string, revision int16, sha256 string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"select d.id from syllabi d",
+		"inner join syllabi s on s.course_id = d.course_id and s.year = d.year and s.semester = d.semester",
+	)
+	qb.Concat("where s.id = $%d and d.sha256 = $%d", syllabusUuid, sha256)
+	qb.Concat("and (d.id <> s.id or d.revision <> $%d)", revision)
+	qb.Concat("and d.status in ($%d, $%d)", SyllabusVerifying, SyllabusPublished)
+	qb.Concat("limit 1")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) setCurrentSha256Query(syllabusId string, revision int16, sha256 string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabi")
+	qb.Concat("set sha256 = $%d", sha256)
+	qb.Concat("where id = $%d and revision = $%d", syllabusUuid, revision)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) RecordSyllabusThumbnail(ctx context.Context, syllabusId string, revision int16) error {
+	result, err := s.recordSyllabusThumbnailQuery(syllabusId, revision)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled record syllabus thumbnail query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *pgSyllabusRepository) recordSyllabusThumbnailQuery(syllabusId string, revision int16) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabi")
+	qb.Concat("set thumbnail_revision = $%d", revision)
+	qb.Concat("where id = $%d and revision = $%d", syllabusUuid, revision)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) ListSyllabi(ctx context.Context, userId string, filters SyllabusFilters, paginate util.Paginate) ([]SyllabusSchema, error) {
+	result, err := s.listSyllabiQuery(userId, filters, paginate)
+	if err != nil {
+		return []SyllabusSchema{}, err
+	}
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []SyllabusSchema{}, nil
+		}
+		s.log.Error("un-handled list syllabi query error", logger.Err(err))
+		return []SyllabusSchema{}, util.ErrInternal
+	}
+
+	var syllabi []SyllabusSchema
+	for rows.Next() {
+		syllabus := SyllabusSchema{}
+		err := scanSyllabus(rows, &syllabus)
+		if err != nil {
+			s.log.Error("scan syllabus error", logger.Err(err))
+			return []SyllabusSchema{}, util.ErrInternal
+		}
+
+		syllabi = append(syllabi, syllabus)
+	}
+
+	return syllabi, nil
+}
+
+func (s *pgSyllabusRepository) listSyllabiQuery(userId string, filters SyllabusFilters, paginate util.Paginate) (util.SqlBuilderResult, error) {
+	qb := util.NewSqlBuilder("select " + syllabusColumns + " from syllabi")
+	if filters.SyncedOnly {
+		qb.Concat("where status = $%d", SyllabusPublished)
+	} else {
+		qb.Concat("where (status = $%d or user_id = $%d)", SyllabusPublished, userId)
+	}
+
+	if filters.UserId != "" {
+		var userUuid pgtype.UUID
+		if err := userUuid.Scan(filters.UserId); err != nil {
+			s.log.Info("invalid user id")
+			return util.SqlBuilderResult{}, util.ErrMalformed
+		}
+		qb.Concat("and user_id = $%d", userUuid)
+	}
+
+	if filters.CourseId != "" {
+		var courseUuid pgtype.UUID
+		if err := courseUuid.Scan(filters.CourseId); err != nil {
+			s.log.Info("invalid course id")
+			return util.SqlBuilderResult{}, util.ErrMalformed
+		}
+		qb.Concat("and course_id = $%d", courseUuid)
+	}
+
+	if filters.Year != nil {
+		if *filters.Year <= 0 {
+			s.log.Info("year zero or less was passed as a syllabus query filter")
+		}
+		qb.Concat("and year = $%d", *filters.Year)
+	}
+
+	if filters.Semester != "" {
+		qb.Concat("and semester = $%d", filters.Semester)
+	}
+
+	if filters.TermId != "" {
+		if err := concatTermId(qb, "term_id", filters.TermId); err != nil {
+			return util.SqlBuilderResult{}, err
+		}
+	}
+
+	if filters.Status != "" {
+		qb.Concat("and status = $%d", filters.Status)
+	}
+
+	if filters.InstructorId != "" {
+		instructorUuid, err := database.ParsePgUuid(filters.InstructorId)
+		if err != nil {
+			return util.SqlBuilderResult{}, err
+		}
+		qb.Concat("and instructor_id = $%d", instructorUuid)
+	}
+
+	qb.Concat("limit $%d", paginate.Size)
+	offset := (paginate.Page - 1) * paginate.Size
+	qb.Concat("offset $%d", offset)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) DeleteSyllabus(ctx context.Context, userId string, syllabusId string) error {
+	result, err := s.deleteSyllabusQuery(syllabusId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	var createUserId string
+	err = tx.QueryRow(ctx, result.Query, result.Args...).Scan(&createUserId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		s.log.Error("un-handled delete syllabus query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if createUserId != userId {
+		s.log.Info(fmt.Sprintf("user %s attempted to delete user %s syllabus %s", userId, createUserId, syllabusId))
+		return util.ErrForbidden
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus %s deleted", syllabusId))
+	return nil
+}
+
+func (s *pgSyllabusRepository) deleteSyllabusQuery(syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("delete from syllabi")
+	qb.Concat("where id = $%d", syllabusUuid)
+	qb.Concat("returning user_id")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) UpdateSyllabus(ctx context.Context, userId string, syllabusId string, syllabus UpdateSyllabus) error {
+	result, err := s.updateSyllabusQuery(syllabusId, syllabus)
+	if err != nil {
+		return err
 	}
 
 	tx, err := s.db.Pool.Begin(ctx)
@@ -299,288 +1233,700 @@ func (s *pgSyllabusRepository) DeleteSyllabus(ctx context.Context, userId string
 			return util.ErrNotFound
 		}
 
-		s.log.Error("un-handled delete syllabus query error", logger.Err(err))
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == database.PgFKeyViolationErrCode || pgErr.Code == database.PgCheckErrCode) {
+			return util.ErrMalformed
+		}
+
+		s.log.Error("un-handled update syllabus query error", logger.Err(err))
 		return util.ErrInternal
 	}
 
 	if createUserId != userId {
-		s.log.Info(fmt.Sprintf("user %s attempted to delete user %s syllabus %s", userId, createUserId, syllabusId))
+		s.log.Info(fmt.Sprintf("user %s attempted to update user %s syllabus %s", userId, createUserId, syllabusId))
 		return util.ErrForbidden
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		s.log.Error("failed to commit transaction", logger.Err(err))
-		return util.ErrInternal
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("syllabus %s updated", syllabusId))
+	return nil
+}
+
+func (s *pgSyllabusRepository) updateSyllabusQuery(syllabusId string, syllabus UpdateSyllabus) (util.SqlBuilderResult, error) {
+	var syllabusUuid pgtype.UUID
+	if err := syllabusUuid.Scan(syllabusId); err != nil {
+		return util.SqlBuilderResult{}, util.ErrMalformed
+	}
+
+	qb := util.NewSqlBuilder("update syllabi")
+	qb.Concat("set date_modified = $%d", time.Now())
+
+	if syllabus.Year.IsSpecified() {
+		year, err := syllabus.Year.Get()
+		if err != nil {
+			qb.Concat(",year = $%d", year)
+		}
+	}
+
+	if syllabus.Semester.IsSpecified() {
+		semester, err := syllabus.Semester.Get()
+		if err != nil {
+			qb.Concat(",semester = $%d", semester)
+		}
+	}
+
+	if syllabus.TermId.IsSpecified() {
+		termId, err := syllabus.TermId.Get()
+		if err != nil {
+			return util.SqlBuilderResult{}, util.ErrMalformed
+		}
+		termUuid, err := database.ParsePgUuid(termId)
+		if err != nil {
+			return util.SqlBuilderResult{}, err
+		}
+		qb.Concat(",term_id = $%d", termUuid)
+	}
+
+	if syllabus.InstructorId.IsSpecified() {
+		instructorId, err := syllabus.InstructorId.Get()
+		if err != nil {
+			qb.Concat(",instructor_id = null")
+		} else {
+			instructorUuid, err := database.ParsePgUuid(instructorId)
+			if err != nil {
+				return util.SqlBuilderResult{}, err
+			}
+			qb.Concat(",instructor_id = $%d", instructorUuid)
+		}
+	}
+
+	qb.Concat("where id = $%d", syllabusUuid)
+	qb.Concat("returning user_id")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) LikeSyllabus(ctx context.Context, userId string, syllabusId string, dislike bool) error {
+	deleteResult, err := s.deleteSyllabusLikeQuery(userId, syllabusId)
+	createResult, _ := s.createSyllabusLikeQuery(userId, syllabusId, dislike)
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, deleteResult.Query, deleteResult.Args...)
+	if err != nil {
+		s.log.Error("un-handled syllabus like query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	_, err = tx.Exec(ctx, createResult.Query, createResult.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			s.log.Warn("duplicate syllabus like entry")
+			return util.ErrConflict
+		}
+
+		s.log.Error("un-handled syllabus like query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("like syllabus %s reaction", syllabusId))
+	return nil
+}
+
+func (s *pgSyllabusRepository) createSyllabusLikeQuery(userId string, syllabusId string, dislike bool) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into syllabus_likes (syllabus_id, user_id, is_dislike)")
+	qb.Concat("values ($%d, $%d, $%d)", syllabusUuid, userId, dislike)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) DeleteSyllabusLike(ctx context.Context, userId string, syllabusId string) error {
+	result, err := s.deleteSyllabusLikeQuery(userId, syllabusId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled syllabus delete like query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	s.log.Info(fmt.Sprintf("user %s removed like from syllabus %s", userId, syllabusId))
+	return nil
+}
+
+func (s *pgSyllabusRepository) deleteSyllabusLikeQuery(userId string, syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("delete from syllabus_likes")
+	qb.Concat("where syllabus_id = $%d and user_id = $%d", syllabusUuid, userId)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) ListUserReactions(ctx context.Context, userId string, syllabusIds []string) (map[string]bool, error) {
+	reactions := map[string]bool{}
+	if len(syllabusIds) == 0 {
+		return reactions, nil
+	}
+
+	qb := util.NewSqlBuilder("select syllabus_id, is_dislike from syllabus_likes")
+	qb.Concat("where user_id = $%d and syllabus_id = any($%d::uuid[])", userId, syllabusIds)
+	result := qb.Result()
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return map[string]bool{}, util.ErrMalformed
+		}
+
+		s.log.Error("un-handled list user reactions query error", logger.Err(err))
+		return map[string]bool{}, util.ErrInternal
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var syllabusId string
+		var isDislike bool
+		if err := rows.Scan(&syllabusId, &isDislike); err != nil {
+			s.log.Error("scan user reaction error", logger.Err(err))
+			return map[string]bool{}, util.ErrInternal
+		}
+
+		reactions[syllabusId] = isDislike
+	}
+
+	return reactions, nil
+}
+
+func (s *pgSyllabusRepository) ListSyllabusLikes(ctx context.Context, syllabusId string) ([]SyllabusLikeSchema, error) {
+	result, err := s.listSyllabusLikesQuery(syllabusId)
+	if err != nil {
+		return []SyllabusLikeSchema{}, err
+	}
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []SyllabusLikeSchema{}, nil
+		}
+
+		s.log.Error("un-handled list syllabus likes query error", logger.Err(err))
+		return []SyllabusLikeSchema{}, util.ErrInternal
+	}
+
+	likes := []SyllabusLikeSchema{}
+	for rows.Next() {
+		like := SyllabusLikeSchema{}
+		err := rows.Scan(
+			&like.SyllabusId,
+			&like.UserId,
+			&like.IsDislike,
+			&like.DateAdded,
+		)
+		if err != nil {
+			s.log.Error(fmt.Sprintf("an error occurred when scanning for syllabus likes on syllabus %s", syllabusId), logger.Err(err))
+			return []SyllabusLikeSchema{}, util.ErrInternal
+		}
+
+		likes = append(likes, like)
+	}
+
+	return likes, nil
+}
+
+func (s *pgSyllabusRepository) listSyllabusLikesQuery(syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select syllabus_id, user_id, is_dislike, date_added from syllabus_likes")
+	qb.Concat("where syllabus_id = $%d", syllabusUuid)
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) CountUserSyllabusLikes(ctx context.Context, userId string) (int, error) {
+	result, err := s.countUserSyllabusLikesQuery(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = s.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&count)
+	if err != nil {
+		s.log.Error("un-handled count user syllabus likes query error", logger.Err(err))
+		return 0, util.ErrInternal
+	}
+
+	return count, nil
+}
+
+func (s *pgSyllabusRepository) ListUnindexedSyllabusIds(ctx context.Context, limit uint) ([]string, error) {
+	qb := util.NewSqlBuilder(
+		"select s.id from syllabi s",
+		"left join syllabus_texts t on t.syllabus_id = s.id and t.revision = s.revision",
+	)
+	qb.Concat("where s.status = $%d and t.syllabus_id is null", SyllabusPublished)
+	qb.Concat("order by s.date_added")
+	qb.Concat("limit $%d", limit)
+	result := qb.Result()
+
+	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		s.log.Error("un-handled list unindexed syllabi query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	syllabusIds := []string{}
+	for rows.Next() {
+		var syllabusId string
+		if err := rows.Scan(&syllabusId); err != nil {
+			s.log.Error("failed to scan unindexed syllabus row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		syllabusIds = append(syllabusIds, syllabusId)
 	}
 
-	s.log.Info(fmt.Sprintf("syllabus %s deleted", syllabusId))
-	return nil
+	if err := rows.Err(); err != nil {
+		s.log.Error("list unindexed syllabi rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return syllabusIds, nil
 }
 
-func (s *pgSyllabusRepository) deleteSyllabusQuery(syllabusId string) (util.SqlBuilderResult, error) {
-	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+func (s *pgSyllabusRepository) countUserSyllabusLikesQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("delete from syllabi")
-	qb.Concat("where id = $%d", syllabusUuid)
-	qb.Concat("returning user_id")
+	qb := util.NewSqlBuilder(
+		"select count(*) from syllabus_likes sl",
+		"inner join syllabi s on s.id = sl.syllabus_id",
+	)
+	qb.Concat("where s.user_id = $%d and s.status = $%d and not sl.is_dislike", userUuid, SyllabusPublished)
 
 	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) UpdateSyllabus(ctx context.Context, userId string, syllabusId string, syllabus UpdateSyllabus) error {
-	result, err := s.updateSyllabusQuery(syllabusId, syllabus)
+// Deprecated - use database database.ParsePgUuid()
+func (s *pgSyllabusRepository) validateSyllabusId(syllabusId string) (pgtype.UUID, error) {
+	var syllabusUuid pgtype.UUID
+	if err := syllabusUuid.Scan(syllabusId); err != nil {
+		return pgtype.UUID{}, util.ErrMalformed
+	}
+
+	return syllabusUuid, nil
+}
+
+func (s *pgSyllabusRepository) TransitionSyllabus(ctx context.Context, syllabusId string, status string, reason string) (SyllabusMeta, error) {
+	dateColumn, ok := syllabusStatusDates[status]
+	if !ok {
+		return SyllabusMeta{}, util.ErrMalformed
+	}
+
+	getResult, err := s.getSyllabusMetaQuery(syllabusId)
 	if err != nil {
-		return err
+		return SyllabusMeta{}, err
 	}
+	updateResult, _ := s.transitionSyllabusQuery(syllabusId, status, dateColumn, reason)
+	revisionResult, _ := s.transitionSyllabusRevisionQuery(syllabusId, 1, status, dateColumn, reason)
 
 	tx, err := s.db.Pool.Begin(ctx)
 	if err != nil {
-		s.log.Error("failed to begin transaction", logger.Err(err))
-		return util.ErrInternal
+		s.log.Error("failed to begin transition syllabus transaction", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
 	defer tx.Rollback(ctx)
 
-	var createUserId string
-	err = tx.QueryRow(ctx, result.Query, result.Args...).Scan(&createUserId)
+	meta := SyllabusMeta{}
+	err = tx.QueryRow(ctx, getResult.Query, getResult.Args...).Scan(
+		&meta.Id,
+		&meta.Revision,
+		&meta.Status,
+		&meta.FileSize,
+		&meta.UserId,
+		&meta.UserName,
+		&meta.UserEmail,
+		&meta.Course,
+		&meta.BatchPending,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return util.ErrNotFound
+			s.log.Info(fmt.Sprintf("syllabus %s not longer exists", syllabusId))
+			return SyllabusMeta{}, util.ErrNotFound
 		}
 
-		s.log.Error("un-handled update syllabus query error", logger.Err(err))
-		return util.ErrInternal
-	}
-
-	if createUserId != userId {
-		s.log.Info(fmt.Sprintf("user %s attempted to update user %s syllabus %s", userId, createUserId, syllabusId))
-		return util.ErrForbidden
+		s.log.Error("un-handled get syllabus meta query error", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
+	meta.ObjectKey = SyllabusObjectKey(meta.Id, meta.Revision)
 
-	if err := tx.Commit(ctx); err != nil {
-		s.log.Error("failed to commit transaction", logger.Err(err))
-		return util.ErrInternal
+	if !CanTransitionSyllabus(meta.Status, status) {
+		s.log.Info(fmt.Sprintf("syllabus %s cannot transition from %s to %s", syllabusId, meta.Status, status))
+		return meta, util.ErrConflict
 	}
 
-	s.log.Info(fmt.Sprintf("syllabus %s updated", syllabusId))
-	return nil
-}
+	if _, err := tx.Exec(ctx, updateResult.Query, updateResult.Args...); err != nil {
+		// Restoring a removed syllabus can collide with a newer upload of the same file
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			return meta, &DuplicateSyllabusError{}
+		}
 
-func (s *pgSyllabusRepository) updateSyllabusQuery(syllabusId string, syllabus UpdateSyllabus) (util.SqlBuilderResult, error) {
-	var syllabusUuid pgtype.UUID
-	if err := syllabusUuid.Scan(syllabusId); err != nil {
-		return util.SqlBuilderResult{}, util.ErrMalformed
+		s.log.Error("un-handled transition syllabus query error", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
 
-	qb := util.NewSqlBuilder("update syllabi")
-	qb.Concat("set date_modified = $%d", time.Now())
-
-	if syllabus.Year.IsSpecified() {
-		year, err := syllabus.Year.Get()
-		if err != nil {
-			qb.Concat(",year = $%d", year)
+	// The initial upload is also the first revision, keep its status in step
+	if meta.Status == SyllabusUploading || meta.Status == SyllabusVerifying {
+		if _, err := tx.Exec(ctx, revisionResult.Query, revisionResult.Args...); err != nil {
+			s.log.Error("un-handled transition syllabus revision query error", logger.Err(err))
+			return SyllabusMeta{}, util.ErrInternal
 		}
 	}
 
-	if syllabus.Semester.IsSpecified() {
-		semester, err := syllabus.Semester.Get()
-		if err != nil {
-			qb.Concat(",semester = $%d", semester)
-		}
+	if err := tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transition syllabus transaction", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
 
-	qb.Concat("where id = $%d", syllabusUuid)
-	qb.Concat("returning user_id")
-
-	return qb.Result(), nil
+	s.log.Info(fmt.Sprintf("syllabus %s transitioned from %s to %s", syllabusId, meta.Status, status))
+	meta.Status = status
+	return meta, nil
 }
 
-func (s *pgSyllabusRepository) SyncSyllabus(ctx context.Context, syllabusId string) error {
-	result, err := s.syncSyllabusQuery(syllabusId)
+func (s *pgSyllabusRepository) transitionSyllabusQuery(syllabusId string, status string, dateColumn string, reason string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
-		return err
+		return util.SqlBuilderResult{}, err
 	}
 
-	_, err = s.db.Pool.Exec(ctx, result.Query, result.Args...)
-	if err != nil {
-		s.log.Error("un-handled sync syllabus query error", logger.Err(err))
-		return util.ErrInternal
+	var statusReason *string
+	if status == SyllabusRejected || status == SyllabusRemoved {
+		statusReason = &reason
 	}
 
-	s.log.Info(fmt.Sprintf("syllabus %s synced", syllabusId))
-	return nil
+	qb := util.NewSqlBuilder("update syllabi")
+	qb.Concat("set status = $%d, status_reason = $%d, "+dateColumn+" = now()", status, statusReason)
+	qb.Concat("where id = $%d", syllabusUuid)
+
+	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) syncSyllabusQuery(syllabusId string) (util.SqlBuilderResult, error) {
-	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+// getSyllabusMetaQuery selects a syllabus' status and notification details, locking the syllabus row.
+func (s *pgSyllabusRepository) getSyllabusMetaQuery(syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("update syllabi")
-	qb.Concat("set date_synced = $%d", time.Now())
-	qb.Concat("where id = $%d", syllabusUuid)
+	qb := util.NewSqlBuilder(
+		"select s.id, s.revision, s.status, s.file_size, u.id as user_id, u.full_name, u.email, c.course,",
+		"b.id is not null and b.date_verified is null",
+		"from syllabi s",
+		"inner join users u on u.id = s.user_id",
+		"inner join courses c on c.id = s.course_id",
+		"left join syllabus_batches b on b.id = s.batch_id",
+	)
+	qb.Concat("where s.id = $%d", syllabusUuid)
+	qb.Concat("for update of s")
 
 	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) LikeSyllabus(ctx context.Context, userId string, syllabusId string, dislike bool) error {
-	deleteResult, err := s.deleteSyllabusLikeQuery(userId, syllabusId)
-	createResult, _ := s.createSyllabusLikeQuery(userId, syllabusId, dislike)
+func (s *pgSyllabusRepository) CreateSyllabusRevision(ctx context.Context, userId string, syllabusId string, entity InsertSyllabusRevision) (SyllabusRevisionSchema, error) {
+	lockResult, err := s.lockSyllabusQuery(syllabusId)
+	if err != nil {
+		return SyllabusRevisionSchema{}, err
+	}
+	pendingResult, _ := s.getPendingRevisionQuery(syllabusId)
+	duplicateResult, _ := s.findDuplicateChecksumQuery(syllabusId, entity.Checksum)
 
 	tx, err := s.db.Pool.Begin(ctx)
 	if err != nil {
 		s.log.Error("failed to begin transaction", logger.Err(err))
-		return util.ErrInternal
+		return SyllabusRevisionSchema{}, util.ErrInternal
 	}
 	defer tx.Rollback(ctx)
 
-	_, err = tx.Exec(ctx, deleteResult.Query, deleteResult.Args...)
+	// Lock the syllabus so concurrent requests cannot create the same revision
+	var ownerId, status string
+	var current int16
+	err = tx.QueryRow(ctx, lockResult.Query, lockResult.Args...).Scan(&ownerId, &status, &current)
 	if err != nil {
-		s.log.Error("un-handled syllabus like query error", logger.Err(err))
-		return util.ErrInternal
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusRevisionSchema{}, util.ErrNotFound
+		}
+
+		s.log.Error("un-handled lock syllabus query error", logger.Err(err))
+		return SyllabusRevisionSchema{}, util.ErrInternal
 	}
 
-	_, err = tx.Exec(ctx, createResult.Query, createResult.Args...)
+	if ownerId != userId {
+		s.log.Info(fmt.Sprintf("user %s attempted to revise user %s syllabus %s", userId, ownerId, syllabusId))
+		return SyllabusRevisionSchema{}, util.ErrForbidden
+	}
+	if status != SyllabusPublished {
+		return SyllabusRevisionSchema{}, util.ErrConflict
+	}
+
+	var latest int16
+	var pending bool
+	if err := tx.QueryRow(ctx, pendingResult.Query, pendingResult.Args...).Scan(&latest, &pending); err != nil {
+		s.log.Error("un-handled get pending revision query error", logger.Err(err))
+		return SyllabusRevisionSchema{}, util.ErrInternal
+	}
+	if pending {
+		s.log.Info(fmt.Sprintf("syllabus %s already has a pending revision", syllabusId))
+		return SyllabusRevisionSchema{}, util.ErrConflict
+	}
+
+	var duplicateId string
+	err = tx.QueryRow(ctx, duplicateResult.Query, duplicateResult.Args...).Scan(&duplicateId)
+	if err == nil {
+		s.log.Info(fmt.Sprintf("syllabus %s revision duplicates syllabus %s", syllabusId, duplicateId))
+		return SyllabusRevisionSchema{}, &DuplicateSyllabusError{SyllabusId: duplicateId}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		s.log.Error("un-handled find duplicate checksum query error", logger.Err(err))
+		return SyllabusRevisionSchema{}, util.ErrInternal
+	}
+
+	revision := SyllabusRevisionSchema{
+		SyllabusId:  syllabusId,
+		Revision:    max(latest, current) + 1,
+		File:        entity.File,
+		FileSize:    entity.FileSize,
+		ContentType: entity.ContentType,
+		Checksum:    sql.NullString{String: entity.Checksum, Valid: true},
+		Status:      SyllabusUploading,
+	}
+	revision.ObjectKey = SyllabusObjectKey(syllabusId, revision.Revision)
+
+	insertResult, _ := s.createSyllabusRevisionQuery(revision)
+	err = tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&revision.DateAdded)
 	if err != nil {
 		var pgErr *pgconn.PgError
-
-		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
-			s.log.Warn("duplicate syllabus like entry")
-			return util.ErrConflict
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgCheckErrCode {
+			return SyllabusRevisionSchema{}, util.ErrMalformed
 		}
 
-		s.log.Error("un-handled syllabus like query error", logger.Err(err))
-		return util.ErrInternal
+		s.log.Error("un-handled create syllabus revision query error", logger.Err(err))
+		return SyllabusRevisionSchema{}, util.ErrInternal
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		s.log.Error("failed to commit transaction", logger.Err(err))
-		return util.ErrInternal
+		return SyllabusRevisionSchema{}, util.ErrInternal
 	}
 
-	s.log.Info(fmt.Sprintf("like syllabus %s reaction", syllabusId))
-	return nil
+	s.log.Info(fmt.Sprintf("syllabus %s revision %d created", syllabusId, revision.Revision))
+	return revision, nil
 }
 
-func (s *pgSyllabusRepository) createSyllabusLikeQuery(userId string, syllabusId string, dislike bool) (util.SqlBuilderResult, error) {
-	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+func (s *pgSyllabusRepository) lockSyllabusQuery(syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("insert into syllabus_likes (syllabus_id, user_id, is_dislike)")
-	qb.Concat("values ($%d, $%d, $%d)", syllabusUuid, userId, dislike)
+	qb := util.NewSqlBuilder("select user_id, status, revision from syllabi")
+	qb.Concat("where id = $%d", syllabusUuid)
+	qb.Concat("for update")
 
 	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) DeleteSyllabusLike(ctx context.Context, userId string, syllabusId string) error {
-	result, err := s.deleteSyllabusLikeQuery(userId, syllabusId)
+func (s *pgSyllabusRepository) getPendingRevisionQuery(syllabusId string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
-		return err
+		return util.SqlBuilderResult{}, err
 	}
 
-	_, err = s.db.Pool.Exec(ctx, result.Query, result.Args...)
+	qb := util.NewSqlBuilder()
+	qb.Concat("select coalesce(max(revision), 0)::smallint, coalesce(bool_or(status in ($%d, $%d)), false)", SyllabusUploading, SyllabusVerifying)
+	qb.Concat("from syllabus_revisions")
+	qb.Concat("where syllabus_id = $%d", syllabusUuid)
+
+	return qb.Result(), nil
+}
+
+// findDuplicateChecksumQuery finds a syllabus in the same course and term, including itself, whose current file matches the checksum.
+func (s *pgSyllabusRepository) findDuplicateChecksumQuery(syllabusId string, checksum string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
-		s.log.Error("un-handled syllabus delete like query error", logger.Err(err))
-		return util.ErrInternal
+		return util.SqlBuilderResult{}, err
 	}
 
-	s.log.Info(fmt.Sprintf("user %s removed like from syllabus %s", userId, syllabusId))
-	return nil
+	qb := util.NewSqlBuilder(
+		"select d.id from syllabi d",
+		"inner join syllabi s on s.course_id = d.course_id and s.year = d.year and s.semester = d.semester",
+	)
+	qb.Concat("where s.id = $%d and d.checksum = $%d", syllabusUuid, checksum)
+	qb.Concat("and d.status in " + duplicateStatusList)
+	qb.Concat("limit 1")
+
+	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) deleteSyllabusLikeQuery(userId string, syllabusId string) (util.SqlBuilderResult, error) {
-	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+func (s *pgSyllabusRepository) createSyllabusRevisionQuery(revision SyllabusRevisionSchema) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(revision.SyllabusId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("delete from syllabus_likes")
-	qb.Concat("where syllabus_id = $%d and user_id = $%d", syllabusUuid, userId)
+	qb := util.NewSqlBuilder("insert into syllabus_revisions (syllabus_id, revision, object_key, file, file_size, content_type, checksum)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, $%d, $%d, $%d)", syllabusUuid, revision.Revision, revision.ObjectKey, revision.File, revision.FileSize, revision.ContentType, revision.Checksum)
+	qb.Concat("returning date_added")
 
 	return qb.Result(), nil
 }
 
-func (s *pgSyllabusRepository) ListSyllabusLikes(ctx context.Context, syllabusId string) ([]SyllabusLikeSchema, error) {
-	result, err := s.listSyllabusLikesQuery(syllabusId)
+func (s *pgSyllabusRepository) ListSyllabusRevisions(ctx context.Context, userId string, syllabusId string) ([]SyllabusRevisionSchema, error) {
+	result, err := s.listSyllabusRevisionsQuery(userId, syllabusId, nil)
 	if err != nil {
-		return []SyllabusLikeSchema{}, err
+		return []SyllabusRevisionSchema{}, err
 	}
 
 	rows, err := s.db.Pool.Query(ctx, result.Query, result.Args...)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return []SyllabusLikeSchema{}, nil
+		s.log.Error("un-handled list syllabus revisions query error", logger.Err(err))
+		return []SyllabusRevisionSchema{}, util.ErrInternal
+	}
+
+	revisions := []SyllabusRevisionSchema{}
+	for rows.Next() {
+		revision := SyllabusRevisionSchema{}
+		if err := scanSyllabusRevision(rows, &revision); err != nil {
+			s.log.Error("scan syllabus revision error", logger.Err(err))
+			return []SyllabusRevisionSchema{}, util.ErrInternal
 		}
+		revisions = append(revisions, revision)
+	}
 
-		s.log.Error("un-handled list syllabus likes query error", logger.Err(err))
-		return []SyllabusLikeSchema{}, util.ErrInternal
+	return revisions, nil
+}
+
+func (s *pgSyllabusRepository) GetSyllabusRevision(ctx context.Context, userId string, syllabusId string, revision int16) (SyllabusRevisionSchema, error) {
+	result, err := s.listSyllabusRevisionsQuery(userId, syllabusId, &revision)
+	if err != nil {
+		return SyllabusRevisionSchema{}, err
 	}
 
-	likes := []SyllabusLikeSchema{}
-	for rows.Next() {
-		like := SyllabusLikeSchema{}
-		err := rows.Scan(
-			&like.SyllabusId,
-			&like.UserId,
-			&like.IsDislike,
-			&like.DateAdded,
-		)
-		if err != nil {
-			s.log.Error(fmt.Sprintf("an error occurred when scanning for syllabus likes on syllabus %s", syllabusId), logger.Err(err))
-			return []SyllabusLikeSchema{}, util.ErrInternal
+	syllabusRevision := SyllabusRevisionSchema{}
+	err = scanSyllabusRevision(s.db.Pool.QueryRow(ctx, result.Query, result.Args...), &syllabusRevision)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusRevisionSchema{}, util.ErrNotFound
 		}
 
-		likes = append(likes, like)
+		s.log.Error("un-handled get syllabus revision query error", logger.Err(err))
+		return SyllabusRevisionSchema{}, util.ErrInternal
 	}
 
-	return likes, nil
+	return syllabusRevision, nil
 }
 
-func (s *pgSyllabusRepository) listSyllabusLikesQuery(syllabusId string) (util.SqlBuilderResult, error) {
-	syllabusUuid, err := s.validateSyllabusId(syllabusId)
+// listSyllabusRevisionsQuery selects the revisions visible to the user, other users only see published revisions of published syllabi.
+func (s *pgSyllabusRepository) listSyllabusRevisionsQuery(userId string, syllabusId string, revision *int16) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("select syllabus_id, user_id, is_dislike, date_added from syllabus_likes")
-	qb.Concat("where syllabus_id = $%d", syllabusUuid)
+	qb := util.NewSqlBuilder(
+		"select r.syllabus_id, r.revision, r.object_key, r.file, r.file_size, r.content_type, r.checksum, r.sha256, r.status, r.status_reason,",
+		"r.date_added, r.date_synced, r.date_published, r.date_rejected, r.date_expired",
+		"from syllabus_revisions r",
+		"inner join syllabi s on s.id = r.syllabus_id",
+	)
+	qb.Concat("where r.syllabus_id = $%d", syllabusUuid)
+	qb.Concat("and (s.user_id = $%d or (s.status = $%d and r.status = $%d))", userId, SyllabusPublished, SyllabusPublished)
+	if revision != nil {
+		qb.Concat("and r.revision = $%d", *revision)
+	}
+	qb.Concat("order by r.revision desc")
 
 	return qb.Result(), nil
 }
 
-// Deprecated - use database database.ParsePgUuid()
-func (s *pgSyllabusRepository) validateSyllabusId(syllabusId string) (pgtype.UUID, error) {
-	var syllabusUuid pgtype.UUID
-	if err := syllabusUuid.Scan(syllabusId); err != nil {
-		return pgtype.UUID{}, util.ErrMalformed
-	}
-
-	return syllabusUuid, nil
+func scanSyllabusRevision(row pgx.Row, revision *SyllabusRevisionSchema) error {
+	return row.Scan(
+		&revision.SyllabusId,
+		&revision.Revision,
+		&revision.ObjectKey,
+		&revision.File,
+		&revision.FileSize,
+		&revision.ContentType,
+		&revision.Checksum,
+		&revision.Sha256,
+		&revision.Status,
+		&revision.StatusReason,
+		&revision.DateAdded,
+		&revision.DateSynced,
+		&revision.DatePublished,
+		&revision.DateRejected,
+		&revision.DateExpired,
+	)
 }
 
-func (s *pgSyllabusRepository) VerifySyllabus(ctx context.Context, syllabusId string) (bool, SyllabusMeta, error) {
-	tx, err := s.db.Pool.Begin(ctx)
+func (s *pgSyllabusRepository) TransitionSyllabusRevision(ctx context.Context, syllabusId string, revision int16, status string, reason string) (SyllabusMeta, error) {
+	dateColumn, ok := syllabusStatusDates[status]
+	if !ok || status == SyllabusRemoved {
+		return SyllabusMeta{}, util.ErrMalformed
+	}
+
+	getResult, err := s.getSyllabusRevisionMetaQuery(syllabusId, revision)
 	if err != nil {
-		s.log.Error("failed to begin verify syllabus transaction", logger.Err(err))
-		return false, SyllabusMeta{}, util.ErrInternal
+		return SyllabusMeta{}, err
 	}
-	defer tx.Rollback(ctx)
+	updateResult, _ := s.transitionSyllabusRevisionQuery(syllabusId, revision, status, dateColumn, reason)
+	currentResult, _ := s.setCurrentRevisionQuery(syllabusId, revision)
 
-	getResult, err := s.getSyllabusMetaQuery(syllabusId)
+	tx, err := s.db.Pool.Begin(ctx)
 	if err != nil {
-		return false, SyllabusMeta{}, err
+		s.log.Error("failed to begin transition syllabus revision transaction", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
+	defer tx.Rollback(ctx)
 
-	var dateSynced sql.NullTime
 	meta := SyllabusMeta{}
 	err = tx.QueryRow(ctx, getResult.Query, getResult.Args...).Scan(
 		&meta.Id,
-		&dateSynced,
+		&meta.Revision,
+		&meta.ObjectKey,
+		&meta.Status,
+		&meta.FileSize,
 		&meta.UserId,
 		&meta.UserName,
 		&meta.UserEmail,
@@ -588,49 +1934,97 @@ func (s *pgSyllabusRepository) VerifySyllabus(ctx context.Context, syllabusId st
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			s.log.Info(fmt.Sprintf("syllabus %s not longer exists", syllabusId))
-			return false, SyllabusMeta{}, util.ErrNotFound
+			s.log.Info(fmt.Sprintf("syllabus %s revision %d not longer exists", syllabusId, revision))
+			return SyllabusMeta{}, util.ErrNotFound
 		}
 
-		s.log.Error("un-handled verify syllabus query error", logger.Err(err))
-		return false, SyllabusMeta{}, util.ErrInternal
+		s.log.Error("un-handled get syllabus revision meta query error", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
 
-	isVerified := true
-	// Delete syllabus as it was not uploaded within a given time span
-	if !dateSynced.Valid {
-		deleteResult, _ := s.deleteSyllabusQuery(syllabusId)
-		_, err = tx.Exec(ctx, deleteResult.Query, deleteResult.Args...)
-		if err != nil {
-			s.log.Error("un-handled delete syllabus query error", logger.Err(err))
-			return false, SyllabusMeta{}, util.ErrInternal
-		}
+	if !CanTransitionSyllabus(meta.Status, status) {
+		s.log.Info(fmt.Sprintf("syllabus %s revision %d cannot transition from %s to %s", syllabusId, revision, meta.Status, status))
+		return meta, util.ErrConflict
+	}
+
+	if _, err := tx.Exec(ctx, updateResult.Query, updateResult.Args...); err != nil {
+		s.log.Error("un-handled transition syllabus revision query error", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
+	}
+
+	if status == SyllabusPublished {
+		if _, err := tx.Exec(ctx, currentResult.Query, currentResult.Args...); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+				return meta, &DuplicateSyllabusError{}
+			}
 
-		s.log.Info(fmt.Sprintf("syllabus %s removed", syllabusId))
-		isVerified = false
+			s.log.Error("un-handled set current syllabus revision query error", logger.Err(err))
+			return SyllabusMeta{}, util.ErrInternal
+		}
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		s.log.Error("failed to commit verify syllabus transaction", logger.Err(err))
-		return false, SyllabusMeta{}, util.ErrInternal
+		s.log.Error("failed to commit transition syllabus revision transaction", logger.Err(err))
+		return SyllabusMeta{}, util.ErrInternal
 	}
 
-	return isVerified, meta, nil
+	s.log.Info(fmt.Sprintf("syllabus %s revision %d transitioned from %s to %s", syllabusId, revision, meta.Status, status))
+	meta.Status = status
+	return meta, nil
 }
 
-func (s *pgSyllabusRepository) getSyllabusMetaQuery(syllabusId string) (util.SqlBuilderResult, error) {
+// getSyllabusRevisionMetaQuery selects a revision's status and notification details, locking the revision row.
+func (s *pgSyllabusRepository) getSyllabusRevisionMetaQuery(syllabusId string, revision int16) (util.SqlBuilderResult, error) {
 	syllabusUuid, err := database.ParsePgUuid(syllabusId)
 	if err != nil {
 		return util.SqlBuilderResult{}, err
 	}
 
 	qb := util.NewSqlBuilder(
-		"select s.id, s.date_synced, u.id as user_id, u.full_name, u.email, c.course",
-		"from syllabi s",
+		"select s.id, r.revision, r.object_key, r.status, r.file_size, u.id as user_id, u.full_name, u.email, c.course",
+		"from syllabus_revisions r",
+		"inner join syllabi s on s.id = r.syllabus_id",
 		"inner join users u on u.id = s.user_id",
 		"inner join courses c on c.id = s.course_id",
 	)
-	qb.Concat("where s.id = $%d", syllabusUuid)
+	qb.Concat("where r.syllabus_id = $%d and r.revision = $%d", syllabusUuid, revision)
+	qb.Concat("for update of r")
+
+	return qb.Result(), nil
+}
+
+func (s *pgSyllabusRepository) transitionSyllabusRevisionQuery(syllabusId string, revision int16, status string, dateColumn string, reason string) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	var statusReason *string
+	if status == SyllabusRejected {
+		statusReason = &reason
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_revisions")
+	qb.Concat("set status = $%d, status_reason = $%d, "+dateColumn+" = now()", status, statusReason)
+	qb.Concat("where syllabus_id = $%d and revision = $%d", syllabusUuid, revision)
+
+	return qb.Result(), nil
+}
+
+// setCurrentRevisionQuery replaces a syllabus' current file with a revision's file.
+func (s *pgSyllabusRepository) setCurrentRevisionQuery(syllabusId string, revision int16) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder(
+		"update syllabi s",
+		"set revision = r.revision, file = r.file, file_size = r.file_size, content_type = r.content_type, checksum = r.checksum, sha256 = r.sha256",
+		"from syllabus_revisions r",
+	)
+	qb.Concat("where s.id = r.syllabus_id and r.syllabus_id = $%d and r.revision = $%d", syllabusUuid, revision)
 
 	return qb.Result(), nil
 }
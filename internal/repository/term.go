@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CurrentTerm is accepted in place of a term id wherever terms are looked up or filtered.
+const CurrentTerm = "current"
+
+type TermSchema struct {
+	Id          string
+	Code        string // e.g. 2024-fall
+	Name        string // e.g. Fall 2024
+	Year        int16
+	Semester    string
+	Institution string
+	DateStart   time.Time
+	DateEnd     time.Time
+}
+
+type InsertTerm struct {
+	Code        string
+	Name        string
+	Year        int16
+	Semester    string
+	Institution string // Defaults to the database default when empty
+	DateStart   time.Time
+	DateEnd     time.Time
+}
+
+type TermFilters struct {
+	Year        *int16
+	Semester    string
+	Institution string
+}
+
+type TermRepository interface {
+	// ListTerms lists terms latest first.
+	ListTerms(ctx context.Context, filters TermFilters, paginate util.Paginate) ([]TermSchema, error)
+	// GetTerm gets a term by id, [CurrentTerm] resolves to the current term.
+	GetTerm(ctx context.Context, termId string) (TermSchema, error)
+	// CreateTerm adds a term, returning [util.ErrConflict] if the institution already has the code or year and semester.
+	CreateTerm(ctx context.Context, term InsertTerm) (TermSchema, error)
+}
+
+type pgTermRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgTermRepository(db *database.PostgresDb, log logger.Logger) *pgTermRepository {
+	return &pgTermRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const termColumns = "id, code, name, year, semester, institution, date_start, date_end"
+
+// currentTermIdQuery selects the shortest term in progress, or the next term to start between terms.
+const currentTermIdQuery = "(select id from terms where date_end >= current_date " +
+	"order by date_start > current_date, case when date_start <= current_date then date_end - date_start end, date_start limit 1)"
+
+func scanTerm(row pgx.Row, term *TermSchema) error {
+	return row.Scan(
+		&term.Id,
+		&term.Code,
+		&term.Name,
+		&term.Year,
+		&term.Semester,
+		&term.Institution,
+		&term.DateStart,
+		&term.DateEnd,
+	)
+}
+
+// concatTermId appends a condition matching column to a term id, resolving [CurrentTerm] to the current term.
+func concatTermId(qb *util.SqlBuilder, column string, termId string) error {
+	if termId == CurrentTerm {
+		qb.Concat("and " + column + " = " + currentTermIdQuery)
+		return nil
+	}
+
+	termUuid, err := database.ParsePgUuid(termId)
+	if err != nil {
+		return err
+	}
+	qb.Concat("and "+column+" = $%d", termUuid)
+
+	return nil
+}
+
+func (t *pgTermRepository) ListTerms(ctx context.Context, filters TermFilters, paginate util.Paginate) ([]TermSchema, error) {
+	qb := util.NewSqlBuilder("select " + termColumns + " from terms")
+	qb.Concat("where 1 = 1")
+
+	if filters.Year != nil {
+		qb.Concat("and year = $%d", *filters.Year)
+	}
+	if filters.Semester != "" {
+		qb.Concat("and semester = $%d", filters.Semester)
+	}
+	if filters.Institution != "" {
+		qb.Concat("and institution = $%d", filters.Institution)
+	}
+
+	qb.Concat("order by date_start desc, code")
+	qb.Concat("limit $%d", paginate.Size)
+	qb.Concat("offset $%d", (paginate.Page-1)*paginate.Size)
+	result := qb.Result()
+
+	rows, err := t.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		t.log.Error("un-handled list terms query error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+	defer rows.Close()
+
+	terms := []TermSchema{}
+	for rows.Next() {
+		var term TermSchema
+		if err := scanTerm(rows, &term); err != nil {
+			t.log.Error("failed to scan term row", logger.Err(err))
+			return nil, util.ErrInternal
+		}
+		terms = append(terms, term)
+	}
+
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgInvalidTextRepErrCode {
+			return nil, util.ErrMalformed
+		}
+
+		t.log.Error("list terms rows error", logger.Err(err))
+		return nil, util.ErrInternal
+	}
+
+	return terms, nil
+}
+
+func (t *pgTermRepository) GetTerm(ctx context.Context, termId string) (TermSchema, error) {
+	qb := util.NewSqlBuilder("select " + termColumns + " from terms")
+	qb.Concat("where 1 = 1")
+	if err := concatTermId(qb, "id", termId); err != nil {
+		return TermSchema{}, err
+	}
+	result := qb.Result()
+
+	term := TermSchema{}
+	err := scanTerm(t.db.Pool.QueryRow(ctx, result.Query, result.Args...), &term)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TermSchema{}, util.ErrNotFound
+		}
+
+		t.log.Error("un-handled get term query error", logger.Err(err))
+		return TermSchema{}, util.ErrInternal
+	}
+
+	return term, nil
+}
+
+func (t *pgTermRepository) CreateTerm(ctx context.Context, term InsertTerm) (TermSchema, error) {
+	qb := util.NewSqlBuilder("insert into terms (code, name, year, semester, institution, date_start, date_end)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, coalesce(nullif($%d, ''), 'Toronto Metropolitan University'), $%d, $%d)",
+		term.Code, term.Name, term.Year, term.Semester, term.Institution, term.DateStart, term.DateEnd)
+	qb.Concat("returning " + termColumns)
+	result := qb.Result()
+
+	created := TermSchema{}
+	err := scanTerm(t.db.Pool.QueryRow(ctx, result.Query, result.Args...), &created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgConflictErrCode {
+				return TermSchema{}, util.ErrConflict
+			} else if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+				return TermSchema{}, util.ErrMalformed
+			}
+		}
+
+		t.log.Error("un-handled create term query error", logger.Err(err))
+		return TermSchema{}, util.ErrInternal
+	}
+
+	t.log.Info(fmt.Sprintf("term %s created", created.Code))
+	return created, nil
+}
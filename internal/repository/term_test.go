@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/oapi-codegen/nullable"
+)
+
+func TestCreateSyllabusTerm(t *testing.T) {
+	db := testPostgresDb(t)
+	repo := NewPgSyllabusRepository(db, logger.NewTextLogger())
+	userId := createTestUser(t, db)
+	courseId := createTestCourse(t, db)
+
+	tests := []struct {
+		name     string
+		year     int16
+		semester string
+	}{
+		{name: "spring", year: 2020, semester: "Spring"},
+		{name: "summer", year: 2020, semester: "Summer"},
+		{name: "spring and summer", year: 2020, semester: "Spring/Summer"},
+		{name: "past fall", year: 2020, semester: "Fall"},
+		{name: "current winter", year: int16(time.Now().Year()), semester: "Winter"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			syllabusId, err := repo.CreateSyllabus(context.Background(), InsertSyllabus{
+				UserId:      userId,
+				CourseId:    courseId,
+				File:        "syllabus.pdf",
+				FileSize:    1,
+				ContentType: "application/pdf",
+				Checksum:    test.name,
+				Year:        test.year,
+				Semester:    test.semester,
+			})
+			if err != nil {
+				t.Fatalf("CreateSyllabus() error = %v", err)
+			}
+
+			var year int16
+			var semester string
+			err = db.Pool.QueryRow(context.Background(),
+				"select t.year, t.semester from syllabi s inner join terms t on t.id = s.term_id where s.id = $1", syllabusId).Scan(&year, &semester)
+			if err != nil {
+				t.Fatalf("failed to read the syllabus term: %v", err)
+			}
+			if year != test.year || semester != test.semester {
+				t.Errorf("term = %s %d, want %s %d", semester, year, test.semester, test.year)
+			}
+		})
+	}
+}
+
+func TestUserCourseTerm(t *testing.T) {
+	db := testPostgresDb(t)
+	repo := NewPgUserRepository(db, logger.NewTextLogger())
+	userId := createTestUser(t, db)
+	courseId := createTestCourse(t, db)
+
+	// Courses are taken in the latest term of their semester which started before they were added
+	wantTerm := func(semester string) sql.NullString {
+		var termId sql.NullString
+		err := db.Pool.QueryRow(context.Background(),
+			"select id from terms where institution = 'Toronto Metropolitan University' and semester = $1 and date_start <= now() order by date_start desc limit 1",
+			semester).Scan(&termId)
+		if err != nil {
+			t.Fatalf("failed to read the %s term: %v", semester, err)
+		}
+		return termId
+	}
+	userCourseTerm := func() sql.NullString {
+		var termId sql.NullString
+		err := db.Pool.QueryRow(context.Background(),
+			"select term_id from user_courses where user_id = $1 and course_id = $2", userId, courseId).Scan(&termId)
+		if err != nil {
+			t.Fatalf("failed to read the user course term: %v", err)
+		}
+		return termId
+	}
+
+	fall := "Fall"
+	if err := repo.AddUserCourse(context.Background(), userId, InsertUserCourse{CourseId: courseId, SemesterTaken: &fall}); err != nil {
+		t.Fatalf("AddUserCourse() error = %v", err)
+	}
+	if got, want := userCourseTerm(), wantTerm("Fall"); got != want {
+		t.Errorf("term after adding = %v, want %v", got, want)
+	}
+
+	err := repo.UpdateUserCourse(context.Background(), userId, courseId, UpdateUserCourse{SemesterTaken: nullable.NewNullableWithValue("Winter")})
+	if err != nil {
+		t.Fatalf("UpdateUserCourse() error = %v", err)
+	}
+	if got, want := userCourseTerm(), wantTerm("Winter"); got != want {
+		t.Errorf("term after changing the semester = %v, want %v", got, want)
+	}
+
+	err = repo.UpdateUserCourse(context.Background(), userId, courseId, UpdateUserCourse{SemesterTaken: nullable.NewNullNullable[string]()})
+	if err != nil {
+		t.Fatalf("UpdateUserCourse() error = %v", err)
+	}
+	if got := userCourseTerm(); got.Valid {
+		t.Errorf("term after clearing the semester = %v, want null", got)
+	}
+}
@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JackieLi565/syllabye/internal/service/database"
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SyllabusUploadLifetime is how long a resumable upload can be resumed after it is created.
+const SyllabusUploadLifetime = 24 * time.Hour
+
+// activeUploadCond matches resumable uploads which are neither completed nor expired.
+// Its placeholder takes [uploadLifetimeSecs] so the lifetime is only defined by [SyllabusUploadLifetime].
+const activeUploadCond = "date_completed is null and date_added > now() - make_interval(secs => $%d)"
+
+var uploadLifetimeSecs = SyllabusUploadLifetime.Seconds()
+
+// SyllabusUploadSchema is a resumable upload of a syllabus revision's file.
+type SyllabusUploadSchema struct {
+	Id          string
+	UserId      string
+	SyllabusId  string
+	Revision    int16
+	MultipartId string
+	Length      int64
+	Offset      int64
+	PartCount   int32
+	// PendingPart holds received bytes which are too few to upload as a multipart part.
+	PendingPart   []byte
+	DateAdded     time.Time
+	DateModified  time.Time
+	DateCompleted sql.NullTime
+	IsExpired     bool // Unfinished and past its lifetime
+}
+
+// ObjectKey returns the storage key the upload is written to.
+func (u SyllabusUploadSchema) ObjectKey() string {
+	return SyllabusObjectKey(u.SyllabusId, u.Revision)
+}
+
+func (u SyllabusUploadSchema) DateExpires() time.Time {
+	return u.DateAdded.Add(SyllabusUploadLifetime)
+}
+
+type InsertSyllabusUpload struct {
+	SyllabusId  string
+	Revision    int16
+	MultipartId string
+	Length      int64
+}
+
+// UpdateSyllabusUpload is the progress of an upload after receiving a chunk.
+type UpdateSyllabusUpload struct {
+	Offset      int64
+	PartCount   int32
+	PendingPart []byte
+}
+
+type UploadRepository interface {
+	// CreateSyllabusUpload starts a resumable upload, replacing expired uploads of the same revision.
+	// Returns [util.ErrConflict] if the revision already has an active upload.
+	CreateSyllabusUpload(ctx context.Context, userId string, entity InsertSyllabusUpload) (SyllabusUploadSchema, error)
+	GetSyllabusUpload(ctx context.Context, userId string, uploadId string) (SyllabusUploadSchema, error)
+	// AdvanceSyllabusUpload records received chunks, returning [util.ErrConflict] if the upload is no longer at the expected offset.
+	AdvanceSyllabusUpload(ctx context.Context, uploadId string, fromOffset int64, entity UpdateSyllabusUpload) error
+	CompleteSyllabusUpload(ctx context.Context, uploadId string) error
+	DeleteSyllabusUpload(ctx context.Context, userId string, uploadId string) error
+	// HasActiveSyllabusUpload reports whether a revision is still being uploaded through a resumable upload.
+	HasActiveSyllabusUpload(ctx context.Context, syllabusId string, revision int16) (bool, error)
+	// HasActiveSyllabusBatchUpload reports whether any syllabus of a batch is still being uploaded through a resumable upload.
+	HasActiveSyllabusBatchUpload(ctx context.Context, batchId string) (bool, error)
+}
+
+type pgUploadRepository struct {
+	db  *database.PostgresDb
+	log logger.Logger
+}
+
+func NewPgUploadRepository(db *database.PostgresDb, log logger.Logger) *pgUploadRepository {
+	return &pgUploadRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// syllabusUploadColumns selects a [SyllabusUploadSchema], its expired column takes [uploadLifetimeSecs].
+const syllabusUploadColumns = "id, user_id, syllabus_id, revision, multipart_id, upload_length, upload_offset, part_count, pending_part, " +
+	"date_added, date_modified, date_completed, date_completed is null and not (" + activeUploadCond + ")"
+
+func scanSyllabusUpload(row pgx.Row, upload *SyllabusUploadSchema) error {
+	return row.Scan(
+		&upload.Id,
+		&upload.UserId,
+		&upload.SyllabusId,
+		&upload.Revision,
+		&upload.MultipartId,
+		&upload.Length,
+		&upload.Offset,
+		&upload.PartCount,
+		&upload.PendingPart,
+		&upload.DateAdded,
+		&upload.DateModified,
+		&upload.DateCompleted,
+		&upload.IsExpired,
+	)
+}
+
+func (u *pgUploadRepository) CreateSyllabusUpload(ctx context.Context, userId string, entity InsertSyllabusUpload) (SyllabusUploadSchema, error) {
+	deleteResult, err := u.deleteExpiredUploadsQuery(entity.SyllabusId, entity.Revision)
+	if err != nil {
+		return SyllabusUploadSchema{}, err
+	}
+	insertResult, err := u.createSyllabusUploadQuery(userId, entity)
+	if err != nil {
+		return SyllabusUploadSchema{}, err
+	}
+
+	tx, err := u.db.Pool.Begin(ctx)
+	if err != nil {
+		u.log.Error("failed to begin transaction", logger.Err(err))
+		return SyllabusUploadSchema{}, util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	// Abandoned multipart uploads are aborted by the bucket lifecycle rules
+	if _, err := tx.Exec(ctx, deleteResult.Query, deleteResult.Args...); err != nil {
+		u.log.Error("un-handled delete expired syllabus uploads query error", logger.Err(err))
+		return SyllabusUploadSchema{}, util.ErrInternal
+	}
+
+	upload := SyllabusUploadSchema{}
+	err = scanSyllabusUpload(tx.QueryRow(ctx, insertResult.Query, insertResult.Args...), &upload)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == database.PgConflictErrCode {
+			u.log.Info(fmt.Sprintf("syllabus %s revision %d already has an active upload", entity.SyllabusId, entity.Revision))
+			return SyllabusUploadSchema{}, util.ErrConflict
+		} else if errors.As(err, &pgErr) && pgErr.Code == database.PgFKeyViolationErrCode {
+			return SyllabusUploadSchema{}, util.ErrNotFound
+		}
+
+		u.log.Error("un-handled create syllabus upload query error", logger.Err(err))
+		return SyllabusUploadSchema{}, util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		u.log.Error("failed to commit transaction", logger.Err(err))
+		return SyllabusUploadSchema{}, util.ErrInternal
+	}
+
+	u.log.Info(fmt.Sprintf("syllabus upload %s created for %s", upload.Id, upload.ObjectKey()))
+	return upload, nil
+}
+
+func (u *pgUploadRepository) deleteExpiredUploadsQuery(syllabusId string, revision int16) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("delete from syllabus_uploads")
+	qb.Concat("where syllabus_id = $%d and revision = $%d", syllabusUuid, revision)
+	qb.Concat("and date_completed is null and not ("+activeUploadCond+")", uploadLifetimeSecs)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) createSyllabusUploadQuery(userId string, entity InsertSyllabusUpload) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	syllabusUuid, err := database.ParsePgUuid(entity.SyllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into syllabus_uploads (user_id, syllabus_id, revision, multipart_id, upload_length)")
+	qb.Concat("values ($%d, $%d, $%d, $%d, $%d)", userUuid, syllabusUuid, entity.Revision, entity.MultipartId, entity.Length)
+	qb.Concat("returning "+syllabusUploadColumns, uploadLifetimeSecs)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) GetSyllabusUpload(ctx context.Context, userId string, uploadId string) (SyllabusUploadSchema, error) {
+	result, err := u.getSyllabusUploadQuery(userId, uploadId)
+	if err != nil {
+		return SyllabusUploadSchema{}, err
+	}
+
+	upload := SyllabusUploadSchema{}
+	err = scanSyllabusUpload(u.db.Pool.QueryRow(ctx, result.Query, result.Args...), &upload)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SyllabusUploadSchema{}, util.ErrNotFound
+		}
+
+		u.log.Error("un-handled get syllabus upload query error", logger.Err(err))
+		return SyllabusUploadSchema{}, util.ErrInternal
+	}
+
+	return upload, nil
+}
+
+func (u *pgUploadRepository) getSyllabusUploadQuery(userId string, uploadId string) (util.SqlBuilderResult, error) {
+	uploadUuid, err := database.ParsePgUuid(uploadId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder()
+	qb.Concat("select "+syllabusUploadColumns+" from syllabus_uploads", uploadLifetimeSecs)
+	qb.Concat("where id = $%d and user_id = $%d", uploadUuid, userUuid)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) AdvanceSyllabusUpload(ctx context.Context, uploadId string, fromOffset int64, entity UpdateSyllabusUpload) error {
+	result, err := u.advanceSyllabusUploadQuery(uploadId, fromOffset, entity)
+	if err != nil {
+		return err
+	}
+
+	tag, err := u.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		u.log.Error("un-handled advance syllabus upload query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		u.log.Info(fmt.Sprintf("syllabus upload %s moved from offset %d", uploadId, fromOffset))
+		return util.ErrConflict
+	}
+
+	return nil
+}
+
+func (u *pgUploadRepository) advanceSyllabusUploadQuery(uploadId string, fromOffset int64, entity UpdateSyllabusUpload) (util.SqlBuilderResult, error) {
+	uploadUuid, err := database.ParsePgUuid(uploadId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_uploads")
+	qb.Concat("set upload_offset = $%d, part_count = $%d, pending_part = $%d, date_modified = now()", entity.Offset, entity.PartCount, entity.PendingPart)
+	qb.Concat("where id = $%d and upload_offset = $%d and date_completed is null", uploadUuid, fromOffset)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) CompleteSyllabusUpload(ctx context.Context, uploadId string) error {
+	result, err := u.completeSyllabusUploadQuery(uploadId)
+	if err != nil {
+		return err
+	}
+
+	tag, err := u.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		u.log.Error("un-handled complete syllabus upload query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	u.log.Info(fmt.Sprintf("syllabus upload %s completed", uploadId))
+	return nil
+}
+
+func (u *pgUploadRepository) completeSyllabusUploadQuery(uploadId string) (util.SqlBuilderResult, error) {
+	uploadUuid, err := database.ParsePgUuid(uploadId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update syllabus_uploads")
+	qb.Concat("set pending_part = '', date_modified = now(), date_completed = now()")
+	qb.Concat("where id = $%d and upload_offset = upload_length and date_completed is null", uploadUuid)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) DeleteSyllabusUpload(ctx context.Context, userId string, uploadId string) error {
+	result, err := u.deleteSyllabusUploadQuery(userId, uploadId)
+	if err != nil {
+		return err
+	}
+
+	tag, err := u.db.Pool.Exec(ctx, result.Query, result.Args...)
+	if err != nil {
+		u.log.Error("un-handled delete syllabus upload query error", logger.Err(err))
+		return util.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return util.ErrNotFound
+	}
+
+	u.log.Info(fmt.Sprintf("syllabus upload %s terminated", uploadId))
+	return nil
+}
+
+func (u *pgUploadRepository) deleteSyllabusUploadQuery(userId string, uploadId string) (util.SqlBuilderResult, error) {
+	uploadUuid, err := database.ParsePgUuid(uploadId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("delete from syllabus_uploads")
+	qb.Concat("where id = $%d and user_id = $%d and date_completed is null", uploadUuid, userUuid)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) HasActiveSyllabusUpload(ctx context.Context, syllabusId string, revision int16) (bool, error) {
+	result, err := u.hasActiveSyllabusUploadQuery(syllabusId, revision)
+	if err != nil {
+		return false, err
+	}
+
+	var active bool
+	if err := u.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&active); err != nil {
+		u.log.Error("un-handled has active syllabus upload query error", logger.Err(err))
+		return false, util.ErrInternal
+	}
+
+	return active, nil
+}
+
+func (u *pgUploadRepository) hasActiveSyllabusUploadQuery(syllabusId string, revision int16) (util.SqlBuilderResult, error) {
+	syllabusUuid, err := database.ParsePgUuid(syllabusId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select exists (select 1 from syllabus_uploads")
+	qb.Concat("where syllabus_id = $%d and revision = $%d and "+activeUploadCond+")", syllabusUuid, revision, uploadLifetimeSecs)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUploadRepository) HasActiveSyllabusBatchUpload(ctx context.Context, batchId string) (bool, error) {
+	result, err := u.hasActiveSyllabusBatchUploadQuery(batchId)
+	if err != nil {
+		return false, err
+	}
+
+	var active bool
+	if err := u.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(&active); err != nil {
+		u.log.Error("un-handled has active syllabus batch upload query error", logger.Err(err))
+		return false, util.ErrInternal
+	}
+
+	return active, nil
+}
+
+func (u *pgUploadRepository) hasActiveSyllabusBatchUploadQuery(batchId string) (util.SqlBuilderResult, error) {
+	batchUuid, err := database.ParsePgUuid(batchId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select exists (select 1 from syllabus_uploads")
+	qb.Concat("where (syllabus_id, revision) in (select id, revision from syllabi where batch_id = $%d and status = $%d)", batchUuid, SyllabusUploading)
+	qb.Concat("and "+activeUploadCond+")", uploadLifetimeSecs)
+
+	return qb.Result(), nil
+}
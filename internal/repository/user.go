@@ -18,29 +18,91 @@ import (
 	"github.com/oapi-codegen/nullable"
 )
 
+// Field visibility values, matching the visibility_type database enum.
+const (
+	VisibilityPublic  = "Public"
+	VisibilityProgram = "Program"
+	VisibilityPrivate = "Private"
+)
+
+// ProfileAudience describes how a viewer relates to the user whose profile is being viewed.
+type ProfileAudience string
+
+const (
+	AudienceSelf     ProfileAudience = "Self"
+	AudienceProgram  ProfileAudience = "Program"
+	AudienceEveryone ProfileAudience = "Everyone"
+)
+
+// CanView reports whether the audience is allowed to see a field with the given visibility.
+func (a ProfileAudience) CanView(visibility string) bool {
+	switch a {
+	case AudienceSelf:
+		return true
+	case AudienceProgram:
+		return visibility == VisibilityPublic || visibility == VisibilityProgram
+	default:
+		return visibility == VisibilityPublic
+	}
+}
+
 type UserSchema struct {
+	Id                    string
+	ProgramId             sql.NullString
+	FullName              string
+	Nickname              sql.NullString
+	CurrentYear           sql.NullInt16
+	Gender                sql.NullString
+	Email                 string
+	Bio                   sql.NullString
+	Picture               sql.NullString
+	AvatarKey             sql.NullString
+	IsActive              bool
+	EmailVisibility       string
+	GenderVisibility      string
+	CurrentYearVisibility string
+	LinksVisibility       string
+	CoursesVisibility     string
+	DateAdded             time.Time
+	DateModified          time.Time
+}
+
+type UpdateUser struct {
+	ProgramId             nullable.Nullable[string]
+	Nickname              nullable.Nullable[string]
+	CurrentYear           nullable.Nullable[int16]
+	Gender                nullable.Nullable[string]
+	Bio                   nullable.Nullable[string]
+	EmailVisibility       nullable.Nullable[string]
+	GenderVisibility      nullable.Nullable[string]
+	CurrentYearVisibility nullable.Nullable[string]
+	LinksVisibility       nullable.Nullable[string]
+	CoursesVisibility     nullable.Nullable[string]
+}
+
+// Link type values, matching the link_type database enum.
+const (
+	LinkGitHub    = "GitHub"
+	LinkLinkedIn  = "LinkedIn"
+	LinkWebsite   = "Website"
+	LinkInstagram = "Instagram"
+)
+
+// MaxUserLinks is the maximum number of links a single user can add to their profile.
+const MaxUserLinks = 5
+
+type UserLinkSchema struct {
 	Id           string
-	ProgramId    sql.NullString
-	FullName     string
-	Nickname     sql.NullString
-	CurrentYear  sql.NullInt16
-	Gender       sql.NullString
-	Email        string
-	Bio          sql.NullString
-	IgHandle     sql.NullString
-	Picture      sql.NullString
-	IsActive     bool
+	UserId       string
+	Type         string
+	Link         string
 	DateAdded    time.Time
 	DateModified time.Time
 }
 
-type UpdateUser struct {
-	ProgramId   nullable.Nullable[string]
-	Nickname    nullable.Nullable[string]
-	CurrentYear nullable.Nullable[int16]
-	Gender      nullable.Nullable[string]
-	Bio         nullable.Nullable[string]
-	IgHandle    nullable.Nullable[string]
+type InsertUserLink struct {
+	Type string
+	Link string
 }
 
 type UserCourseSchema struct {
@@ -48,8 +110,9 @@ type UserCourseSchema struct {
 	CourseId      string
 	Title         string
 	Course        string
-	YearTaken     sql.NullInt16
+	YearTaken     sql.NullInt16 // Year of study the course was taken in
 	SemesterTaken sql.NullString
+	TermId        sql.NullString
 	DateAdded     time.Time
 	DateModified  time.Time
 }
@@ -58,24 +121,38 @@ type InsertUserCourse struct {
 	CourseId      string
 	YearTaken     *int16
 	SemesterTaken *string
+	// TermId sets the semester taken from the term, [CurrentTerm] resolves to the current term.
+	TermId *string
 }
 
 type UpdateUserCourse struct {
 	YearTaken     nullable.Nullable[int16]
 	SemesterTaken nullable.Nullable[string]
+	TermId        nullable.Nullable[string]
 }
 
 type UserRepository interface {
 	GetUser(ctx context.Context, userId string) (UserSchema, error)
+	// GetUserProfile retrieves a user along with the audience the viewer belongs to.
+	GetUserProfile(ctx context.Context, viewerId string, userId string) (UserSchema, ProfileAudience, error)
+	GetUserProfileByNickname(ctx context.Context, viewerId string, nickname string) (UserSchema, ProfileAudience, error)
 	GetUserIdByEmail(ctx context.Context, email string) (string, error)
 	RegisterUser(ctx context.Context, openId openid.StandardClaims) (string, error)
 	UpdateUser(ctx context.Context, userId string, entity UpdateUser) error
+	// SetUserAvatar sets the object key prefix of a user's uploaded avatar, nil removes the avatar.
+	SetUserAvatar(ctx context.Context, userId string, avatarKey *string) error
 	SearchUserNickname(ctx context.Context, nickname string) (bool, error)
 
 	AddUserCourse(ctx context.Context, userId string, entity InsertUserCourse) error
 	DeleteUserCourse(ctx context.Context, userId string, courseId string) error
 	UpdateUserCourse(ctx context.Context, userId string, courseId string, entity UpdateUserCourse) error
 	ListUserCourses(ctx context.Context, userId string, filters CourseFilters, paginate util.Paginate) ([]UserCourseSchema, error)
+
+	ListUserLinks(ctx context.Context, userId string) ([]UserLinkSchema, error)
+	// AddUserLink adds a link to a user's profile, returning [util.ErrForbidden] once [MaxUserLinks] is reached.
+	AddUserLink(ctx context.Context, userId string, entity InsertUserLink) (string, error)
+	UpdateUserLink(ctx context.Context, userId string, linkId string, entity InsertUserLink) error
+	DeleteUserLink(ctx context.Context, userId string, linkId string) error
 }
 
 type pgUserRepository struct {
@@ -100,7 +177,9 @@ func (u *pgUserRepository) GetUser(ctx context.Context, userId string) (UserSche
 	var userSchema UserSchema
 	err = u.db.Pool.QueryRow(ctx, res.Query, res.Args...).Scan(
 		&userSchema.Id, &userSchema.ProgramId, &userSchema.FullName, &userSchema.Nickname, &userSchema.CurrentYear, &userSchema.Gender, &userSchema.Email,
-		&userSchema.Picture, &userSchema.IsActive, &userSchema.DateAdded, &userSchema.DateModified, &userSchema.Bio, &userSchema.IgHandle,
+		&userSchema.Picture, &userSchema.IsActive, &userSchema.DateAdded, &userSchema.DateModified, &userSchema.Bio,
+		&userSchema.EmailVisibility, &userSchema.GenderVisibility, &userSchema.CurrentYearVisibility, &userSchema.LinksVisibility,
+		&userSchema.CoursesVisibility, &userSchema.AvatarKey,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -122,7 +201,8 @@ func (u *pgUserRepository) getUserQuery(userId string) (util.SqlBuilderResult, e
 	}
 
 	qb := util.NewSqlBuilder(
-		"select id, program_id, full_name, nickname, current_year, gender, email, picture, is_active, date_added, date_modified, bio, ig_handle",
+		"select id, program_id, full_name, nickname, current_year, gender, email, picture, is_active, date_added, date_modified, bio,",
+		"email_visibility, gender_visibility, current_year_visibility, links_visibility, courses_visibility, avatar_key",
 		"from users",
 	)
 	qb = qb.Concat("where id = $%d", userUuid)
@@ -130,6 +210,75 @@ func (u *pgUserRepository) getUserQuery(userId string) (util.SqlBuilderResult, e
 	return qb.Result(), nil
 }
 
+func (u *pgUserRepository) GetUserProfile(ctx context.Context, viewerId string, userId string) (UserSchema, ProfileAudience, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return UserSchema{}, AudienceEveryone, err
+	}
+
+	res, err := u.getUserProfileQuery(viewerId, "u.id = $%d", userUuid)
+	if err != nil {
+		return UserSchema{}, AudienceEveryone, err
+	}
+
+	return u.queryUserProfile(ctx, res)
+}
+
+func (u *pgUserRepository) GetUserProfileByNickname(ctx context.Context, viewerId string, nickname string) (UserSchema, ProfileAudience, error) {
+	res, err := u.getUserProfileQuery(viewerId, "u.nickname = $%d", strings.ToLower(nickname))
+	if err != nil {
+		return UserSchema{}, AudienceEveryone, err
+	}
+
+	return u.queryUserProfile(ctx, res)
+}
+
+func (u *pgUserRepository) queryUserProfile(ctx context.Context, res util.SqlBuilderResult) (UserSchema, ProfileAudience, error) {
+	var userSchema UserSchema
+	var audience string
+	err := u.db.Pool.QueryRow(ctx, res.Query, res.Args...).Scan(
+		&userSchema.Id, &userSchema.ProgramId, &userSchema.FullName, &userSchema.Nickname, &userSchema.CurrentYear, &userSchema.Gender, &userSchema.Email,
+		&userSchema.Picture, &userSchema.IsActive, &userSchema.DateAdded, &userSchema.DateModified, &userSchema.Bio,
+		&userSchema.EmailVisibility, &userSchema.GenderVisibility, &userSchema.CurrentYearVisibility, &userSchema.LinksVisibility,
+		&userSchema.CoursesVisibility, &userSchema.AvatarKey, &audience,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserSchema{}, AudienceEveryone, util.ErrNotFound
+		}
+
+		u.log.Error("get user profile query failed", logger.Err(err))
+		return UserSchema{}, AudienceEveryone, util.ErrInternal
+	}
+
+	return userSchema, ProfileAudience(audience), nil
+}
+
+// getUserProfileQuery selects a single user matching the where clause along with the viewer's audience.
+func (u *pgUserRepository) getUserProfileQuery(viewerId string, where string, value interface{}) (util.SqlBuilderResult, error) {
+	// An empty viewer (internal bearer requests) is treated as a null id and falls into the everyone audience.
+	var viewerUuid pgtype.UUID
+	if viewerId != "" {
+		var err error
+		viewerUuid, err = database.ParsePgUuid(viewerId)
+		if err != nil {
+			return util.SqlBuilderResult{}, err
+		}
+	}
+
+	qb := util.NewSqlBuilder(
+		"select u.id, u.program_id, u.full_name, u.nickname, u.current_year, u.gender, u.email, u.picture, u.is_active, u.date_added, u.date_modified, u.bio,",
+		"u.email_visibility, u.gender_visibility, u.current_year_visibility, u.links_visibility, u.courses_visibility, u.avatar_key,",
+	)
+	qb.Concat("case when u.id = $%d then 'Self'", viewerUuid)
+	qb.Concat("when u.program_id = (select v.program_id from users v where v.id = $%d) then 'Program'", viewerUuid)
+	qb.Concat("else 'Everyone' end as audience")
+	qb.Concat("from users u")
+	qb.Concat("where "+where, value)
+
+	return qb.Result(), nil
+}
+
 func (u *pgUserRepository) GetUserIdByEmail(ctx context.Context, email string) (string, error) {
 	res := u.getUserIdByEmailQuery(email)
 
@@ -170,7 +319,7 @@ func (u *pgUserRepository) UpdateUser(ctx context.Context, userId string, entity
 				u.log.Warn("conflict user update error", logger.Err(err))
 				return util.ErrConflict
 			}
-			if pgErr.Code == database.PgCheckErrCode {
+			if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
 				return util.ErrMalformed
 			}
 		}
@@ -243,12 +392,28 @@ func (u *pgUserRepository) updateUserQuery(userId string, entity UpdateUser) (ut
 			qb.Concat(",bio = $%d", bio)
 		}
 	}
-	if entity.IgHandle.IsSpecified() {
-		ig, err := entity.IgHandle.Get()
+
+	// Visibility columns are not nullable, a null value resets the field to its default visibility.
+	visibilities := []struct {
+		column string
+		value  nullable.Nullable[string]
+	}{
+		{"email_visibility", entity.EmailVisibility},
+		{"gender_visibility", entity.GenderVisibility},
+		{"current_year_visibility", entity.CurrentYearVisibility},
+		{"links_visibility", entity.LinksVisibility},
+		{"courses_visibility", entity.CoursesVisibility},
+	}
+	for _, visibility := range visibilities {
+		if !visibility.value.IsSpecified() {
+			continue
+		}
+
+		value, err := visibility.value.Get()
 		if err != nil {
-			qb.Concat(",ig_handle = null")
+			qb.Concat(fmt.Sprintf(",%s = default", visibility.column))
 		} else {
-			qb.Concat(",ig_handle = $%d", ig)
+			qb.Concat(fmt.Sprintf(",%s = $%%d", visibility.column), value)
 		}
 	}
 
@@ -257,6 +422,40 @@ func (u *pgUserRepository) updateUserQuery(userId string, entity UpdateUser) (ut
 	return qb.Result(), nil
 }
 
+func (u *pgUserRepository) SetUserAvatar(ctx context.Context, userId string, avatarKey *string) error {
+	result, err := u.setUserAvatarQuery(userId, avatarKey)
+	if err != nil {
+		return err
+	}
+
+	err = u.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(new(interface{}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		u.log.Error("un-handled set user avatar query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	u.log.Info(fmt.Sprintf("user %s updated avatar", userId))
+	return nil
+}
+
+func (u *pgUserRepository) setUserAvatarQuery(userId string, avatarKey *string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update users")
+	qb.Concat("set avatar_key = $%d", avatarKey)
+	qb.Concat("where id = $%d", userUuid)
+	qb.Concat("returning id")
+
+	return qb.Result(), nil
+}
+
 func (u *pgUserRepository) RegisterUser(ctx context.Context, openId openid.StandardClaims) (string, error) {
 	var userId string
 
@@ -321,8 +520,13 @@ func (u *pgUserRepository) addUserCourseQuery(userId string, entity InsertUserCo
 		return util.SqlBuilderResult{}, err
 	}
 
-	qb := util.NewSqlBuilder("insert into user_courses (user_id, course_id, year_taken, semester_taken)")
-	qb.Concat("values ($%d, $%d, $%d, $%d)", userId, courseUuid, entity.YearTaken, entity.SemesterTaken)
+	qb := util.NewSqlBuilder("insert into user_courses (user_id, course_id, year_taken, semester_taken, term_id)")
+	qb.Concat("values ($%d, $%d, $%d, $%d,", userId, courseUuid, entity.YearTaken, entity.SemesterTaken)
+	if entity.TermId != nil && *entity.TermId == CurrentTerm {
+		qb.Concat(currentTermIdQuery + ")")
+	} else {
+		qb.Concat("$%d::uuid)", entity.TermId)
+	}
 
 	return qb.Result(), nil
 }
@@ -397,12 +601,38 @@ func (u *pgUserRepository) updateUserCourseQuery(userId string, courseId string,
 	qb := util.NewSqlBuilder("update user_courses")
 	qb.Concat("set date_modified = $%d", time.Now())
 
-	if entity.SemesterTaken != nil {
-		qb.Concat(",semester_taken = $%d", entity.SemesterTaken)
+	// Null values clear the column, the term follows the semester taken unless it is also given
+	if entity.SemesterTaken.IsSpecified() {
+		semesterTaken, err := entity.SemesterTaken.Get()
+		if err != nil {
+			qb.Concat(",semester_taken = null")
+		} else {
+			qb.Concat(",semester_taken = $%d", semesterTaken)
+		}
 	}
 
-	if entity.YearTaken != nil {
-		qb.Concat(",year_taken = $%d", entity.YearTaken)
+	if entity.YearTaken.IsSpecified() {
+		yearTaken, err := entity.YearTaken.Get()
+		if err != nil {
+			qb.Concat(",year_taken = null")
+		} else {
+			qb.Concat(",year_taken = $%d", yearTaken)
+		}
+	}
+
+	if entity.TermId.IsSpecified() {
+		termId, err := entity.TermId.Get()
+		if err != nil {
+			qb.Concat(",term_id = null")
+		} else if termId == CurrentTerm {
+			qb.Concat(",term_id = " + currentTermIdQuery)
+		} else {
+			termUuid, err := database.ParsePgUuid(termId)
+			if err != nil {
+				return util.SqlBuilderResult{}, err
+			}
+			qb.Concat(",term_id = $%d", termUuid)
+		}
 	}
 
 	qb.Concat("where user_id = $%d and course_id = $%d", userId, courseUuid)
@@ -434,6 +664,7 @@ func (u *pgUserRepository) ListUserCourses(ctx context.Context, userId string, f
 			&course.Course,
 			&course.YearTaken,
 			&course.SemesterTaken,
+			&course.TermId,
 		)
 		if err != nil {
 			u.log.Error("scan internal user course error", logger.Err(err))
@@ -447,7 +678,7 @@ func (u *pgUserRepository) ListUserCourses(ctx context.Context, userId string, f
 
 func (u *pgUserRepository) listUserCoursesQuery(userId string, filters CourseFilters, paginate util.Paginate) util.SqlBuilderResult {
 	qb := util.NewSqlBuilder(
-		"select uc.user_id, uc.course_id, c.title, c.course, uc.year_taken, uc.semester_taken",
+		"select uc.user_id, uc.course_id, c.title, c.course, uc.year_taken, uc.semester_taken, uc.term_id",
 		"from user_courses uc",
 		"inner join courses c on c.id = uc.course_id",
 	)
@@ -490,3 +721,224 @@ func (u *pgUserRepository) searchNicknameQuery(nickname string) util.SqlBuilderR
 
 	return qb.Result()
 }
+
+func (u *pgUserRepository) ListUserLinks(ctx context.Context, userId string) ([]UserLinkSchema, error) {
+	result, err := u.listUserLinksQuery(userId)
+	if err != nil {
+		return []UserLinkSchema{}, err
+	}
+
+	rows, err := u.db.Pool.Query(ctx, result.Query, result.Args...)
+	if err != nil {
+		u.log.Error("un-handled list user links query error", logger.Err(err))
+		return []UserLinkSchema{}, util.ErrInternal
+	}
+
+	links := []UserLinkSchema{}
+	for rows.Next() {
+		link := UserLinkSchema{}
+		err := rows.Scan(&link.Id, &link.UserId, &link.Type, &link.Link, &link.DateAdded, &link.DateModified)
+		if err != nil {
+			u.log.Error("scan user link error", logger.Err(err))
+			return []UserLinkSchema{}, util.ErrInternal
+		}
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+func (u *pgUserRepository) listUserLinksQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select id, user_id, type, link, date_added, date_modified from user_links")
+	qb.Concat("where user_id = $%d", userUuid)
+	qb.Concat("order by date_added")
+
+	return qb.Result(), nil
+}
+
+func (u *pgUserRepository) AddUserLink(ctx context.Context, userId string, entity InsertUserLink) (string, error) {
+	lockResult, err := u.lockUserQuery(userId)
+	if err != nil {
+		return "", err
+	}
+	countResult, _ := u.countUserLinksQuery(userId)
+	insertResult, _ := u.addUserLinkQuery(userId, entity)
+
+	tx, err := u.db.Pool.Begin(ctx)
+	if err != nil {
+		u.log.Error("failed to begin transaction", logger.Err(err))
+		return "", util.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the user row so concurrent inserts cannot exceed the link limit
+	err = tx.QueryRow(ctx, lockResult.Query, lockResult.Args...).Scan(new(interface{}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", util.ErrNotFound
+		}
+
+		u.log.Error("un-handled lock user query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, countResult.Query, countResult.Args...).Scan(&count); err != nil {
+		u.log.Error("un-handled count user links query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+	if count >= MaxUserLinks {
+		u.log.Info(fmt.Sprintf("user %s reached the link limit", userId))
+		return "", util.ErrForbidden
+	}
+
+	var linkId string
+	err = tx.QueryRow(ctx, insertResult.Query, insertResult.Args...).Scan(&linkId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+				return "", util.ErrMalformed
+			} else if pgErr.Code == database.PgConflictErrCode {
+				return "", util.ErrConflict
+			}
+		}
+
+		u.log.Error("un-handled add user link query error", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		u.log.Error("failed to commit transaction", logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	u.log.Info(fmt.Sprintf("user %s added link %s", userId, linkId))
+	return linkId, nil
+}
+
+func (u *pgUserRepository) lockUserQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select id from users")
+	qb.Concat("where id = $%d", userUuid)
+	qb.Concat("for update")
+
+	return qb.Result(), nil
+}
+
+func (u *pgUserRepository) countUserLinksQuery(userId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("select count(*) from user_links")
+	qb.Concat("where user_id = $%d", userUuid)
+
+	return qb.Result(), nil
+}
+
+func (u *pgUserRepository) addUserLinkQuery(userId string, entity InsertUserLink) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("insert into user_links (user_id, type, link)")
+	qb.Concat("values ($%d, $%d, $%d)", userUuid, entity.Type, entity.Link)
+	qb.Concat("returning id")
+
+	return qb.Result(), nil
+}
+
+func (u *pgUserRepository) UpdateUserLink(ctx context.Context, userId string, linkId string, entity InsertUserLink) error {
+	result, err := u.updateUserLinkQuery(userId, linkId, entity)
+	if err != nil {
+		return err
+	}
+
+	err = u.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(new(interface{}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == database.PgCheckErrCode || pgErr.Code == database.PgInvalidTextRepErrCode {
+				return util.ErrMalformed
+			} else if pgErr.Code == database.PgConflictErrCode {
+				return util.ErrConflict
+			}
+		}
+
+		u.log.Error("un-handled update user link query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (u *pgUserRepository) updateUserLinkQuery(userId string, linkId string, entity InsertUserLink) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	linkUuid, err := database.ParsePgUuid(linkId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("update user_links")
+	qb.Concat("set type = $%d, link = $%d", entity.Type, entity.Link)
+	qb.Concat("where id = $%d and user_id = $%d", linkUuid, userUuid)
+	qb.Concat("returning id")
+
+	return qb.Result(), nil
+}
+
+func (u *pgUserRepository) DeleteUserLink(ctx context.Context, userId string, linkId string) error {
+	result, err := u.deleteUserLinkQuery(userId, linkId)
+	if err != nil {
+		return err
+	}
+
+	err = u.db.Pool.QueryRow(ctx, result.Query, result.Args...).Scan(new(interface{}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return util.ErrNotFound
+		}
+
+		u.log.Error("un-handled delete user link query error", logger.Err(err))
+		return util.ErrInternal
+	}
+
+	u.log.Info(fmt.Sprintf("user %s removed link %s", userId, linkId))
+	return nil
+}
+
+func (u *pgUserRepository) deleteUserLinkQuery(userId string, linkId string) (util.SqlBuilderResult, error) {
+	userUuid, err := database.ParsePgUuid(userId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+	linkUuid, err := database.ParsePgUuid(linkId)
+	if err != nil {
+		return util.SqlBuilderResult{}, err
+	}
+
+	qb := util.NewSqlBuilder("delete from user_links")
+	qb.Concat("where id = $%d and user_id = $%d", linkUuid, userUuid)
+	qb.Concat("returning id")
+
+	return qb.Result(), nil
+}
@@ -0,0 +1,33 @@
+package bucket
+
+import "strings"
+
+// Location is where a client's objects are stored, a bucket and an optional key prefix.
+// Several clients can share a bucket by storing their objects under different prefixes.
+type Location struct {
+	Bucket string
+	Prefix string
+}
+
+// ParseLocation parses a location written as "bucket" or "bucket/prefix".
+func ParseLocation(value string) Location {
+	bucket, prefix, _ := strings.Cut(strings.Trim(value, "/"), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return Location{
+		Bucket: bucket,
+		Prefix: prefix,
+	}
+}
+
+// Key returns the bucket key of an object.
+func (l Location) Key(objectKey string) string {
+	return l.Prefix + objectKey
+}
+
+// ObjectKey returns the object key of a bucket key, the inverse of Key.
+func (l Location) ObjectKey(key string) string {
+	return strings.TrimPrefix(key, l.Prefix)
+}
@@ -0,0 +1,132 @@
+package bucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// MinPartSize is the smallest part accepted by a multipart upload, except for its last part.
+const MinPartSize = 5 << 20
+
+// MultipartClient writes objects in parts, for uploads received over several requests.
+type MultipartClient interface {
+	// CreateMultipartUpload starts a multipart upload and returns its ID.
+	CreateMultipartUpload(ctx context.Context, objectKey string, contentType string) (string, error)
+	// UploadPart writes a part, parts are numbered from 1 and re-uploading a part number replaces it.
+	UploadPart(ctx context.Context, objectKey string, uploadId string, partNumber int32, body []byte) error
+	// CompleteMultipartUpload assembles the first partCount parts into the object.
+	CompleteMultipartUpload(ctx context.Context, objectKey string, uploadId string, partCount int32) error
+	AbortMultipartUpload(ctx context.Context, objectKey string, uploadId string) error
+}
+
+func (o *s3Object) CreateMultipartUpload(ctx context.Context, objectKey string, contentType string) (string, error) {
+	res, err := o.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(o.location.Bucket),
+		Key:         aws.String(o.location.Key(objectKey)),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		o.log.Error(fmt.Sprintf("failed to create multipart upload %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return "", util.ErrInternal
+	}
+
+	return aws.ToString(res.UploadId), nil
+}
+
+func (o *s3Object) UploadPart(ctx context.Context, objectKey string, uploadId string, partNumber int32, body []byte) error {
+	_, err := o.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:        aws.String(o.location.Bucket),
+		Key:           aws.String(o.location.Key(objectKey)),
+		UploadId:      aws.String(uploadId),
+		PartNumber:    aws.Int32(partNumber),
+		ContentLength: aws.Int64(int64(len(body))),
+		Body:          bytes.NewReader(body),
+	})
+	if err != nil {
+		var noUpload *types.NoSuchUpload
+		if errors.As(err, &noUpload) {
+			return util.ErrNotFound
+		}
+
+		o.log.Error(fmt.Sprintf("failed to upload part %d of %s:%s", partNumber, o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (o *s3Object) CompleteMultipartUpload(ctx context.Context, objectKey string, uploadId string, partCount int32) error {
+	// Part ETags are listed rather than stored since retried parts replace earlier ones
+	parts := make([]types.CompletedPart, 0, partCount)
+	paginator := s3.NewListPartsPaginator(o.client, &s3.ListPartsInput{
+		Bucket:   aws.String(o.location.Bucket),
+		Key:      aws.String(o.location.Key(objectKey)),
+		UploadId: aws.String(uploadId),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			var noUpload *types.NoSuchUpload
+			if errors.As(err, &noUpload) {
+				return util.ErrNotFound
+			}
+
+			o.log.Error(fmt.Sprintf("failed to list parts of %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+			return util.ErrInternal
+		}
+
+		for _, part := range page.Parts {
+			if aws.ToInt32(part.PartNumber) <= partCount {
+				parts = append(parts, types.CompletedPart{
+					ETag:       part.ETag,
+					PartNumber: part.PartNumber,
+				})
+			}
+		}
+	}
+
+	if len(parts) != int(partCount) {
+		o.log.Error(fmt.Sprintf("multipart upload %s:%s has %d of %d parts", o.location.Bucket, o.location.Key(objectKey), len(parts), partCount))
+		return util.ErrInternal
+	}
+
+	_, err := o.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(o.location.Bucket),
+		Key:             aws.String(o.location.Key(objectKey)),
+		UploadId:        aws.String(uploadId),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		o.log.Error(fmt.Sprintf("failed to complete multipart upload %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (o *s3Object) AbortMultipartUpload(ctx context.Context, objectKey string, uploadId string) error {
+	_, err := o.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(o.location.Bucket),
+		Key:      aws.String(o.location.Key(objectKey)),
+		UploadId: aws.String(uploadId),
+	})
+	if err != nil {
+		var noUpload *types.NoSuchUpload
+		if errors.As(err, &noUpload) {
+			return util.ErrNotFound
+		}
+
+		o.log.Error(fmt.Sprintf("failed to abort multipart upload %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
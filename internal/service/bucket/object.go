@@ -0,0 +1,136 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+type ObjectInfo struct {
+	ContentType string
+	Size        int64
+}
+
+// ObjectClient reads and writes objects directly, for server side processing of uploaded files.
+type ObjectClient interface {
+	// GetObject returns the object body which must be closed by the caller, or [util.ErrNotFound] if missing.
+	GetObject(ctx context.Context, objectKey string) (io.ReadCloser, ObjectInfo, error)
+	// HeadObject returns the object metadata without its body, or [util.ErrNotFound] if missing.
+	HeadObject(ctx context.Context, objectKey string) (ObjectInfo, error)
+	PutObject(ctx context.Context, objectKey string, contentType string, body io.Reader) error
+	DeleteObject(ctx context.Context, objectKey string) error
+	// ListObjectKeys returns the keys of the objects starting with the prefix, in ascending order.
+	ListObjectKeys(ctx context.Context, prefix string) ([]string, error)
+}
+
+type s3Object struct {
+	client   *s3.Client
+	log      logger.Logger
+	location Location
+}
+
+func NewS3Object(log logger.Logger, s3Client *s3.Client, location string) *s3Object {
+	return &s3Object{
+		log:      log,
+		client:   s3Client,
+		location: ParseLocation(location),
+	}
+}
+
+func (o *s3Object) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, ObjectInfo, error) {
+	res, err := o.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(o.location.Bucket),
+		Key:    aws.String(o.location.Key(objectKey)),
+	})
+	if err != nil {
+		var noKey *types.NoSuchKey
+		if errors.As(err, &noKey) {
+			return nil, ObjectInfo{}, util.ErrNotFound
+		}
+
+		o.log.Error(fmt.Sprintf("failed to get object %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return nil, ObjectInfo{}, util.ErrInternal
+	}
+
+	return res.Body, ObjectInfo{
+		ContentType: aws.ToString(res.ContentType),
+		Size:        aws.ToInt64(res.ContentLength),
+	}, nil
+}
+
+func (o *s3Object) HeadObject(ctx context.Context, objectKey string) (ObjectInfo, error) {
+	res, err := o.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(o.location.Bucket),
+		Key:    aws.String(o.location.Key(objectKey)),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return ObjectInfo{}, util.ErrNotFound
+		}
+
+		o.log.Error(fmt.Sprintf("failed to head object %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return ObjectInfo{}, util.ErrInternal
+	}
+
+	return ObjectInfo{
+		ContentType: aws.ToString(res.ContentType),
+		Size:        aws.ToInt64(res.ContentLength),
+	}, nil
+}
+
+func (o *s3Object) PutObject(ctx context.Context, objectKey string, contentType string, body io.Reader) error {
+	_, err := o.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(o.location.Bucket),
+		Key:         aws.String(o.location.Key(objectKey)),
+		ContentType: aws.String(contentType),
+		Body:        body,
+	})
+	if err != nil {
+		o.log.Error(fmt.Sprintf("failed to put object %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (o *s3Object) DeleteObject(ctx context.Context, objectKey string) error {
+	_, err := o.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(o.location.Bucket),
+		Key:    aws.String(o.location.Key(objectKey)),
+	})
+	if err != nil {
+		o.log.Error(fmt.Sprintf("failed to delete object %s:%s", o.location.Bucket, o.location.Key(objectKey)), logger.Err(err))
+		return util.ErrInternal
+	}
+
+	return nil
+}
+
+func (o *s3Object) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	paginator := s3.NewListObjectsV2Paginator(o.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(o.location.Bucket),
+		Prefix: aws.String(o.location.Key(prefix)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			o.log.Error(fmt.Sprintf("failed to list objects %s:%s", o.location.Bucket, o.location.Key(prefix)), logger.Err(err))
+			return []string{}, util.ErrInternal
+		}
+
+		for _, object := range page.Contents {
+			keys = append(keys, o.location.ObjectKey(aws.ToString(object.Key)))
+		}
+	}
+
+	return keys, nil
+}
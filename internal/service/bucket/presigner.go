@@ -3,7 +3,9 @@ package bucket
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/JackieLi565/syllabye/internal/service/logger"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -13,42 +15,83 @@ import (
 
 type PresignerClient interface {
 	GetObject(ctx context.Context, objectKey string, lifetimeSecs int64) (string, error)
+	// GetObjectDownload presigns a GET which saves the object as a file named fileName rather than displaying it.
+	GetObjectDownload(ctx context.Context, objectKey string, fileName string, lifetimeSecs int64) (string, error)
 	PutObject(ctx context.Context, objectKey string, contentType string, checksum string, lifetimeSecs int64) (string, error)
 }
 
 type s3Presigner struct {
 	presignClient *s3.PresignClient
 	log           logger.Logger
-	bucket        string
+	location      Location
 }
 
-func NewS3Presigner(log logger.Logger, s3Client *s3.Client, bucket string) *s3Presigner {
+func NewS3Presigner(log logger.Logger, s3Client *s3.Client, location string) *s3Presigner {
 	return &s3Presigner{
 		log:           log,
 		presignClient: s3.NewPresignClient(s3Client),
-		bucket:        bucket,
+		location:      ParseLocation(location),
 	}
 }
 
 func (p *s3Presigner) GetObject(ctx context.Context, objectKey string, lifetimeSecs int64) (string, error) {
 	request, err := p.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(p.bucket),
-		Key:    aws.String(objectKey),
+		Bucket: aws.String(p.location.Bucket),
+		Key:    aws.String(p.location.Key(objectKey)),
 	}, func(opts *s3.PresignOptions) {
 		opts.Expires = time.Duration(lifetimeSecs * int64(time.Second))
 	})
 	if err != nil {
 		p.log.Error(fmt.Sprintf("Couldn't get a presigned request to get %v:%v. Here's why: %v\n",
-			p.bucket, objectKey, err))
+			p.location.Bucket, p.location.Key(objectKey), err))
 	}
 
 	return request.URL, err
 }
 
+func (p *s3Presigner) GetObjectDownload(ctx context.Context, objectKey string, fileName string, lifetimeSecs int64) (string, error) {
+	request, err := p.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket:                     aws.String(p.location.Bucket),
+		Key:                        aws.String(p.location.Key(objectKey)),
+		ResponseContentDisposition: aws.String(attachmentDisposition(fileName)),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = time.Duration(lifetimeSecs * int64(time.Second))
+	})
+	if err != nil {
+		p.log.Error(fmt.Sprintf("failed to presign download of %s:%s", p.location.Bucket, p.location.Key(objectKey)), logger.Err(err))
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+// attachmentDisposition formats an RFC 6266 attachment header, with an ASCII file name for older clients
+// and the UTF-8 file name for the rest.
+func attachmentDisposition(fileName string) string {
+	var fallback, encoded strings.Builder
+	for _, r := range fileName {
+		switch {
+		case r == '"' || r == '\\' || r < ' ' || r > '~':
+			fallback.WriteByte('_')
+		default:
+			fallback.WriteRune(r)
+		}
+	}
+	for _, c := range []byte(fileName) {
+		if c < 0x80 && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || strings.IndexByte("!#$&+-.^_`|~", c) >= 0) {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, fallback.String(), encoded.String())
+}
+
 func (p *s3Presigner) PutObject(ctx context.Context, objectKey string, contentType string, checksum string, lifetimeSecs int64) (string, error) {
 	request, err := p.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket:            aws.String(p.bucket),
-		Key:               aws.String(objectKey),
+		Bucket:            aws.String(p.location.Bucket),
+		Key:               aws.String(p.location.Key(objectKey)),
 		ContentType:       aws.String(contentType),
 		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
 		ChecksumCRC32:     aws.String(checksum),
@@ -57,7 +100,7 @@ func (p *s3Presigner) PutObject(ctx context.Context, objectKey string, contentTy
 	})
 	if err != nil {
 		p.log.Error(fmt.Sprintf("Couldn't get a presigned request to put %v:%v. Here's why: %v\n",
-			p.bucket, objectKey, err))
+			p.location.Bucket, p.location.Key(objectKey), err))
 	}
 
 	return request.URL, err
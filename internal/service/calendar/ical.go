@@ -0,0 +1,95 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar documents.
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	productId = "-//Syllabye//Syllabye API//EN"
+	// Content lines longer than this many octets must be folded.
+	maxLineLength  = 75
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+)
+
+// Event is an all day event, such as an assessment's due date.
+type Event struct {
+	// Uid identifies the event across exports, so calendar clients update rather than duplicate it.
+	Uid          string
+	Summary      string
+	Description  string
+	Date         time.Time
+	DateModified time.Time
+}
+
+// Write encodes the events as an RFC 5545 calendar.
+func Write(w io.Writer, name string, events []Event) error {
+	bw := bufio.NewWriter(w)
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:"+productId)
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	writeLine(bw, "X-WR-CALNAME:"+escapeText(name))
+
+	for _, event := range events {
+		stamp := event.DateModified.UTC().Format(dateTimeLayout)
+
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, "UID:"+event.Uid)
+		writeLine(bw, "DTSTAMP:"+stamp)
+		writeLine(bw, "LAST-MODIFIED:"+stamp)
+		writeLine(bw, "DTSTART;VALUE=DATE:"+event.Date.Format(dateLayout))
+		writeLine(bw, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format(dateLayout))
+		writeLine(bw, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(bw, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		writeLine(bw, "TRANSP:TRANSPARENT")
+		writeLine(bw, "END:VEVENT")
+	}
+
+	writeLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeLine writes a CRLF terminated content line, folding it without splitting multi-byte characters.
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space which counts toward their length
+		limit = maxLineLength - 1
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	";", "\\;",
+	",", "\\,",
+	"\r\n", "\\n",
+	"\n", "\\n",
+	"\r", "",
+)
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/JackieLi565/syllabye/internal/config"
 	"github.com/JackieLi565/syllabye/internal/service/logger"
@@ -18,6 +19,24 @@ type NoReplyEmailer interface {
 	SendWelcomeEmail(ctx context.Context, to string, name string) error
 	SendSubmissionSuccessEmail(ctx context.Context, to string, name string, course string) error
 	SendSubmissionMissingEmail(ctx context.Context, to string, name string, course string) error
+	SendSubmissionRejectedEmail(ctx context.Context, to string, name string, course string, reason string) error
+	// SendSuspensionEmail notifies a user of their suspension, a nil expiry indicates a permanent suspension.
+	SendSuspensionEmail(ctx context.Context, to string, name string, reason string, expires *time.Time) error
+	// SendBatchSummaryEmail notifies a user of the outcome of each syllabus they submitted in a batch.
+	SendBatchSummaryEmail(ctx context.Context, to string, name string, received int, items []BatchSummaryItem) error
+	// SendCommentReplyEmail notifies a user of a reply to their comment on a syllabus.
+	SendCommentReplyEmail(ctx context.Context, to string, name string, course string, comment string) error
+	// SendSyllabusCommentEmail notifies a user of a comment on a syllabus they uploaded.
+	SendSyllabusCommentEmail(ctx context.Context, to string, name string, course string, comment string) error
+	// SendRequestFulfilledEmail notifies a user that a syllabus they requested was uploaded.
+	SendRequestFulfilledEmail(ctx context.Context, to string, name string, course string) error
+	// SendSyllabusRemovedEmail notifies a user that a syllabus they uploaded was removed.
+	SendSyllabusRemovedEmail(ctx context.Context, to string, name string, course string, reason string) error
+}
+
+type BatchSummaryItem struct {
+	Course  string `json:"course"`
+	Outcome string `json:"outcome"`
 }
 
 type sesNoReply struct {
@@ -84,6 +103,123 @@ func (s *sesNoReply) SendSubmissionMissingEmail(ctx context.Context, to string,
 	return s.sendEmail(ctx, to, uploadErrorTemplate, templateData)
 }
 
+func (s *sesNoReply) SendSubmissionRejectedEmail(ctx context.Context, to string, name string, course string, reason string) error {
+	uploadErrorTemplate := os.Getenv(config.AWS_SES_UPLOAD_ERROR_TEMPLATE)
+	if uploadErrorTemplate == "" {
+		s.log.Error("Upload Error template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":   name,
+		"course": course,
+		"reason": reason,
+	}
+
+	return s.sendEmail(ctx, to, uploadErrorTemplate, templateData)
+}
+
+func (s *sesNoReply) SendSuspensionEmail(ctx context.Context, to string, name string, reason string, expires *time.Time) error {
+	suspensionTemplate := os.Getenv(config.AWS_SES_SUSPENSION_TEMPLATE)
+	if suspensionTemplate == "" {
+		s.log.Error("Suspension template name not defined")
+		return util.ErrInternal
+	}
+
+	duration := "permanently"
+	if expires != nil {
+		duration = fmt.Sprintf("until %s", expires.UTC().Format("January 2, 2006 at 15:04 UTC"))
+	}
+
+	templateData := map[string]interface{}{
+		"name":     name,
+		"reason":   reason,
+		"duration": duration,
+	}
+
+	return s.sendEmail(ctx, to, suspensionTemplate, templateData)
+}
+
+func (s *sesNoReply) SendBatchSummaryEmail(ctx context.Context, to string, name string, received int, items []BatchSummaryItem) error {
+	batchSummaryTemplate := os.Getenv(config.AWS_SES_BATCH_SUMMARY_TEMPLATE)
+	if batchSummaryTemplate == "" {
+		s.log.Error("Batch Summary template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":     name,
+		"received": received,
+		"total":    len(items),
+		"items":    items,
+	}
+
+	return s.sendEmail(ctx, to, batchSummaryTemplate, templateData)
+}
+
+func (s *sesNoReply) SendCommentReplyEmail(ctx context.Context, to string, name string, course string, comment string) error {
+	commentReplyTemplate := os.Getenv(config.AWS_SES_COMMENT_REPLY_TEMPLATE)
+	if commentReplyTemplate == "" {
+		s.log.Error("Comment Reply template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":    name,
+		"course":  course,
+		"comment": comment,
+	}
+
+	return s.sendEmail(ctx, to, commentReplyTemplate, templateData)
+}
+
+func (s *sesNoReply) SendSyllabusCommentEmail(ctx context.Context, to string, name string, course string, comment string) error {
+	syllabusCommentTemplate := os.Getenv(config.AWS_SES_SYLLABUS_COMMENT_TEMPLATE)
+	if syllabusCommentTemplate == "" {
+		s.log.Error("Syllabus Comment template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":    name,
+		"course":  course,
+		"comment": comment,
+	}
+
+	return s.sendEmail(ctx, to, syllabusCommentTemplate, templateData)
+}
+
+func (s *sesNoReply) SendRequestFulfilledEmail(ctx context.Context, to string, name string, course string) error {
+	requestFulfilledTemplate := os.Getenv(config.AWS_SES_REQUEST_FULFILLED_TEMPLATE)
+	if requestFulfilledTemplate == "" {
+		s.log.Error("Request Fulfilled template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":   name,
+		"course": course,
+	}
+
+	return s.sendEmail(ctx, to, requestFulfilledTemplate, templateData)
+}
+
+func (s *sesNoReply) SendSyllabusRemovedEmail(ctx context.Context, to string, name string, course string, reason string) error {
+	syllabusRemovedTemplate := os.Getenv(config.AWS_SES_SYLLABUS_REMOVED_TEMPLATE)
+	if syllabusRemovedTemplate == "" {
+		s.log.Error("Syllabus Removed template name not defined")
+		return util.ErrInternal
+	}
+
+	templateData := map[string]interface{}{
+		"name":   name,
+		"course": course,
+		"reason": reason,
+	}
+
+	return s.sendEmail(ctx, to, syllabusRemovedTemplate, templateData)
+}
+
 func (s *sesNoReply) sendEmail(ctx context.Context, to string, template string, templateData map[string]interface{}) error {
 	dat, _ := json.Marshal(templateData)
 
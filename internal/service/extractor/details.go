@@ -0,0 +1,332 @@
+package extractor
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyllabusDetails holds the structured information read from a syllabus' text.
+// It is stored as JSON, so fields should only be added.
+type SyllabusDetails struct {
+	GradingScheme []GradeWeight     `json:"gradingScheme"`
+	Assessments   []Assessment      `json:"assessments"`
+	Textbooks     []Textbook        `json:"textbooks"`
+	Instructor    InstructorContact `json:"instructor"`
+}
+
+type GradeWeight struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Percentage of the final grade
+}
+
+type Assessment struct {
+	Name   string  `json:"name"`
+	Date   string  `json:"date,omitempty"`   // YYYY-MM-DD
+	Weight float64 `json:"weight,omitempty"` // Percentage of the final grade
+}
+
+type Textbook struct {
+	Title    string `json:"title"`
+	Isbn     string `json:"isbn,omitempty"`
+	Required bool   `json:"required"`
+}
+
+type InstructorContact struct {
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Phone  string `json:"phone,omitempty"`
+	Office string `json:"office,omitempty"`
+}
+
+// DateLayout is the format of assessment dates.
+const DateLayout = time.DateOnly
+
+const (
+	maxDetailNameLength = 80
+	maxDetailItems      = 50
+)
+
+var (
+	percentPattern       = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)\s?%`)
+	assessmentPattern    = regexp.MustCompile(`(?i)\b(assignments?|quiz(?:zes)?|mid-?terms?|(?:final )?exams?|examinations?|tests?|projects?|labs?|presentations?|essays?|reports?|participation|tutorials?|proposals?|papers?)\b`)
+	monthDatePattern     = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?\b`)
+	isoDatePattern       = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	isbnPattern          = regexp.MustCompile(`(?i)\bISBN(?:-1[03])?\s*:?\s*((?:97[89][\s-]?)?\d[\d\s-]{7,14}[\dX])\b`)
+	textbookHeading      = regexp.MustCompile(`(?i)^(required|recommended|optional)?\s*(course\s+)?(text\s?books?|texts|readings?|course materials?)\s*:?\s*(.*)$`)
+	instructorPattern    = regexp.MustCompile(`(?i)^(?:course\s+)?(?:instructor|professor|lecturer|taught by)\s*(?:name)?\s*[:\-]?\s*(.+)$`)
+	emailPattern         = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern         = regexp.MustCompile(`\(?\b\d{3}\)?[\s.\-]?\d{3}[\s.\-]?\d{4}\b(?:\s*(?:x|ext\.?)\s*\d{1,5})?`)
+	officePattern        = regexp.MustCompile(`(?i)^office(?:\s+location)?\s*:\s*(.+)$`)
+	weekdaySuffixPattern = regexp.MustCompile(`(?i)\b(mon|tue|tues|wed|thu|thur|thurs|fri|sat|sun)[a-z]*\.?,?\s*$`)
+	dueSuffixPattern     = regexp.MustCompile(`(?i)\b(due|on|by|week \d+)\s*$`)
+	labelTrimCharacter   = " \t-–—:.,;()[]*•·|"
+)
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "sept": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// ParseDetails reads the grading scheme, assessments, textbooks and instructor contact from a syllabus' text.
+// Dates without a year are placed in the given year. The confidence from 0 to 1 estimates how complete the details are.
+func ParseDetails(text string, year int) (SyllabusDetails, float64) {
+	details := SyllabusDetails{
+		GradingScheme: []GradeWeight{},
+		Assessments:   []Assessment{},
+		Textbooks:     []Textbook{},
+	}
+
+	lines := strings.Split(text, "\n")
+	seenWeights := map[string]bool{}
+	seenAssessments := map[string]bool{}
+	seenIsbns := map[string]bool{}
+	textbookSection, textbookRequired := 0, false
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		date, dated := parseDate(line, year)
+
+		// Grading weights are a label with a single percentage, such as "Midterm Exam 25%", dated lines are assessments
+		if percents := percentPattern.FindAllStringSubmatchIndex(line, -1); len(percents) == 1 && !dated {
+			weight, _ := strconv.ParseFloat(line[percents[0][2]:percents[0][3]], 64)
+			name := detailLabel(line[:percents[0][0]] + " " + line[percents[0][1]:])
+			if name != "" && weight > 0 && weight <= 100 && !seenWeights[strings.ToLower(name)] && len(details.GradingScheme) < maxDetailItems {
+				seenWeights[strings.ToLower(name)] = true
+				details.GradingScheme = append(details.GradingScheme, GradeWeight{Name: name, Weight: weight})
+			}
+		}
+
+		if dated {
+			if assessmentPattern.MatchString(line) && len(details.Assessments) < maxDetailItems {
+				name := assessmentName(line)
+				key := strings.ToLower(name) + date
+				if name != "" && !seenAssessments[key] {
+					seenAssessments[key] = true
+					assessment := Assessment{Name: name, Date: date}
+					if percents := percentPattern.FindAllStringSubmatch(line, -1); len(percents) == 1 {
+						assessment.Weight, _ = strconv.ParseFloat(percents[0][1], 64)
+					}
+					details.Assessments = append(details.Assessments, assessment)
+				}
+			}
+		}
+
+		if match := isbnPattern.FindStringSubmatchIndex(line); match != nil && len(details.Textbooks) < maxDetailItems {
+			isbn := strings.NewReplacer(" ", "", "-", "").Replace(line[match[2]:match[3]])
+			title := detailLabel(line[:match[0]])
+			if title == "" && i > 0 {
+				title = detailLabel(lines[i-1])
+			}
+			if title != "" && !seenIsbns[isbn] {
+				seenIsbns[isbn] = true
+				details.Textbooks = append(details.Textbooks, Textbook{Title: title, Isbn: isbn, Required: textbookSection == 0 || textbookRequired})
+			}
+			textbookSection = 0
+		} else if match := textbookHeading.FindStringSubmatch(line); match != nil && len(line) < maxDetailNameLength {
+			textbookRequired = !strings.EqualFold(match[1], "recommended") && !strings.EqualFold(match[1], "optional")
+			if title := detailLabel(match[4]); title != "" {
+				details.Textbooks = appendTextbook(details.Textbooks, Textbook{Title: title, Required: textbookRequired})
+			} else {
+				// The titles follow the heading
+				textbookSection = 2
+			}
+		} else if textbookSection > 0 {
+			textbookSection--
+			if title := detailLabel(line); title != "" && len(details.Textbooks) < maxDetailItems {
+				details.Textbooks = appendTextbook(details.Textbooks, Textbook{Title: title, Required: textbookRequired})
+			}
+		}
+
+		parseInstructorLine(line, &details.Instructor)
+	}
+
+	if details.Instructor.Email == "" {
+		details.Instructor.Email = emailPattern.FindString(text)
+	}
+
+	return details, detailsConfidence(details)
+}
+
+// parseInstructorLine fills contact fields not yet found from a line of the syllabus.
+func parseInstructorLine(line string, instructor *InstructorContact) {
+	if match := instructorPattern.FindStringSubmatch(line); match != nil && instructor.Name == "" {
+		name := match[1]
+		if loc := emailPattern.FindStringIndex(name); loc != nil {
+			if instructor.Email == "" {
+				instructor.Email = name[loc[0]:loc[1]]
+			}
+			name = name[:loc[0]]
+		}
+		if loc := phonePattern.FindStringIndex(name); loc != nil {
+			name = name[:loc[0]]
+		}
+		instructor.Name = detailLabel(name)
+		// Emails near the instructor's name belong to them
+		if instructor.Email == "" {
+			instructor.Email = emailPattern.FindString(line)
+		}
+		return
+	}
+
+	if instructor.Name == "" {
+		return
+	}
+	if instructor.Email == "" {
+		instructor.Email = emailPattern.FindString(line)
+	}
+	if lower := strings.ToLower(line); instructor.Phone == "" && (strings.Contains(lower, "phone") || strings.Contains(lower, "tel")) {
+		instructor.Phone = phonePattern.FindString(line)
+	}
+	if match := officePattern.FindStringSubmatch(line); match != nil && instructor.Office == "" {
+		instructor.Office = detailLabel(match[1])
+	}
+}
+
+func appendTextbook(textbooks []Textbook, textbook Textbook) []Textbook {
+	for _, t := range textbooks {
+		if strings.EqualFold(t.Title, textbook.Title) {
+			return textbooks
+		}
+	}
+
+	return append(textbooks, textbook)
+}
+
+// parseDate finds the first date in a line, formatted with DateLayout.
+func parseDate(line string, year int) (string, bool) {
+	if match := isoDatePattern.FindStringSubmatch(line); match != nil {
+		if date, err := time.Parse(DateLayout, match[0]); err == nil {
+			return date.Format(DateLayout), true
+		}
+	}
+
+	if match := monthDatePattern.FindStringSubmatch(line); match != nil {
+		month := months[strings.ToLower(match[1])]
+		day, _ := strconv.Atoi(match[2])
+		if match[3] != "" {
+			year, _ = strconv.Atoi(match[3])
+		}
+
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		// Reject overflowing days such as Feb 30
+		if date.Day() == day {
+			return date.Format(DateLayout), true
+		}
+	}
+
+	return "", false
+}
+
+// assessmentName is the line without its dates and weights.
+func assessmentName(line string) string {
+	line = isoDatePattern.ReplaceAllString(line, " ")
+	line = monthDatePattern.ReplaceAllString(line, " ")
+	line = percentPattern.ReplaceAllString(line, " ")
+	line = weekdaySuffixPattern.ReplaceAllString(strings.TrimSpace(line), "")
+	line = dueSuffixPattern.ReplaceAllString(strings.TrimSpace(line), "")
+
+	return detailLabel(line)
+}
+
+// detailLabel cleans up a name read from the text, returning an empty string if it is not a plausible name.
+func detailLabel(label string) string {
+	label = strings.Join(strings.Fields(label), " ")
+	label = strings.Trim(label, labelTrimCharacter)
+	if len(label) > maxDetailNameLength || strings.IndexFunc(label, isLetter) < 0 {
+		return ""
+	}
+
+	return label
+}
+
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// detailsConfidence scores each part of the details, weighing the grading scheme highest since it is most often read.
+func detailsConfidence(details SyllabusDetails) float64 {
+	grading := 0.0
+	if len(details.GradingScheme) > 0 {
+		total := 0.0
+		for _, weight := range details.GradingScheme {
+			total += weight.Weight
+		}
+		// A complete scheme adds up to 100%
+		grading = 0.5
+		if math.Abs(total-100) <= 0.5 {
+			grading = 1
+		}
+	}
+
+	assessments := 0.0
+	if len(details.Assessments) > 0 {
+		assessments = 1
+	}
+
+	textbooks := 0.0
+	if len(details.Textbooks) > 0 {
+		textbooks = 1
+	}
+
+	instructor := 0.0
+	if details.Instructor.Name != "" {
+		instructor += 0.5
+	}
+	if details.Instructor.Email != "" {
+		instructor += 0.5
+	}
+
+	confidence := 0.4*grading + 0.3*assessments + 0.2*instructor + 0.1*textbooks
+	return math.Round(confidence*100) / 100
+}
+
+// ValidateDetails checks details edited by a user.
+func ValidateDetails(details SyllabusDetails) error {
+	if len(details.GradingScheme) > maxDetailItems || len(details.Assessments) > maxDetailItems || len(details.Textbooks) > maxDetailItems {
+		return fmt.Errorf("at most %d of each item are allowed", maxDetailItems)
+	}
+
+	for _, weight := range details.GradingScheme {
+		if strings.TrimSpace(weight.Name) == "" || len(weight.Name) > maxDetailNameLength {
+			return fmt.Errorf("grading scheme names must be 1 to %d characters", maxDetailNameLength)
+		}
+		if weight.Weight <= 0 || weight.Weight > 100 {
+			return fmt.Errorf("grading weight %s must be between 0 and 100", weight.Name)
+		}
+	}
+
+	for _, assessment := range details.Assessments {
+		if strings.TrimSpace(assessment.Name) == "" || len(assessment.Name) > maxDetailNameLength {
+			return fmt.Errorf("assessment names must be 1 to %d characters", maxDetailNameLength)
+		}
+		if assessment.Date != "" {
+			if _, err := time.Parse(DateLayout, assessment.Date); err != nil {
+				return fmt.Errorf("assessment %s date must be formatted as YYYY-MM-DD", assessment.Name)
+			}
+		}
+		if assessment.Weight < 0 || assessment.Weight > 100 {
+			return fmt.Errorf("assessment %s weight must be between 0 and 100", assessment.Name)
+		}
+	}
+
+	for _, textbook := range details.Textbooks {
+		if strings.TrimSpace(textbook.Title) == "" || len(textbook.Title) > maxDetailNameLength {
+			return fmt.Errorf("textbook titles must be 1 to %d characters", maxDetailNameLength)
+		}
+	}
+
+	if details.Instructor.Email != "" && !emailPattern.MatchString(details.Instructor.Email) {
+		return fmt.Errorf("instructor email is invalid")
+	}
+
+	return nil
+}
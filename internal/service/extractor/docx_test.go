@@ -0,0 +1,85 @@
+package extractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func buildDocx(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	archive := zip.NewWriter(&b)
+	for name, content := range files {
+		file, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+func TestExtractDocx(t *testing.T) {
+	const namespace = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "paragraphs and runs",
+			files: map[string]string{
+				"word/document.xml": `<w:document ` + namespace + `><w:body>` +
+					`<w:p><w:r><w:t>Course </w:t></w:r><w:r><w:t>Outline</w:t></w:r></w:p>` +
+					`<w:p><w:r><w:t>Midterm</w:t><w:tab/><w:t>30%</w:t><w:br/><w:t>Final &amp; Exam</w:t></w:r></w:p>` +
+					`</w:body></w:document>`,
+			},
+			want: "Course Outline\nMidterm\t30%\nFinal & Exam\n",
+		},
+		{
+			name: "text outside runs ignored",
+			files: map[string]string{
+				"word/document.xml": `<w:document ` + namespace + `><w:body>` +
+					`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr>style<w:r><w:t>Grading</w:t></w:r></w:p>` +
+					`</w:body></w:document>`,
+			},
+			want: "Grading\n",
+		},
+		{
+			name:    "missing document",
+			files:   map[string]string{"word/styles.xml": "<w:styles/>"},
+			wantErr: true,
+		},
+		{
+			name: "malformed document",
+			files: map[string]string{
+				"word/document.xml": `<w:document ` + namespace + `><w:body><w:p>`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := extractDocx(buildDocx(t, test.files))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("extractDocx() error = %v, want error %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("extractDocx() = %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if _, err := extractDocx([]byte("not a zip")); err == nil {
+		t.Error("extractDocx() on a non zip document returned no error")
+	}
+}
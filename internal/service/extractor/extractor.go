@@ -0,0 +1,121 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JackieLi565/syllabye/internal/service/logger"
+	"github.com/JackieLi565/syllabye/internal/util"
+)
+
+const (
+	// Documents larger than this are not read, syllabi are rarely more than a few megabytes.
+	maxDocumentSize = 50 << 20
+	// Extracted text is truncated to keep its tsvector within the Postgres size limit.
+	maxTextLength = 256 << 10
+)
+
+// Supported document content types.
+const (
+	ContentTypePdf  = "application/pdf"
+	ContentTypeDocx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+// TextExtractor reads the plain text of an uploaded document.
+type TextExtractor interface {
+	// Extract returns the document's text, or [util.ErrMalformed] if the content type is not supported or the document cannot be read.
+	Extract(ctx context.Context, contentType string, body io.Reader) (string, error)
+}
+
+type documentExtractor struct {
+	log logger.Logger
+}
+
+func NewDocumentExtractor(log logger.Logger) *documentExtractor {
+	return &documentExtractor{
+		log: log,
+	}
+}
+
+func (d *documentExtractor) Extract(ctx context.Context, contentType string, body io.Reader) (string, error) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+
+	dat, err := io.ReadAll(io.LimitReader(body, maxDocumentSize+1))
+	if err != nil {
+		d.log.Error("failed to read document", logger.Err(err))
+		return "", util.ErrInternal
+	}
+	if len(dat) > maxDocumentSize {
+		d.log.Info(fmt.Sprintf("document exceeds %d bytes", maxDocumentSize))
+		return "", util.ErrMalformed
+	}
+
+	var text string
+	switch {
+	case mediaType == ContentTypePdf:
+		text, err = extractPdf(dat)
+	case mediaType == ContentTypeDocx:
+		text, err = extractDocx(dat)
+	case strings.HasPrefix(mediaType, "text/"):
+		text = string(dat)
+	default:
+		d.log.Info(fmt.Sprintf("unsupported document content type %s", contentType))
+		return "", util.ErrMalformed
+	}
+	if err != nil {
+		d.log.Info(fmt.Sprintf("failed to extract %s document", mediaType), logger.Err(err))
+		return "", util.ErrMalformed
+	}
+
+	return normalizeText(text), nil
+}
+
+// normalizeText makes extracted text safe to store, collapsing blank space and dropping control characters.
+func normalizeText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+
+	var b strings.Builder
+	b.Grow(len(text))
+	space, newlines := false, 0
+	for _, r := range text {
+		switch {
+		case r == '\n':
+			newlines++
+			space = false
+		case unicode.IsSpace(r):
+			space = true
+		case unicode.IsControl(r) || r == utf8.RuneError:
+			continue
+		default:
+			if b.Len() > 0 {
+				if newlines > 0 {
+					b.WriteString(strings.Repeat("\n", min(newlines, 2)))
+				} else if space {
+					b.WriteByte(' ')
+				}
+			}
+			newlines, space = 0, false
+			b.WriteRune(r)
+		}
+
+		if b.Len() >= maxTextLength {
+			break
+		}
+	}
+
+	// Truncating can split the last rune
+	return strings.ToValidUTF8(truncate(b.String(), maxTextLength), "")
+}
+
+func truncate(text string, length int) string {
+	if len(text) <= length {
+		return text
+	}
+
+	return text[:length]
+}
@@ -0,0 +1,65 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: "",
+		},
+		{
+			name: "collapses spaces",
+			text: "  Course \t\t Outline  ",
+			want: "Course Outline",
+		},
+		{
+			name: "keeps at most one blank line",
+			text: "Grading\n\n\n\n\nMidterm 30%\nFinal 40%\n",
+			want: "Grading\n\nMidterm 30%\nFinal 40%",
+		},
+		{
+			name: "newline takes precedence over spaces",
+			text: "Week 1 \n  Week 2",
+			want: "Week 1\nWeek 2",
+		},
+		{
+			name: "drops control characters and invalid utf8",
+			text: "Lab\x00 1\x07\xff\xfe report",
+			want: "Lab 1 report",
+		},
+		{
+			name: "keeps non ascii text",
+			text: "Café résumé",
+			want: "Café résumé",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := normalizeText(test.text); got != test.want {
+				t.Errorf("normalizeText() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTextTruncates(t *testing.T) {
+	// Two byte runes so the limit can fall within a rune
+	got := normalizeText("a" + strings.Repeat("é", maxTextLength))
+	if len(got) > maxTextLength {
+		t.Errorf("normalizeText() length = %d, want at most %d", len(got), maxTextLength)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("normalizeText() returned invalid utf8")
+	}
+}
@@ -0,0 +1,476 @@
+package extractor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var errNotPdf = errors.New("missing pdf header")
+
+// Decompressed streams are capped in total, so a small document cannot inflate without bound.
+const maxStreamLength = 4 * maxDocumentSize
+
+// Streams with these dictionary entries hold images, fonts or file structure rather than page content.
+var nonContentStreamKeys = [][]byte{
+	[]byte("/Image"),
+	[]byte("/FontFile"),
+	[]byte("/Length1"),
+	[]byte("/Type1C"),
+	[]byte("/XRef"),
+	[]byte("/ObjStm"),
+	[]byte("/Metadata"),
+	[]byte("/EmbeddedFile"),
+}
+
+var (
+	pdfObjectPattern    = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfReferencePattern = regexp.MustCompile(`/([^\s()<>\[\]{}/%]+)\s+(\d+)\s+\d+\s+R\b`)
+)
+
+type pdfStream struct {
+	dict    []byte
+	content []byte
+}
+
+// extractPdf reads the text drawn by a PDF's content streams.
+// Strings are decoded as single byte text, text drawn with composite fonts is skipped as it cannot be decoded without the font's CMap.
+// Scanned documents without a text layer have no text.
+func extractPdf(dat []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(dat, " \t\r\n"), []byte("%PDF-")) {
+		return "", errNotPdf
+	}
+
+	streams := readPdfStreams(dat)
+	composite := compositeFontNames(dat, streams)
+
+	var b strings.Builder
+	for _, stream := range streams {
+		if !isContentStream(stream.dict) {
+			continue
+		}
+
+		extractContentText(stream.content, composite, &b)
+		if b.Len() >= maxTextLength {
+			break
+		}
+	}
+
+	return b.String(), nil
+}
+
+// readPdfStreams reads the content and object streams of a PDF, decompressing them until maxStreamLength bytes are read.
+func readPdfStreams(dat []byte) []pdfStream {
+	var streams []pdfStream
+	remaining := maxStreamLength
+	rest := dat
+	for remaining > 0 {
+		i := bytes.Index(rest, []byte("stream"))
+		if i < 0 {
+			break
+		}
+		if i >= 3 && string(rest[i-3:i]) == "end" {
+			rest = rest[i+len("stream"):]
+			continue
+		}
+
+		// The stream dictionary sits between the object header and the stream keyword
+		dict := rest[:i]
+		if j := bytes.LastIndex(dict, []byte("obj")); j >= 0 {
+			dict = dict[j:]
+		}
+
+		start := i + len("stream")
+		if start < len(rest) && rest[start] == '\r' {
+			start++
+		}
+		if start < len(rest) && rest[start] == '\n' {
+			start++
+		}
+		end := bytes.Index(rest[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		raw := rest[start : start+end]
+		rest = rest[start+end+len("endstream"):]
+
+		if !isContentStream(dict) && !containsPdfName(dict, []byte("/ObjStm")) {
+			continue
+		}
+
+		content := raw
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			reader, err := zlib.NewReader(bytes.NewReader(raw))
+			if err != nil {
+				continue
+			}
+			content, err = io.ReadAll(io.LimitReader(reader, int64(remaining)+1))
+			reader.Close()
+			// Keep what was decoded from truncated streams
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				continue
+			}
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			continue
+		}
+
+		if len(content) > remaining {
+			content = content[:remaining]
+		}
+		remaining -= len(content)
+		streams = append(streams, pdfStream{dict: dict, content: content})
+	}
+
+	return streams
+}
+
+// compositeFontNames finds the resource names referring to Type0 fonts, whether the font objects are stored directly or within object streams.
+// Resource names are per page, so a name used for a Type0 font on any page is treated as composite on every page.
+func compositeFontNames(dat []byte, streams []pdfStream) map[string]bool {
+	composite := map[string]bool{}
+	fonts := map[string]bool{}
+	for _, object := range readPdfObjects(dat) {
+		if containsPdfName(object.body, []byte("/Type0")) {
+			fonts[object.number] = true
+		}
+	}
+	for _, stream := range streams {
+		if !containsPdfName(stream.dict, []byte("/ObjStm")) {
+			continue
+		}
+		for _, object := range readPdfObjectStream(stream) {
+			if containsPdfName(object.body, []byte("/Type0")) {
+				fonts[object.number] = true
+			}
+		}
+	}
+	if len(fonts) == 0 {
+		return composite
+	}
+
+	sources := [][]byte{dat}
+	for _, stream := range streams {
+		if containsPdfName(stream.dict, []byte("/ObjStm")) {
+			sources = append(sources, stream.content)
+		}
+	}
+	for _, source := range sources {
+		for _, match := range pdfReferencePattern.FindAllSubmatch(source, -1) {
+			if fonts[string(match[2])] {
+				composite[string(match[1])] = true
+			}
+		}
+	}
+
+	return composite
+}
+
+type pdfObject struct {
+	number string
+	body   []byte
+}
+
+// readPdfObjects reads the objects stored directly in a PDF, up to their stream or end.
+func readPdfObjects(dat []byte) []pdfObject {
+	var objects []pdfObject
+	for _, match := range pdfObjectPattern.FindAllSubmatchIndex(dat, -1) {
+		body := dat[match[1]:]
+		if end := bytes.Index(body, []byte("endobj")); end >= 0 {
+			body = body[:end]
+		}
+		if end := bytes.Index(body, []byte("stream")); end >= 0 {
+			body = body[:end]
+		}
+		objects = append(objects, pdfObject{number: string(dat[match[2]:match[3]]), body: body})
+	}
+
+	return objects
+}
+
+// readPdfObjectStream reads the objects of an object stream, whose content starts with pairs of object numbers and offsets from /First.
+func readPdfObjectStream(stream pdfStream) []pdfObject {
+	first := pdfDictInt(stream.dict, "/First")
+	if first <= 0 || first > len(stream.content) {
+		return nil
+	}
+
+	header := strings.Fields(string(stream.content[:first]))
+	var objects []pdfObject
+	for i := 0; i+1 < len(header); i += 2 {
+		offset, err := strconv.Atoi(header[i+1])
+		if err != nil || first+offset > len(stream.content) {
+			return objects
+		}
+
+		end := len(stream.content)
+		if i+3 < len(header) {
+			if next, err := strconv.Atoi(header[i+3]); err == nil && next >= offset && first+next <= end {
+				end = first + next
+			}
+		}
+		objects = append(objects, pdfObject{number: header[i], body: stream.content[first+offset : end]})
+	}
+
+	return objects
+}
+
+// pdfDictInt reads an integer dictionary entry, returning 0 if it is missing.
+func pdfDictInt(dict []byte, name string) int {
+	i := bytes.Index(dict, []byte(name))
+	if i < 0 {
+		return 0
+	}
+
+	value := bytes.TrimLeft(dict[i+len(name):], " \t\r\n")
+	j := 0
+	for j < len(value) && value[j] >= '0' && value[j] <= '9' {
+		j++
+	}
+	number, _ := strconv.Atoi(string(value[:j]))
+
+	return number
+}
+
+func isContentStream(dict []byte) bool {
+	for _, key := range nonContentStreamKeys {
+		if containsPdfName(dict, key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsPdfName reports whether the name appears as a whole name, so /Length1 does not match /Length10.
+func containsPdfName(dict []byte, name []byte) bool {
+	for offset := 0; ; {
+		i := bytes.Index(dict[offset:], name)
+		if i < 0 {
+			return false
+		}
+
+		end := offset + i + len(name)
+		if end == len(dict) || isPdfSpace(dict[end]) || isPdfDelimiter(dict[end]) {
+			return true
+		}
+		offset = end
+	}
+}
+
+// extractContentText writes the strings shown by the text operators of a content stream, skipping strings shown with composite fonts.
+func extractContentText(content []byte, composite map[string]bool, b *strings.Builder) {
+	var texts []string
+	var numbers []float64
+	var name string
+	inText, inArray, skipText := false, false, false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isPdfSpace(c):
+			i++
+		case c == '(':
+			text, n := readLiteralString(content[i:])
+			texts = append(texts, text)
+			i += n
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case c == '<':
+			text, n := readHexString(content[i:])
+			texts = append(texts, text)
+			i += n
+		case c == '>':
+			i++
+		case c == '[':
+			inArray = true
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case c == '{' || c == '}':
+			i++
+		case c == '/':
+			j := i + 1
+			for j < len(content) && !isPdfSpace(content[j]) && !isPdfDelimiter(content[j]) {
+				j++
+			}
+			name = string(content[i+1 : j])
+			i = j
+		case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(content) && (content[j] == '.' || (content[j] >= '0' && content[j] <= '9')) {
+				j++
+			}
+			number, _ := strconv.ParseFloat(string(content[i:j]), 64)
+			// Large negative adjustments within a TJ array separate words
+			if inArray && number < -200 {
+				texts = append(texts, " ")
+			}
+			numbers = append(numbers, number)
+			i = j
+		default:
+			j := i
+			for j < len(content) && !isPdfSpace(content[j]) && !isPdfDelimiter(content[j]) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			operator := string(content[i:j])
+			i = j
+
+			switch operator {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				b.WriteByte('\n')
+			case "Tf":
+				skipText = composite[name]
+			case "Tj", "TJ":
+				if inText && !skipText {
+					b.WriteString(strings.Join(texts, ""))
+				}
+			case "'", "\"":
+				if inText && !skipText {
+					b.WriteByte('\n')
+					b.WriteString(strings.Join(texts, ""))
+				}
+			case "T*", "Tm":
+				if inText {
+					b.WriteByte('\n')
+				}
+			case "Td", "TD":
+				if inText {
+					if len(numbers) >= 2 && numbers[len(numbers)-1] != 0 {
+						b.WriteByte('\n')
+					} else {
+						b.WriteByte(' ')
+					}
+				}
+			case "ID":
+				// Skip inline image data, which ends at the EI operator
+				end := bytes.Index(content[i:], []byte("EI"))
+				if end < 0 {
+					return
+				}
+				i += end + 2
+			}
+
+			texts, numbers, name = texts[:0], numbers[:0], ""
+		}
+	}
+}
+
+// readLiteralString reads a parenthesized string, returning its text and the number of bytes read.
+func readLiteralString(content []byte) (string, int) {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				b.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return decodePdfBytes([]byte(b.String())), i + 1
+			}
+			b.WriteByte(c)
+		case '\\':
+			i++
+			if i >= len(content) {
+				break
+			}
+			switch escaped := content[i]; escaped {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'b', 'f':
+			case '\r':
+				// Line continuation
+				if i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					value := 0
+					j := i
+					for ; j < len(content) && j < i+3 && content[j] >= '0' && content[j] <= '7'; j++ {
+						value = value*8 + int(content[j]-'0')
+					}
+					b.WriteByte(byte(value))
+					i = j - 1
+				} else {
+					b.WriteByte(escaped)
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return decodePdfBytes([]byte(b.String())), len(content)
+}
+
+// readHexString reads an angle bracketed hex string, returning its text and the number of bytes read.
+func readHexString(content []byte) (string, int) {
+	end := bytes.IndexByte(content, '>')
+	if end < 0 {
+		return "", len(content)
+	}
+
+	digits := make([]byte, 0, end)
+	for _, c := range content[1:end] {
+		if !isPdfSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	dat := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		value, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			return "", end + 1
+		}
+		dat = append(dat, byte(value))
+	}
+
+	return decodePdfBytes(dat), end + 1
+}
+
+// decodePdfBytes decodes single byte text, treating it as Latin-1 which matches the printable ASCII range of simple font encodings.
+func decodePdfBytes(dat []byte) string {
+	runes := make([]rune, 0, len(dat))
+	for _, c := range dat {
+		runes = append(runes, rune(c))
+	}
+
+	return string(runes)
+}
+
+func isPdfSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func isPdfDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
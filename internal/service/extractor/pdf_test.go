@@ -0,0 +1,124 @@
+package extractor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func buildPdf(objects ...string) []byte {
+	var b strings.Builder
+	b.WriteString("%PDF-1.7\n")
+	for i, object := range objects {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return []byte(b.String())
+}
+
+func pdfStreamObject(dict string, content string, compress bool) string {
+	dat := []byte(content)
+	if compress {
+		var b bytes.Buffer
+		writer := zlib.NewWriter(&b)
+		writer.Write(dat)
+		writer.Close()
+		dat = b.Bytes()
+		dict += " /Filter /FlateDecode"
+	}
+
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(dat), dat)
+}
+
+func TestExtractPdf(t *testing.T) {
+	tests := []struct {
+		name string
+		dat  []byte
+		want string
+		err  error
+	}{
+		{
+			name: "missing header",
+			dat:  []byte("not a pdf"),
+			err:  errNotPdf,
+		},
+		{
+			name: "uncompressed text",
+			dat: buildPdf(
+				"<< /Type /Page /Resources << /Font << /F1 3 0 R >> >> /Contents 2 0 R >>",
+				pdfStreamObject("", "BT /F1 12 Tf (Course Outline) Tj ET", false),
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			),
+			want: "Course Outline\n",
+		},
+		{
+			name: "compressed text",
+			dat: buildPdf(
+				"<< /Type /Page /Resources << /Font << /F1 3 0 R >> >> /Contents 2 0 R >>",
+				pdfStreamObject("", "BT /F1 12 Tf (CPS 109) Tj 0 -14 Td (Computer Science I) Tj ET", true),
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+			),
+			want: "CPS 109\nComputer Science I\n",
+		},
+		{
+			name: "text array spacing and escapes",
+			dat: buildPdf(
+				pdfStreamObject("", `BT [(Final)-250(Exam)] TJ T* (\(40\\%\) caf\351) Tj ET`, false),
+			),
+			want: "Final Exam\n(40\\%) café\n",
+		},
+		{
+			name: "hex string",
+			dat: buildPdf(
+				pdfStreamObject("", "BT <4D6964 7465726D> Tj ET", false),
+			),
+			want: "Midterm\n",
+		},
+		{
+			name: "non content streams skipped",
+			dat: buildPdf(
+				pdfStreamObject("/Type /XObject /Subtype /Image /Width 1 /Height 1", "BT (pixels) Tj ET", false),
+				pdfStreamObject("/Filter /DCTDecode", "BT (jpeg) Tj ET", false),
+				pdfStreamObject("", "BT (Grading) Tj ET", false),
+			),
+			want: "Grading\n",
+		},
+		{
+			name: "composite font skipped",
+			dat: buildPdf(
+				"<< /Type /Page /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents 2 0 R >>",
+				pdfStreamObject("", "BT /F1 12 Tf <00360037> Tj /F2 12 Tf (Textbook) Tj ET", false),
+				"<< /Type /Font /Subtype /Type0 /BaseFont /Arial /Encoding /Identity-H /DescendantFonts [5 0 R] >>",
+				"<< /Type /Font /Subtype /TrueType /BaseFont /Arial >>",
+				"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Arial >>",
+			),
+			want: "Textbook\n",
+		},
+		{
+			name: "composite font in object stream skipped",
+			dat: buildPdf(
+				"<< /Type /Page /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 2 0 R >>",
+				pdfStreamObject("", "BT /F1 12 Tf <0036> Tj /F2 12 Tf (Labs) Tj ET", false),
+				pdfStreamObject("/Type /ObjStm /N 2 /First 9",
+					"5 0 6 56 << /Type /Font /Subtype /Type0 /Encoding /Identity-H >> << /Type /Font /Subtype /Type1 >>", true),
+			),
+			want: "Labs\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := extractPdf(test.dat)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("extractPdf() error = %v, want %v", err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("extractPdf() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
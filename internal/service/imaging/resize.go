@@ -0,0 +1,50 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/JackieLi565/syllabye/internal/util"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// maxPixels guards against decompression bombs, small files which decode into huge images.
+const maxPixels = 40_000_000
+
+// DecodeImage decodes a jpeg, png or webp image returning the image and its format name.
+func DecodeImage(data []byte) (image.Image, string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > maxPixels {
+		return nil, "", util.ErrMalformed
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", util.ErrMalformed
+	}
+
+	return img, format, nil
+}
+
+// SquareJpeg center crops an image to a square and scales it to size x size, encoded as a jpeg.
+func SquareJpeg(img image.Image, size int) ([]byte, error) {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, util.ErrInternal
+	}
+
+	return buf.Bytes(), nil
+}
@@ -67,6 +67,14 @@ func (s *SqlBuilder) Build() string {
 	return strings.TrimSpace(s.query.String())
 }
 
+// likeEscaper escapes the LIKE wildcards with Postgres' default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ContainsPattern returns a LIKE pattern matching values containing the text literally.
+func ContainsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
 func (s *SqlBuilder) writeClause(clause string) {
 	s.query.WriteString(fmt.Sprintf("\n%s", clause))
 }
@@ -0,0 +1,7 @@
+alter table users
+    drop column ig_handle_visibility,
+    drop column current_year_visibility,
+    drop column gender_visibility,
+    drop column email_visibility;
+
+drop type visibility_type;
@@ -0,0 +1,11 @@
+create type visibility_type as enum (
+    'Public',
+    'Program',
+    'Private'
+    );
+
+alter table users
+    add column email_visibility        visibility_type not null default 'Private',
+    add column gender_visibility       visibility_type not null default 'Private',
+    add column current_year_visibility visibility_type not null default 'Program',
+    add column ig_handle_visibility    visibility_type not null default 'Public';
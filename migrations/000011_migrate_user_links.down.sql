@@ -0,0 +1,24 @@
+alter table users
+    rename column links_visibility to ig_handle_visibility;
+
+alter table users
+    add column ig_handle text;
+
+update users u
+set ig_handle = substring(l.link from 'instagram\.com/([^/?#]+)')
+from (select distinct on (user_id) user_id, link
+      from user_links
+      where type = 'Instagram'
+      order by user_id, date_added) l
+where l.user_id = u.id;
+
+drop index user_id_user_links_idx;
+
+alter table user_links
+    drop constraint user_links_link_uq,
+    drop constraint user_links_user_id_fkey,
+    add constraint user_links_user_id_fkey foreign key (user_id) references users (id),
+    alter column type type text using type::text,
+    alter column type set default 'Link';
+
+drop type link_type;
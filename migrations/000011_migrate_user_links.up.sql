@@ -0,0 +1,34 @@
+create type link_type as enum (
+    'GitHub',
+    'LinkedIn',
+    'Website',
+    'Instagram'
+    );
+
+alter table user_links
+    alter column type drop default;
+
+update user_links
+set type = 'Website'
+where type not in ('GitHub', 'LinkedIn', 'Website', 'Instagram');
+
+alter table user_links
+    alter column type type link_type using type::link_type,
+    drop constraint user_links_user_id_fkey,
+    add constraint user_links_user_id_fkey foreign key (user_id) references users (id) on delete cascade,
+    add constraint user_links_link_uq unique (user_id, link);
+
+create index user_id_user_links_idx on user_links (user_id);
+
+insert into user_links (user_id, type, link)
+select id, 'Instagram', 'https://www.instagram.com/' || trim(leading '@' from trim(ig_handle))
+from users
+where ig_handle is not null
+  and trim(leading '@' from trim(ig_handle)) <> ''
+on conflict do nothing;
+
+alter table users
+    drop column ig_handle;
+
+alter table users
+    rename column ig_handle_visibility to links_visibility;
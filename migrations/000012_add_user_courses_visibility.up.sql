@@ -0,0 +1,2 @@
+alter table users
+    add column courses_visibility visibility_type not null default 'Public';
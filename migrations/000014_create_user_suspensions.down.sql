@@ -0,0 +1,6 @@
+drop table user_suspensions;
+
+alter table users
+    drop column role;
+
+drop type user_role;
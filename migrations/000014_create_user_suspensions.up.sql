@@ -0,0 +1,18 @@
+create type user_role as enum ('User', 'Admin');
+
+alter table users
+    add column role user_role not null default 'User';
+
+create table user_suspensions
+(
+    id           uuid primary key   default gen_random_uuid(),
+    user_id      uuid      not null references users (id) on delete cascade,
+    suspended_by uuid references users (id) on delete set null,
+    reason       text      not null check (length(reason) > 0),
+    date_expires timestamp,
+    lifted_by    uuid references users (id) on delete set null,
+    date_lifted  timestamp,
+    date_added   timestamp not null default now()
+);
+
+create index user_id_user_suspensions_idx on user_suspensions (user_id);
@@ -0,0 +1,9 @@
+alter table syllabi
+    drop column date_removed,
+    drop column date_expired,
+    drop column date_rejected,
+    drop column date_published,
+    drop column status_reason,
+    drop column status;
+
+drop type syllabus_status;
@@ -0,0 +1,23 @@
+create type syllabus_status as enum (
+    'Uploading',
+    'Verifying',
+    'Published',
+    'Rejected',
+    'Expired',
+    'Removed'
+    );
+
+alter table syllabi
+    add column status         syllabus_status not null default 'Uploading',
+    add column status_reason  text,
+    add column date_published timestamp,
+    add column date_rejected  timestamp,
+    add column date_expired   timestamp,
+    add column date_removed   timestamp;
+
+update syllabi
+set status         = 'Published',
+    date_published = date_synced
+where date_synced is not null;
+
+create index status_syllabi_idx on syllabi (status);
@@ -0,0 +1,4 @@
+drop table syllabus_revisions;
+
+alter table syllabi
+    drop column revision;
@@ -0,0 +1,38 @@
+alter table syllabi
+    add column revision smallint not null default 1;
+
+create table syllabus_revisions
+(
+    syllabus_id    uuid            not null references syllabi (id) on delete cascade,
+    revision       smallint        not null check (revision > 0),
+    object_key     text            not null unique,
+    file           text            not null,
+    content_type   text            not null,
+    file_size      integer         not null,
+    status         syllabus_status not null default 'Uploading' check (status <> 'Removed'),
+    status_reason  text,
+    date_added     timestamp       not null default now(),
+    date_synced    timestamp,
+    date_published timestamp,
+    date_rejected  timestamp,
+    date_expired   timestamp,
+    constraint syllabus_revisions_pk primary key (syllabus_id, revision)
+);
+
+-- Existing syllabi become their own first revision, stored under the syllabus id
+insert into syllabus_revisions (syllabus_id, revision, object_key, file, content_type, file_size, status,
+                                status_reason, date_added, date_synced, date_published, date_rejected, date_expired)
+select id,
+       1,
+       id::text,
+       file,
+       content_type,
+       file_size,
+       case when status = 'Removed' then 'Published' else status end,
+       case when status = 'Rejected' then status_reason end,
+       date_added,
+       date_synced,
+       date_published,
+       date_rejected,
+       date_expired
+from syllabi;
@@ -0,0 +1,11 @@
+drop index syllabi_sha256_uq;
+
+drop index syllabi_checksum_uq;
+
+alter table syllabus_revisions
+    drop column sha256,
+    drop column checksum;
+
+alter table syllabi
+    drop column sha256,
+    drop column checksum;
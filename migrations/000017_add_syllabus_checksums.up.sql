@@ -0,0 +1,14 @@
+alter table syllabi
+    add column checksum text,
+    add column sha256   text;
+
+alter table syllabus_revisions
+    add column checksum text,
+    add column sha256   text;
+
+-- Syllabi which are expired, rejected or removed no longer block uploading the same file
+create unique index syllabi_checksum_uq on syllabi (course_id, year, semester, checksum)
+    where status in ('Uploading', 'Verifying', 'Published');
+
+create unique index syllabi_sha256_uq on syllabi (course_id, year, semester, sha256)
+    where status in ('Verifying', 'Published');
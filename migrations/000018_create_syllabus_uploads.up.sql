@@ -0,0 +1,20 @@
+create table syllabus_uploads
+(
+    id             uuid primary key   default gen_random_uuid(),
+    user_id        uuid      not null references users (id) on delete cascade,
+    syllabus_id    uuid      not null,
+    revision       smallint  not null,
+    multipart_id   text      not null,
+    upload_length  bigint    not null check (upload_length > 0),
+    upload_offset  bigint    not null default 0 check (upload_offset between 0 and upload_length),
+    part_count     integer   not null default 0,
+    pending_part   bytea     not null default '',
+    date_added     timestamp not null default now(),
+    date_modified  timestamp not null default now(),
+    date_completed timestamp,
+    constraint syllabus_uploads_revision_fk foreign key (syllabus_id, revision)
+        references syllabus_revisions (syllabus_id, revision) on delete cascade
+);
+
+-- A revision is only uploaded through one resumable upload at a time
+create unique index syllabus_uploads_active_uq on syllabus_uploads (syllabus_id, revision) where date_completed is null;
@@ -0,0 +1,4 @@
+alter table syllabi
+    drop column batch_id;
+
+drop table syllabus_batches;
@@ -0,0 +1,12 @@
+create table syllabus_batches
+(
+    id            uuid primary key   default gen_random_uuid(),
+    user_id       uuid      not null references users (id) on delete cascade,
+    date_added    timestamp not null default now(),
+    date_verified timestamp
+);
+
+alter table syllabi
+    add column batch_id uuid references syllabus_batches (id) on delete set null;
+
+create index batch_id_syllabi_idx on syllabi (batch_id);
@@ -0,0 +1,10 @@
+create table syllabus_texts
+(
+    syllabus_id uuid primary key references syllabi (id) on delete cascade,
+    revision    smallint  not null,
+    content     text      not null,
+    document    tsvector  not null generated always as (to_tsvector('english', content)) stored,
+    date_added  timestamp not null default now()
+);
+
+create index document_syllabus_texts_idx on syllabus_texts using gin (document);
@@ -0,0 +1,10 @@
+create table syllabus_details
+(
+    syllabus_id   uuid primary key references syllabi (id) on delete cascade,
+    revision      smallint  not null,
+    details       jsonb     not null,
+    confidence    real      not null check (confidence between 0 and 1),
+    edited        boolean   not null default false,
+    date_added    timestamp not null default now(),
+    date_modified timestamp not null default now()
+);
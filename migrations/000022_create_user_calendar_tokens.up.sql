@@ -0,0 +1,7 @@
+-- Calendar feeds are fetched by calendar clients without a session, only a hash of the secret token is stored
+create table user_calendar_tokens
+(
+    user_id    uuid primary key references users (id) on delete cascade,
+    token_hash text      not null unique,
+    date_added timestamp not null default now()
+);
@@ -0,0 +1,2 @@
+alter table syllabi
+    drop column thumbnail_revision;
@@ -0,0 +1,4 @@
+-- Revision of the syllabus file whose thumbnail was found in the thumbnail bucket,
+-- null until the thumbnail lambda's output is seen so a new revision has no thumbnail until its own is rendered
+alter table syllabi
+    add column thumbnail_revision smallint;
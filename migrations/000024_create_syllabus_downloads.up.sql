@@ -0,0 +1,13 @@
+-- Downloads are recorded per request, unlike views which are recorded once per user
+create table syllabus_downloads
+(
+    id          bigint generated always as identity primary key,
+    syllabus_id uuid      not null references syllabi (id) on delete cascade,
+    revision    smallint  not null,
+    user_id     uuid      not null references users (id) on delete cascade,
+    date_added  timestamp not null default now()
+);
+
+create index user_id_date_added_syllabus_downloads_idx on syllabus_downloads (user_id, date_added);
+
+create index syllabus_id_syllabus_downloads_idx on syllabus_downloads (syllabus_id);
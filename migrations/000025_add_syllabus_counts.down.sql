@@ -0,0 +1,20 @@
+drop trigger date_modified on syllabi;
+
+create trigger date_modified
+    before update
+    on syllabi
+    for each row
+execute function date_modified();
+
+drop trigger syllabus_view_count on syllabus_views;
+
+drop function syllabus_view_count;
+
+drop trigger syllabus_like_count on syllabus_likes;
+
+drop function syllabus_like_count;
+
+alter table syllabi
+    drop column like_count,
+    drop column dislike_count,
+    drop column view_count;
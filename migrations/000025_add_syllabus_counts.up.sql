@@ -0,0 +1,65 @@
+alter table syllabi
+    add column like_count    integer not null default 0,
+    add column dislike_count integer not null default 0,
+    add column view_count    integer not null default 0;
+
+update syllabi s
+set like_count    = (select count(*) from syllabus_likes l where l.syllabus_id = s.id and not l.is_dislike),
+    dislike_count = (select count(*) from syllabus_likes l where l.syllabus_id = s.id and l.is_dislike),
+    view_count    = (select count(*) from syllabus_views v where v.syllabus_id = s.id);
+
+create function syllabus_like_count() returns trigger as
+$syllabus_like_count$
+begin
+    if TG_OP in ('UPDATE', 'DELETE') then
+        update syllabi
+        set like_count    = like_count - (not OLD.is_dislike)::integer,
+            dislike_count = dislike_count - OLD.is_dislike::integer
+        where id = OLD.syllabus_id;
+    end if;
+
+    if TG_OP in ('INSERT', 'UPDATE') then
+        update syllabi
+        set like_count    = like_count + (not NEW.is_dislike)::integer,
+            dislike_count = dislike_count + NEW.is_dislike::integer
+        where id = NEW.syllabus_id;
+    end if;
+
+    return null;
+end;
+$syllabus_like_count$ language plpgsql;
+
+create trigger syllabus_like_count
+    after insert or update or delete
+    on syllabus_likes
+    for each row
+execute function syllabus_like_count();
+
+create function syllabus_view_count() returns trigger as
+$syllabus_view_count$
+begin
+    if TG_OP = 'DELETE' then
+        update syllabi set view_count = view_count - 1 where id = OLD.syllabus_id;
+    else
+        update syllabi set view_count = view_count + 1 where id = NEW.syllabus_id;
+    end if;
+
+    return null;
+end;
+$syllabus_view_count$ language plpgsql;
+
+create trigger syllabus_view_count
+    after insert or delete
+    on syllabus_views
+    for each row
+execute function syllabus_view_count();
+
+-- Counting a reaction or view does not modify the syllabus
+drop trigger date_modified on syllabi;
+
+create trigger date_modified
+    before update
+    on syllabi
+    for each row
+    when (OLD.like_count = NEW.like_count and OLD.dislike_count = NEW.dislike_count and OLD.view_count = NEW.view_count)
+execute function date_modified();
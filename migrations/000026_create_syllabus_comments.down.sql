@@ -0,0 +1 @@
+drop table syllabus_comments;
@@ -0,0 +1,21 @@
+-- Comments are soft deleted so replies keep their place in the thread
+create table syllabus_comments
+(
+    id             uuid primary key   default gen_random_uuid(),
+    syllabus_id    uuid      not null references syllabi (id) on delete cascade,
+    user_id        uuid      references users (id) on delete set null,
+    thread_id      uuid references syllabus_comments (id) on delete cascade,
+    parent_id      uuid references syllabus_comments (id) on delete cascade,
+    content        text      not null check (length(content) > 0),
+    date_edited    timestamp,
+    date_deleted   timestamp,
+    removed_by     uuid references users (id) on delete set null,
+    removed_reason text,
+    date_removed   timestamp,
+    date_added     timestamp not null default now(),
+    check ((thread_id is null) = (parent_id is null))
+);
+
+create index syllabus_id_date_added_syllabus_comments_idx on syllabus_comments (syllabus_id, date_added) where thread_id is null;
+
+create index thread_id_date_added_syllabus_comments_idx on syllabus_comments (thread_id, date_added);
@@ -0,0 +1 @@
+drop table course_reviews;
@@ -0,0 +1,26 @@
+-- Reviews reference the reviewer's user course, so only users who recorded taking a course may review it
+create table course_reviews
+(
+    id            uuid primary key       default gen_random_uuid(),
+    user_id       uuid          not null,
+    course_id     uuid          not null,
+    difficulty    smallint      not null check (difficulty between 1 and 5),
+    workload      smallint      not null check (workload between 1 and 5),
+    usefulness    smallint      not null check (usefulness between 1 and 5),
+    year          smallint      not null check (year > 0),
+    semester      semester_type not null,
+    content       text check (length(content) > 0),
+    date_added    timestamp     not null default now(),
+    date_modified timestamp     not null default now(),
+    constraint user_course_course_reviews_fk foreign key (user_id, course_id)
+        references user_courses (user_id, course_id) on delete cascade,
+    constraint user_id_course_id_course_reviews_key unique (user_id, course_id)
+);
+
+create index course_id_course_reviews_idx on course_reviews (course_id);
+
+create trigger date_modified
+    before update
+    on course_reviews
+    for each row
+execute function date_modified();
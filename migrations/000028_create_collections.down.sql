@@ -0,0 +1,5 @@
+drop table collection_syllabi;
+
+drop table collections;
+
+drop type collection_visibility;
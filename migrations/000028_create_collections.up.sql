@@ -0,0 +1,37 @@
+create type collection_visibility as enum ('Private', 'Shared');
+
+create table collections
+(
+    id            uuid primary key               default gen_random_uuid(),
+    user_id       uuid                  not null references users (id) on delete cascade,
+    name          text                  not null check (length(name) between 1 and 100),
+    description   text check (length(description) > 0),
+    visibility    collection_visibility not null default 'Private',
+    -- Token of the read only link, shared collections can be read by any signed in user with the link
+    share_token   text                  not null unique default replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', ''),
+    is_default    boolean               not null default false,
+    date_added    timestamp             not null default now(),
+    date_modified timestamp             not null default now()
+);
+
+create index user_id_collections_idx on collections (user_id);
+
+-- Each user has at most one default collection for bookmarks
+create unique index user_id_default_collections_idx on collections (user_id) where is_default;
+
+create trigger date_modified
+    before update
+    on collections
+    for each row
+execute function date_modified();
+
+create table collection_syllabi
+(
+    collection_id uuid      not null references collections (id) on delete cascade,
+    syllabus_id   uuid      not null references syllabi (id) on delete cascade,
+    position      integer   not null,
+    date_added    timestamp not null default now(),
+    constraint collection_syllabi_pk primary key (collection_id, syllabus_id)
+);
+
+create index syllabus_id_collection_syllabi_idx on collection_syllabi (syllabus_id);
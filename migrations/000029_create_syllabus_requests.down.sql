@@ -0,0 +1,5 @@
+drop table syllabus_request_votes;
+
+drop table syllabus_requests;
+
+drop type syllabus_request_status;
@@ -0,0 +1,35 @@
+create type syllabus_request_status as enum ('Open', 'Fulfilled');
+
+-- Requests without a term are fulfilled by a syllabus of any term
+create table syllabus_requests
+(
+    id             uuid primary key                 default gen_random_uuid(),
+    course_id      uuid                    not null references courses (id),
+    year           smallint check (year > 0),
+    semester       semester_type,
+    user_id        uuid                    references users (id) on delete set null,
+    status         syllabus_request_status not null default 'Open',
+    syllabus_id    uuid                    references syllabi (id) on delete set null,
+    date_fulfilled timestamp,
+    date_added     timestamp               not null default now(),
+    check ((year is null) = (semester is null))
+);
+
+-- Users asking for the same syllabus vote on a single open request
+create unique index course_id_term_open_syllabus_requests_idx
+    on syllabus_requests (course_id, year, semester)
+    where status = 'Open';
+
+create unique index course_id_open_syllabus_requests_idx
+    on syllabus_requests (course_id)
+    where status = 'Open' and year is null;
+
+create index status_syllabus_requests_idx on syllabus_requests (status);
+
+create table syllabus_request_votes
+(
+    request_id uuid      not null references syllabus_requests (id) on delete cascade,
+    user_id    uuid      not null references users (id) on delete cascade,
+    date_added timestamp not null default now(),
+    constraint syllabus_request_votes_pk primary key (request_id, user_id)
+);
@@ -0,0 +1,8 @@
+drop index instructor_id_syllabi_idx;
+
+alter table syllabi
+    drop column instructor_id;
+
+drop table instructors;
+
+drop extension pg_trgm;
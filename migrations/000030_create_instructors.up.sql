@@ -0,0 +1,19 @@
+create extension if not exists pg_trgm;
+
+create table instructors
+(
+    id         uuid primary key   default gen_random_uuid(),
+    name       text      not null check (length(trim(name)) > 0),
+    user_id    uuid      references users (id) on delete set null,
+    date_added timestamp not null default now()
+);
+
+create unique index name_instructors_idx on instructors (lower(name));
+
+-- Similar names are suggested when adding an instructor to avoid duplicates
+create index name_trgm_instructors_idx on instructors using gin (name gin_trgm_ops);
+
+alter table syllabi
+    add column instructor_id uuid references instructors (id) on delete set null;
+
+create index instructor_id_syllabi_idx on syllabi (instructor_id);
@@ -0,0 +1,9 @@
+drop table syllabus_removal_requests;
+
+drop table instructor_claims;
+
+alter table instructors
+    drop column date_verified,
+    drop column verified_user_id;
+
+drop type approval_status;
@@ -0,0 +1,45 @@
+create type approval_status as enum ('Pending', 'Approved', 'Rejected');
+
+-- Set once an admin approves a claim, syllabi the verified user uploads for the instructor are official
+alter table instructors
+    add column verified_user_id uuid unique references users (id) on delete set null,
+    add column date_verified    timestamp;
+
+create table instructor_claims
+(
+    id            uuid primary key   default gen_random_uuid(),
+    instructor_id uuid            not null references instructors (id) on delete cascade,
+    user_id       uuid            not null references users (id) on delete cascade,
+    message       text,
+    status        approval_status not null default 'Pending',
+    reviewed_by   uuid            references users (id) on delete set null,
+    review_reason text,
+    date_reviewed timestamp,
+    date_added    timestamp       not null default now()
+);
+
+create unique index instructor_id_user_id_pending_instructor_claims_idx
+    on instructor_claims (instructor_id, user_id)
+    where status = 'Pending';
+
+create index status_instructor_claims_idx on instructor_claims (status);
+
+-- Verified instructors request removal of copies of their syllabi uploaded by others
+create table syllabus_removal_requests
+(
+    id            uuid primary key   default gen_random_uuid(),
+    syllabus_id   uuid            not null references syllabi (id) on delete cascade,
+    user_id       uuid            references users (id) on delete set null,
+    reason        text            not null,
+    status        approval_status not null default 'Pending',
+    reviewed_by   uuid            references users (id) on delete set null,
+    review_reason text,
+    date_reviewed timestamp,
+    date_added    timestamp       not null default now()
+);
+
+create unique index syllabus_id_pending_syllabus_removal_requests_idx
+    on syllabus_removal_requests (syllabus_id)
+    where status = 'Pending';
+
+create index status_syllabus_removal_requests_idx on syllabus_removal_requests (status);
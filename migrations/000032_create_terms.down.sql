@@ -0,0 +1,21 @@
+drop trigger user_course_term on user_courses;
+
+drop function user_course_term();
+
+drop function taken_term(semester_type, timestamp);
+
+alter table user_courses
+    drop column term_id;
+
+drop trigger syllabus_term on syllabi;
+
+drop function syllabus_term();
+
+drop index term_id_syllabi_idx;
+
+alter table syllabi
+    drop column term_id;
+
+drop function find_term(smallint, semester_type);
+
+drop table terms;
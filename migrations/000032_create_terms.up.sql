@@ -0,0 +1,163 @@
+create table terms
+(
+    id          uuid primary key       default gen_random_uuid(),
+    code        text          not null,
+    name        text          not null,
+    year        smallint      not null check (year > 0),
+    semester    semester_type not null,
+    institution text          not null default 'Toronto Metropolitan University',
+    date_start  date          not null,
+    date_end    date          not null,
+    check (date_end >= date_start),
+    constraint terms_institution_code_uq unique (institution, code),
+    constraint terms_institution_year_semester_uq unique (institution, year, semester)
+);
+
+create index date_start_date_end_terms_idx on terms (date_start, date_end);
+
+-- resolve_term returns the default institution's term for a year and semester, creating it with the usual dates if missing.
+-- It is only used to seed and backfill existing rows, afterwards terms are added explicitly
+create function resolve_term(term_year smallint, term_semester semester_type) returns uuid as
+$resolve_term$
+declare
+    term_start date;
+    term_end   date;
+    resolved   uuid;
+begin
+    select id into resolved
+    from terms
+    where institution = 'Toronto Metropolitan University' and year = term_year and semester = term_semester;
+
+    if resolved is not null then
+        return resolved;
+    end if;
+
+    case term_semester
+        when 'Winter' then term_start = make_date(term_year, 1, 1); term_end = make_date(term_year, 4, 30);
+        when 'Spring' then term_start = make_date(term_year, 5, 1); term_end = make_date(term_year, 6, 30);
+        when 'Summer' then term_start = make_date(term_year, 7, 1); term_end = make_date(term_year, 8, 31);
+        when 'Spring/Summer' then term_start = make_date(term_year, 5, 1); term_end = make_date(term_year, 8, 31);
+        else term_start = make_date(term_year, 9, 1); term_end = make_date(term_year, 12, 31);
+        end case;
+
+    insert into terms (code, name, year, semester, date_start, date_end)
+    values (term_year || '-' || lower(replace(term_semester::text, '/', '-')), term_semester || ' ' || term_year,
+            term_year, term_semester, term_start, term_end)
+    on conflict do nothing
+    returning id into resolved;
+
+    if resolved is null then
+        select id into resolved
+        from terms
+        where institution = 'Toronto Metropolitan University' and year = term_year and semester = term_semester;
+    end if;
+
+    return resolved;
+end;
+$resolve_term$ language plpgsql;
+
+-- Seed every semester from 2000 through next year so past and current syllabi resolve a term,
+-- later years are added through the terms API
+select resolve_term(y::smallint, s)
+from generate_series(2000, extract(year from now())::int + 1) y,
+     unnest(enum_range(null::semester_type)) s;
+
+alter table syllabi
+    add column term_id uuid references terms (id);
+
+alter table syllabi
+    disable trigger date_modified;
+
+update syllabi
+set term_id = resolve_term(year, semester);
+
+alter table syllabi
+    enable trigger date_modified;
+
+alter table syllabi
+    alter column term_id set not null;
+
+create index term_id_syllabi_idx on syllabi (term_id);
+
+-- find_term returns the default institution's term for a year and semester, raising a check violation if there is none
+create function find_term(term_year smallint, term_semester semester_type) returns uuid as
+$find_term$
+declare
+    found uuid;
+begin
+    select id into found
+    from terms
+    where institution = 'Toronto Metropolitan University' and year = term_year and semester = term_semester;
+
+    if found is null then
+        raise exception 'no term for % %', term_semester, term_year using errcode = 'check_violation';
+    end if;
+
+    return found;
+end;
+$find_term$ language plpgsql;
+
+-- Year and semester are kept for existing queries, they follow the term when it is set and find it otherwise
+create function syllabus_term() returns trigger as
+$syllabus_term$
+begin
+    if NEW.term_id is not null and (TG_OP = 'INSERT' or NEW.term_id is distinct from OLD.term_id) then
+        select year, semester into NEW.year, NEW.semester from terms where id = NEW.term_id;
+    elsif TG_OP = 'INSERT' or NEW.year is distinct from OLD.year or NEW.semester is distinct from OLD.semester then
+        NEW.term_id = find_term(NEW.year, NEW.semester);
+    end if;
+    return NEW;
+end;
+$syllabus_term$ language plpgsql;
+
+create trigger syllabus_term
+    before insert or update
+    on syllabi
+    for each row
+execute function syllabus_term();
+
+alter table user_courses
+    add column term_id uuid references terms (id);
+
+-- taken_term returns the term a course of a semester was taken in, assuming courses are added during or after that term.
+-- year_taken is the year of study rather than a calendar year, so the latest term starting before the course was added is used
+create function taken_term(term_semester semester_type, added timestamp) returns uuid as
+$taken_term$
+select id
+from terms
+where institution = 'Toronto Metropolitan University' and semester = term_semester and date_start <= added
+order by date_start desc
+limit 1;
+$taken_term$ language sql stable;
+
+alter table user_courses
+    disable trigger date_modified;
+
+-- Courses without a semester taken have no term
+update user_courses
+set term_id = taken_term(semester_taken, date_added)
+where semester_taken is not null;
+
+alter table user_courses
+    enable trigger date_modified;
+
+drop function resolve_term(smallint, semester_type);
+
+-- The semester taken follows the term when it is set, otherwise the term is derived from the semester taken or cleared with it
+create function user_course_term() returns trigger as
+$user_course_term$
+begin
+    if NEW.term_id is not null and (TG_OP = 'INSERT' or NEW.term_id is distinct from OLD.term_id) then
+        select semester into NEW.semester_taken from terms where id = NEW.term_id;
+    elsif TG_OP = 'INSERT' or NEW.semester_taken is distinct from OLD.semester_taken then
+        NEW.term_id = taken_term(NEW.semester_taken, NEW.date_added);
+    end if;
+    return NEW;
+end;
+$user_course_term$ language plpgsql;
+
+create trigger user_course_term
+    before insert or update
+    on user_courses
+    for each row
+execute function user_course_term();
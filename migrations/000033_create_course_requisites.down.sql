@@ -0,0 +1,12 @@
+drop table course_requisites;
+
+drop table course_requisite_groups;
+
+alter table courses
+    drop column prerequisites,
+    drop column corequisites,
+    drop column antirequisites;
+
+drop type requisite_operator;
+
+drop type requisite_type;
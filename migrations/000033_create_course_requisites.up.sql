@@ -0,0 +1,34 @@
+create type requisite_type as enum ('Prerequisite', 'Corequisite', 'Antirequisite');
+
+create type requisite_operator as enum ('All', 'Any');
+
+-- Requisites as published in the academic calendar, including conditions which are not courses
+alter table courses
+    add column prerequisites  text,
+    add column corequisites   text,
+    add column antirequisites text;
+
+-- Each course has at most one root group per requisite type, nested groups express combinations such as "one of"
+create table course_requisite_groups
+(
+    id         uuid primary key            default gen_random_uuid(),
+    course_id  uuid               not null references courses (id) on delete cascade,
+    parent_id  uuid references course_requisite_groups (id) on delete cascade,
+    type       requisite_type     not null,
+    operator   requisite_operator not null default 'All',
+    date_added timestamp          not null default now()
+);
+
+create unique index course_requisite_groups_root_uq on course_requisite_groups (course_id, type) where parent_id is null;
+
+create index parent_id_course_requisite_groups_idx on course_requisite_groups (parent_id);
+
+create table course_requisites
+(
+    group_id  uuid not null references course_requisite_groups (id) on delete cascade,
+    course_id uuid not null references courses (id) on delete cascade,
+    primary key (group_id, course_id)
+);
+
+-- Looks up the courses a course unlocks
+create index course_id_course_requisites_idx on course_requisites (course_id);
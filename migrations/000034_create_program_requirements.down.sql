@@ -0,0 +1,10 @@
+drop table program_requirement_categories;
+
+drop table program_requirement_courses;
+
+drop table program_requirements;
+
+drop type program_requirement_type;
+
+alter table courses
+    drop column credits;
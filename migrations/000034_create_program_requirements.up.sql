@@ -0,0 +1,35 @@
+alter table courses
+    add column credits numeric(3, 2) not null default 1 check (credits >= 0);
+
+create type program_requirement_type as enum ('Required', 'Elective', 'Credits');
+
+-- Required requirements need every listed course. Elective requirements need credits from the listed courses and
+-- categories, Credits requirements count credits from any course when nothing is listed.
+create table program_requirements
+(
+    id          uuid primary key                  default gen_random_uuid(),
+    program_id  uuid                     not null references programs (id) on delete cascade,
+    name        text                     not null check (length(trim(name)) > 0),
+    description text,
+    type        program_requirement_type not null,
+    credits     numeric(5, 2) check (credits > 0),
+    position    smallint                 not null default 0,
+    date_added  timestamp                not null default now(),
+    check ((type = 'Required') = (credits is null))
+);
+
+create index program_id_program_requirements_idx on program_requirements (program_id, position);
+
+create table program_requirement_courses
+(
+    requirement_id uuid not null references program_requirements (id) on delete cascade,
+    course_id      uuid not null references courses (id) on delete cascade,
+    primary key (requirement_id, course_id)
+);
+
+create table program_requirement_categories
+(
+    requirement_id uuid not null references program_requirements (id) on delete cascade,
+    category_id    uuid not null references course_categories (id) on delete cascade,
+    primary key (requirement_id, category_id)
+);
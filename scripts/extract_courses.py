@@ -115,6 +115,9 @@ for row in table_rows:
                 "course": payload["courseCode"],
                 "alpha": payload.get("courseAlphaCode", None),
                 "code": payload.get("courseNumberCode", None),
+                "prerequisites": payload.get("prerequisites", None),
+                "corequisites": payload.get("corequisites", None),
+                "antirequisites": payload.get("antirequisites", None),
             }
         )
 
@@ -24,6 +24,7 @@ from psycopg2.extras import execute_values
 from datetime import datetime
 import argparse
 from dotenv import dotenv_values
+from requisites import course_key, parse_antirequisites, parse_requisites, resolve_requisites
 
 parser = argparse.ArgumentParser(description="Syllabye Data Loader Script")
 
@@ -137,7 +138,8 @@ course_category_map = {name: category_id for category_id, name in cur.fetchall()
 execute_values(
     cur=cur,
     sql=r"""
-insert into courses (category_id, title, description, uri, course, alpha, code)
+insert into courses (category_id, title, description, uri, course, alpha, code,
+    prerequisites, corequisites, antirequisites)
 values %s;
 """,
     argslist=[
@@ -149,11 +151,69 @@ values %s;
             course["course"],
             course["alpha"],
             course["code"],
+            course.get("prerequisites"),
+            course.get("corequisites"),
+            course.get("antirequisites"),
         )
         for course in courses
     ],
 )
 
+cur.execute(
+    r"""
+select id, course
+from courses;
+"""
+)
+course_map = {course_key(code): course_id for course_id, code in cur.fetchall()}
+
+
+def insert_requisite_group(course_id, requisite_type, node, parent_id=None):
+    """Inserts a resolved requisite group and its nested groups."""
+    cur.execute(
+        r"""
+insert into course_requisite_groups (course_id, parent_id, type, operator)
+values (%s, %s, %s, %s)
+returning id;
+""",
+        (course_id, parent_id, requisite_type, node["operator"]),
+    )
+    group_id = cur.fetchone()[0]
+
+    if node["courses"]:
+        execute_values(
+            cur=cur,
+            sql=r"""
+insert into course_requisites (group_id, course_id)
+values %s;
+""",
+            argslist=[(group_id, required_id) for required_id in node["courses"]],
+        )
+
+    for child in node["groups"]:
+        insert_requisite_group(course_id, requisite_type, child, group_id)
+
+
+# Insert course_requisite_groups and course_requisites
+for course in courses:
+    course_id = course_map[course_key(course["course"])]
+    for requisite_type, field in (
+        ("Prerequisite", "prerequisites"),
+        ("Corequisite", "corequisites"),
+        ("Antirequisite", "antirequisites"),
+    ):
+        # Taking any antirequisite excludes the course, whatever the wording
+        if requisite_type == "Antirequisite":
+            node = parse_antirequisites(course.get(field))
+        else:
+            node = parse_requisites(course.get(field))
+        node = resolve_requisites(node, course_map, course_id)
+        if node is None:
+            continue
+        insert_requisite_group(course_id, requisite_type, node)
+
+v_print("[database] course requisites inserted")
+
 conn.commit()
 v_print("[database] transaction committed with no issues")
 
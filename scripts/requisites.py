@@ -0,0 +1,136 @@
+"""
+requisites.py
+
+Parses the requisite text of Toronto Metropolitan University's academic calendar into
+requisite groups of the form {"operator", "courses", "groups"}, used by load_data.py.
+"""
+
+import re
+
+# Requisite text is tokenized into course codes, "and", "or" and parentheses, other conditions are ignored
+requisite_token = re.compile(r"\(|\)|\band\b|\bor\b|[A-Z]{2,4} ?\d{3}[A-Z]?", re.IGNORECASE)
+
+
+def course_key(code):
+    """Normalizes a course code for lookups, e.g. "cps109" and "CPS 109" match."""
+    return code.replace(" ", "").upper()
+
+
+def parse_requisites(text):
+    """
+    Parses calendar requisite text into a group of the form {"operator", "courses", "groups"}.
+    "and" binds tighter than "or", adjacent courses without an operator are all required.
+    Returns None when the text has no course codes.
+    """
+    tokens = requisite_token.findall(text or "")
+    pos = 0
+
+    def peek():
+        return tokens[pos].lower() if pos < len(tokens) else None
+
+    def group(operator, terms):
+        node = {"operator": operator, "courses": [], "groups": []}
+        for term in terms:
+            if isinstance(term, str):
+                node["courses"].append(term)
+            elif term["operator"] == operator:
+                node["courses"] += term["courses"]
+                node["groups"] += term["groups"]
+            else:
+                node["groups"].append(term)
+        return node
+
+    def parse_atom():
+        nonlocal pos
+        token = tokens[pos]
+        pos += 1
+        if token == "(":
+            term = parse_or()
+            if peek() == ")":
+                pos += 1
+            return term
+        return course_key(token)
+
+    def parse_and():
+        nonlocal pos
+        terms = []
+        while peek() not in (None, "or", ")"):
+            if peek() == "and":
+                pos += 1
+                continue
+            term = parse_atom()
+            if term is not None:
+                terms.append(term)
+        if len(terms) == 1:
+            return terms[0]
+        return group("All", terms) if terms else None
+
+    def parse_or():
+        nonlocal pos
+        terms = []
+        while True:
+            term = parse_and()
+            if term is not None:
+                terms.append(term)
+            if peek() != "or":
+                break
+            pos += 1
+        if len(terms) == 1:
+            return terms[0]
+        return group("Any", terms) if terms else None
+
+    # Unbalanced closing parentheses are skipped
+    terms = []
+    while pos < len(tokens):
+        term = parse_or()
+        if term is not None:
+            terms.append(term)
+        if peek() == ")":
+            pos += 1
+
+    if not terms:
+        return None
+    if len(terms) == 1 and isinstance(terms[0], dict):
+        return terms[0]
+    return group("All", terms)
+
+
+def parse_antirequisites(text):
+    """Parses antirequisite text into a single Any group of its course codes, returning None when it has none."""
+    codes = [token for token in requisite_token.findall(text or "") if token[0].isalpha() and token.lower() not in ("and", "or")]
+    if not codes:
+        return None
+    return {"operator": "Any", "courses": [course_key(code) for code in codes], "groups": []}
+
+
+def resolve_requisites(node, course_ids, course_id=None):
+    """
+    Maps the course codes of a parsed requisite group to ids, dropping codes missing from course_ids and the course itself.
+    Nested groups left empty are dropped, groups left with a single course or group, or with the operator of their parent,
+    are merged into their parent. Returns None when no courses remain.
+    """
+    if node is None:
+        return None
+
+    courses = []
+    for code in node["courses"]:
+        required_id = course_ids.get(code)
+        if required_id is not None and required_id != course_id and required_id not in courses:
+            courses.append(required_id)
+
+    groups = []
+    for child in node["groups"]:
+        child = resolve_requisites(child, course_ids, course_id)
+        if child is None:
+            continue
+        if child["operator"] == node["operator"] or len(child["courses"]) + len(child["groups"]) == 1:
+            courses += [required_id for required_id in child["courses"] if required_id not in courses]
+            groups += child["groups"]
+        else:
+            groups.append(child)
+
+    if not courses and not groups:
+        return None
+    if not courses and len(groups) == 1:
+        return groups[0]
+    return {"operator": node["operator"], "courses": courses, "groups": groups}
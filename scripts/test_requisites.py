@@ -0,0 +1,77 @@
+"""
+Tests for requisites.py.
+
+Usage:
+    python -m unittest test_requisites
+"""
+
+import unittest
+
+from requisites import parse_antirequisites, parse_requisites, resolve_requisites
+
+
+def group(operator, courses=(), groups=()):
+    return {"operator": operator, "courses": list(courses), "groups": list(groups)}
+
+
+class ParseRequisitesTest(unittest.TestCase):
+    def test_parse_requisites(self):
+        tests = [
+            ("empty", "", None),
+            ("no courses", "Departmental consent", None),
+            ("single course", "CPS 109", group("All", ["CPS109"])),
+            ("and", "CPS 109 and MTH 110", group("All", ["CPS109", "MTH110"])),
+            ("or", "CPS 109 or CPS 106", group("Any", ["CPS109", "CPS106"])),
+            ("and binds tighter than or", "CPS 109 and MTH 110 or CPS 106",
+             group("Any", ["CPS106"], [group("All", ["CPS109", "MTH110"])])),
+            ("parentheses", "CPS 109 and (MTH 110 or MTH 207)",
+             group("All", ["CPS109"], [group("Any", ["MTH110", "MTH207"])])),
+            ("nested operators flatten", "(CPS 109 and CPS 209) and CPS 213",
+             group("All", ["CPS109", "CPS209", "CPS213"])),
+            ("adjacent courses are all required", "CPS 109, CPS 209", group("All", ["CPS109", "CPS209"])),
+            ("case and spacing", "cps109 OR cps 106", group("Any", ["CPS109", "CPS106"])),
+            ("unbalanced parentheses", "CPS 109) or (CPS 106", group("All", ["CPS109", "CPS106"])),
+        ]
+        for name, text, want in tests:
+            with self.subTest(name):
+                self.assertEqual(parse_requisites(text), want)
+
+    def test_parse_antirequisites(self):
+        tests = [
+            ("empty", None, None),
+            ("any wording", "CPS 109 and (CPS 106 or CPS 118)", group("Any", ["CPS109", "CPS106", "CPS118"])),
+        ]
+        for name, text, want in tests:
+            with self.subTest(name):
+                self.assertEqual(parse_antirequisites(text), want)
+
+
+class ResolveRequisitesTest(unittest.TestCase):
+    course_ids = {"CPS109": "cps109", "CPS209": "cps209", "CPS106": "cps106", "MTH110": "mth110", "MTH207": "mth207"}
+
+    def test_resolve_requisites(self):
+        tests = [
+            ("none", None, None),
+            ("maps codes", group("All", ["CPS109", "MTH110"]), group("All", ["cps109", "mth110"])),
+            ("drops missing courses", group("Any", ["CPS109", "XYZ100"]), group("Any", ["cps109"])),
+            ("drops the course itself", group("All", ["CPS109", "CPS209"]), group("All", ["cps109"])),
+            ("drops duplicates", group("Any", ["CPS109", "CPS109"]), group("Any", ["cps109"])),
+            ("drops empty nested groups", group("Any", ["CPS106"], [group("All", ["XYZ100", "XYZ200"])]),
+             group("Any", ["cps106"])),
+            ("merges single course groups", group("All", ["CPS106"], [group("Any", ["MTH110", "XYZ100"])]),
+             group("All", ["cps106", "mth110"])),
+            ("merges groups with the parent's operator", group("All", [], [group("All", ["CPS106", "MTH110"])]),
+             group("All", ["cps106", "mth110"])),
+            ("keeps groups with another operator", group("All", ["CPS106"], [group("Any", ["MTH110", "MTH207"])]),
+             group("All", ["cps106"], [group("Any", ["mth110", "mth207"])])),
+            ("unwraps a single group", group("All", ["XYZ100"], [group("Any", ["MTH110", "MTH207"])]),
+             group("Any", ["mth110", "mth207"])),
+            ("all courses missing", group("Any", [], [group("All", ["XYZ100"])]), None),
+        ]
+        for name, node, want in tests:
+            with self.subTest(name):
+                self.assertEqual(resolve_requisites(node, self.course_ids, "cps209"), want)
+
+
+if __name__ == "__main__":
+    unittest.main()
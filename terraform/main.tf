@@ -66,16 +66,27 @@ module "thumbnail_bucket" {
   bucket = var.aws_s3_thumbnail_bucket
 }
 
+module "avatar_bucket" {
+  source = "./modules/s3/avatar"
+  bucket = var.aws_s3_avatar_bucket
+}
+
 module "webhook_queue" {
   source        = "./modules/sqs"
   function_name = module.webhook_lambda.function_name
 }
 
 module "emailer" {
-  source                       = "./modules/ses"
-  is_dev                       = local.is_dev
-  domain                       = var.domain
-  welcome_template_name        = var.welcome_template_name
-  upload_success_template_name = var.upload_success_template_name
-  upload_error_template_name   = var.upload_error_template_name
+  source                          = "./modules/ses"
+  is_dev                          = local.is_dev
+  domain                          = var.domain
+  welcome_template_name           = var.welcome_template_name
+  upload_success_template_name    = var.upload_success_template_name
+  upload_error_template_name      = var.upload_error_template_name
+  suspension_template_name        = var.suspension_template_name
+  batch_summary_template_name     = var.batch_summary_template_name
+  comment_reply_template_name     = var.comment_reply_template_name
+  syllabus_comment_template_name  = var.syllabus_comment_template_name
+  request_fulfilled_template_name = var.request_fulfilled_template_name
+  syllabus_removed_template_name  = var.syllabus_removed_template_name
 }
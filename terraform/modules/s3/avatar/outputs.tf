@@ -0,0 +1,4 @@
+output "bucket_arn" {
+  value       = aws_s3_bucket.this.arn
+  description = "ARN of avatar bucket"
+}
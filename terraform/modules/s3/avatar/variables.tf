@@ -0,0 +1,4 @@
+variable "bucket" {
+  type        = string
+  description = "Avatar bucket name"
+}
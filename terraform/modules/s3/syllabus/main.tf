@@ -23,3 +23,19 @@ resource "aws_s3_bucket_notification" "this" {
     }
   }
 }
+
+# Resumable uploads which are never completed leave their parts behind
+resource "aws_s3_bucket_lifecycle_configuration" "this" {
+  bucket = aws_s3_bucket.this.id
+
+  rule {
+    id     = "abort-incomplete-multipart-uploads"
+    status = "Enabled"
+
+    filter {}
+
+    abort_incomplete_multipart_upload {
+      days_after_initiation = 2
+    }
+  }
+}
@@ -1038,3 +1038,2086 @@ resource "aws_ses_template" "upload_error" {
 </html>
 EOT
 }
+
+resource "aws_ses_template" "suspension" {
+  name    = var.suspension_template_name
+  subject = "Your Syllabye Account Has Been Suspended"
+  text    = "Hi {{name}}, your Syllabye account has been suspended {{duration}}. Reason: {{reason}}"
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Account Suspended</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Your Syllabye account has been suspended {{duration}}. While
+                    suspended you will not be able to sign in or use Syllabye.
+                  </p>
+
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Reason: {{reason}}
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    If you believe this was a mistake, feel free to reach out to
+                    us at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for your understanding.
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
+
+resource "aws_ses_template" "batch_summary" {
+  name    = var.batch_summary_template_name
+  subject = "Your Syllabye Upload Summary"
+  text    = "Hi {{name}}, we received {{received}} of the {{total}} syllabi you submitted.{{#each items}} {{course}}: {{outcome}}.{{/each}}"
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Upload Summary</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    We received {{received}} of the {{total}} syllabi you
+                    submitted. Here's how each of them went:
+                  </p>
+
+                  <ul
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{#each items}}
+                    <li>{{course}}: {{outcome}}</li>
+                    {{/each}}
+                  </ul>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Syllabi which were not received can be submitted again at any
+                    time. If you have any questions, feel free to reach out to
+                    us at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for contributing to Syllabye!
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
+
+resource "aws_ses_template" "comment_reply" {
+  name    = var.comment_reply_template_name
+  subject = "New Reply to Your Syllabye Comment"
+  text    = "Hi {{name}}, someone replied to your comment on the {{course}} syllabus: \"{{comment}}\""
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Comment Reply</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Someone replied to your comment on the {{course}} syllabus:
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    <span style="font-style: italic">"{{comment}}"</span>
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Sign in to Syllabye to join the discussion. If you have any
+                    questions, feel free to reach out to
+                    us at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for contributing to Syllabye!
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
+
+resource "aws_ses_template" "syllabus_comment" {
+  name    = var.syllabus_comment_template_name
+  subject = "New Comment on Your Syllabye Upload"
+  text    = "Hi {{name}}, someone commented on the {{course}} syllabus you uploaded: \"{{comment}}\""
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Syllabus Comment</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Someone commented on the {{course}} syllabus you uploaded:
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    <span style="font-style: italic">"{{comment}}"</span>
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Sign in to Syllabye to join the discussion. If you have any
+                    questions, feel free to reach out to
+                    us at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for contributing to Syllabye!
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
+
+resource "aws_ses_template" "request_fulfilled" {
+  name    = var.request_fulfilled_template_name
+  subject = "A Syllabus You Requested Is Available"
+  text    = "Hi {{name}}, a syllabus for {{course}} which you requested has been uploaded to Syllabye."
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Request Fulfilled</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    A syllabus for {{course}} which you requested has been uploaded.
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Sign in to Syllabye to view it. If you have any
+                    questions, feel free to reach out to
+                    us at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for contributing to Syllabye!
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
+
+resource "aws_ses_template" "syllabus_removed" {
+  name    = var.syllabus_removed_template_name
+  subject = "Your Syllabus Was Removed"
+  text    = "{{name}}, your syllabus for {{course}} was removed from Syllabye. {{reason}}"
+  html    = <<EOT
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <meta http-equiv="Content-Type" content="text/html; charset=UTF-8" />
+    <title>Syllabus Removed</title>
+    <style media="all" type="text/css">
+      @media all {
+        .btn-primary table td:hover {
+          background-color: #ec0867 !important;
+        }
+
+        .btn-primary a:hover {
+          background-color: #ec0867 !important;
+          border-color: #ec0867 !important;
+        }
+      }
+      @media only screen and (max-width: 640px) {
+        .main p,
+        .main td,
+        .main span {
+          font-size: 16px !important;
+        }
+
+        .wrapper {
+          padding: 8px !important;
+        }
+
+        .content {
+          padding: 0 !important;
+        }
+
+        .container {
+          padding: 0 !important;
+          padding-top: 8px !important;
+          width: 100% !important;
+        }
+
+        .main {
+          border-left-width: 0 !important;
+          border-radius: 0 !important;
+          border-right-width: 0 !important;
+        }
+
+        .btn table {
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+
+        .btn a {
+          font-size: 16px !important;
+          max-width: 100% !important;
+          width: 100% !important;
+        }
+      }
+      @media all {
+        .ExternalClass {
+          width: 100%;
+        }
+
+        .ExternalClass,
+        .ExternalClass p,
+        .ExternalClass span,
+        .ExternalClass font,
+        .ExternalClass td,
+        .ExternalClass div {
+          line-height: 100%;
+        }
+
+        .apple-link a {
+          color: inherit !important;
+          font-family: inherit !important;
+          font-size: inherit !important;
+          font-weight: inherit !important;
+          line-height: inherit !important;
+          text-decoration: none !important;
+        }
+
+        #MessageViewBody a {
+          color: inherit;
+          text-decoration: none;
+          font-size: inherit;
+          font-family: inherit;
+          font-weight: inherit;
+          line-height: inherit;
+        }
+      }
+    </style>
+  </head>
+  <body
+    style="
+      font-family: Helvetica, sans-serif;
+      -webkit-font-smoothing: antialiased;
+      font-size: 16px;
+      line-height: 1.3;
+      -ms-text-size-adjust: 100%;
+      -webkit-text-size-adjust: 100%;
+      background-color: #f4f5f6;
+      margin: 0;
+      padding: 0;
+    "
+  >
+    <table
+      role="presentation"
+      border="0"
+      cellpadding="0"
+      cellspacing="0"
+      class="body"
+      style="
+        border-collapse: separate;
+        mso-table-lspace: 0pt;
+        mso-table-rspace: 0pt;
+        background-color: #f4f5f6;
+        width: 100%;
+      "
+      width="100%"
+      bgcolor="#f4f5f6"
+    >
+      <tr>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+        <td
+          class="container"
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+            max-width: 600px;
+            padding: 0;
+            padding-top: 24px;
+            width: 600px;
+            margin: 0 auto;
+          "
+          width="600"
+          valign="top"
+        >
+          <div
+            class="content"
+            style="
+              box-sizing: border-box;
+              display: block;
+              margin: 0 auto;
+              max-width: 600px;
+              padding: 0;
+            "
+          >
+            <table
+              role="presentation"
+              border="0"
+              cellpadding="0"
+              cellspacing="0"
+              class="main"
+              style="
+                border-collapse: separate;
+                mso-table-lspace: 0pt;
+                mso-table-rspace: 0pt;
+                background: #ffffff;
+                border: 1px solid #eaebed;
+                border-radius: 16px;
+                width: 100%;
+              "
+              width="100%"
+            >
+              <tr>
+                <td
+                  class="wrapper"
+                  style="
+                    font-family: Helvetica, sans-serif;
+                    font-size: 16px;
+                    vertical-align: top;
+                    box-sizing: border-box;
+                    padding: 24px;
+                  "
+                  valign="top"
+                >
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{name}},
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Your syllabus for {{course}} was removed from Syllabye and is
+                    no longer visible to other students.
+                  </p>
+
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    {{reason}}
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    If this doesn&apos;t seem right, feel free to reach out to us
+                    at
+                    <span style="text-decoration: underline; font-weight: bold"
+                      >TODO@torontomu.ca</span
+                    >
+                  </p>
+                  <p
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      font-size: 16px;
+                      font-weight: normal;
+                      margin: 0;
+                      margin-bottom: 16px;
+                    "
+                  >
+                    Thank you for your contribution to the Syllabye community.
+                  </p>
+
+                  The Syllabye Team
+                </td>
+              </tr>
+            </table>
+
+            <div
+              class="footer"
+              style="
+                clear: both;
+                padding-top: 24px;
+                text-align: center;
+                width: 100%;
+              "
+            >
+              <table
+                role="presentation"
+                border="0"
+                cellpadding="0"
+                cellspacing="0"
+                style="
+                  border-collapse: separate;
+                  mso-table-lspace: 0pt;
+                  mso-table-rspace: 0pt;
+                  width: 100%;
+                "
+                width="100%"
+              >
+                <tr>
+                  <td
+                    class="content-block"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    <span
+                      class="apple-link"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                      "
+                      >Syllabye Co.</span
+                    >
+                    <br />
+                  </td>
+                </tr>
+                <tr>
+                  <td
+                    class="content-block powered-by"
+                    style="
+                      font-family: Helvetica, sans-serif;
+                      vertical-align: top;
+                      color: #9a9ea6;
+                      font-size: 16px;
+                      text-align: center;
+                    "
+                    valign="top"
+                    align="center"
+                  >
+                    Powered by
+                    <a
+                      href="https://aws.amazon.com/ses/"
+                      style="
+                        color: #9a9ea6;
+                        font-size: 16px;
+                        text-align: center;
+                        text-decoration: none;
+                      "
+                      >Amazon Web Services</a
+                    >
+                  </td>
+                </tr>
+              </table>
+            </div>
+          </div>
+        </td>
+        <td
+          style="
+            font-family: Helvetica, sans-serif;
+            font-size: 16px;
+            vertical-align: top;
+          "
+          valign="top"
+        >
+          &nbsp;
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+EOT
+}
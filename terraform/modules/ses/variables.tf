@@ -11,3 +11,15 @@ variable "welcome_template_name" {}
 variable "upload_success_template_name" {}
 
 variable "upload_error_template_name" {}
+
+variable "suspension_template_name" {}
+
+variable "batch_summary_template_name" {}
+
+variable "comment_reply_template_name" {}
+
+variable "syllabus_comment_template_name" {}
+
+variable "request_fulfilled_template_name" {}
+
+variable "syllabus_removed_template_name" {}
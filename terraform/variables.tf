@@ -34,11 +34,46 @@ variable "upload_error_template_name" {
   description = "Name for upload error template"
 }
 
+variable "suspension_template_name" {
+  type        = string
+  description = "Name for account suspension template"
+}
+
+variable "batch_summary_template_name" {
+  type        = string
+  description = "Name for batch upload summary template"
+}
+
+variable "comment_reply_template_name" {
+  type        = string
+  description = "Name for comment reply template"
+}
+
+variable "syllabus_comment_template_name" {
+  type        = string
+  description = "Name for syllabus comment template"
+}
+
+variable "request_fulfilled_template_name" {
+  type        = string
+  description = "Name for syllabus request fulfilled template"
+}
+
+variable "syllabus_removed_template_name" {
+  type        = string
+  description = "Name for syllabus removed template"
+}
+
 variable "aws_s3_thumbnail_bucket" {
   type        = string
   description = "Name of thumbnail bucket"
 }
 
+variable "aws_s3_avatar_bucket" {
+  type        = string
+  description = "Name of avatar bucket"
+}
+
 variable "domain" {
   type        = string
   description = "Domain name"